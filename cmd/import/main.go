@@ -0,0 +1,104 @@
+// Command import creates or updates the products of a seller from a csv or ndjson file, the same way as
+// POST /api/product/import does.
+//
+//	go run ./cmd/import -user-id 1 -file products.csv -report report.csv
+package main
+
+import (
+	"context"
+	"ecommerce/internal"
+	"ecommerce/model/request"
+	"ecommerce/repository/postgre"
+	"ecommerce/service"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	support "ecommerce/utils/logger"
+)
+
+func main() {
+	userID := flag.Int64("user-id", 0, "seller the products are imported for")
+	fileName := flag.String("file", "", "csv or ndjson file to import")
+	format := flag.String("format", "", "csv or ndjson, taken from the file extension when empty")
+	reportName := flag.String("report", "", "file the per row report is written to, the report is not written when empty")
+	actor := flag.String("actor", "import-cli", "actor the changes are recorded for in the product history")
+	flag.Parse()
+
+	if *userID <= 0 || *fileName == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	config := internal.InitConfig()
+	logger := support.NewLogger()
+	db := internal.NewDatabases(config, logger)
+	storageRepo, err := internal.NewStorage(config)
+	if err != nil {
+		logger.Fatalf("failed to initialize storage: %v", err)
+	}
+
+	ecommerceService := service.NewEcommerceService(
+		service.EcommerceConfig{
+			EcommerceRepo:     postgre.NewEcommerce(db["main"]),
+			InventoryRepo:     postgre.NewInventory(db["main"]),
+			CategoryRepo:      postgre.NewCategory(db["main"]),
+			ProductImportRepo: postgre.NewProductImport(db["main"]),
+			StorageRepo:       storageRepo,
+			AuditLogRepo:      postgre.NewAuditLog(db["main"]),
+			ProductPriceRepo:  postgre.NewProductPrice(db["main"]),
+			TransactionRepo:   postgre.NewTransaction(db["main"]),
+			Currency:          config.Currency.Default,
+		},
+	)
+
+	file, err := os.Open(*fileName)
+	if err != nil {
+		logger.Fatalf("failed to open %s: %v", *fileName, err)
+	}
+	defer file.Close()
+
+	ctx := service.WithActor(context.Background(), *actor)
+	resp, err := ecommerceService.ImportProducts(ctx, request.ImportProducts{
+		UserID:   *userID,
+		Format:   *format,
+		FileName: *fileName,
+	}, file)
+	if err != nil {
+		logger.Fatalf("failed to import %s: %v", *fileName, err)
+	}
+
+	fmt.Printf("import %d: %d rows, %d created, %d updated, %d failed\n",
+		resp.Data.ID, resp.Data.TotalRows, resp.Data.CreatedRows, resp.Data.UpdatedRows, resp.Data.FailedRows)
+
+	if *reportName == "" {
+		return
+	}
+
+	err = writeReport(ctx, &ecommerceService, resp.Data.ID, *reportName)
+	if err != nil {
+		logger.Fatalf("failed to write the report: %v", err)
+	}
+}
+
+func writeReport(ctx context.Context, ecommerceService service.EcommerceProvider, importID int64, name string) error {
+	report, err := ecommerceService.GetProductImportReport(ctx, importID)
+	if err != nil {
+		return err
+	}
+	defer report.Close()
+
+	file, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(file, report)
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
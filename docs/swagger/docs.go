@@ -1,5 +1,4 @@
-// Package swagger GENERATED BY SWAG; DO NOT EDIT
-// This file was generated by swaggo/swag
+// Package swagger Code generated by swaggo/swag. DO NOT EDIT
 package swagger
 
 import "github.com/swaggo/swag"
@@ -16,22 +15,62 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/product": {
+        "/cart/{user_id}": {
+            "get": {
+                "description": "get cart of a user along with its subtotal and total weight",
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "get cart of a user",
+                "operationId": "v1-GetCart",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetCartResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/cart/{user_id}/items": {
             "post": {
-                "description": "create a product",
+                "description": "add a product to cart, the product price is snapshotted when it is added. Products with variants are added by variant_id and take the price of the variant when it has one",
                 "tags": [
-                    "Product"
+                    "Cart"
                 ],
-                "summary": "create a product",
-                "operationId": "v1-CreateProduct",
+                "summary": "add a product to cart",
+                "operationId": "v1-AddCartItem",
                 "parameters": [
                     {
-                        "description": "UpsertProduct",
-                        "name": "UpsertProduct",
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "UpsertCartItem",
+                        "name": "UpsertCartItem",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.UpsertProduct"
+                            "$ref": "#/definitions/request.UpsertCartItem"
                         }
                     }
                 ],
@@ -51,14 +90,88 @@ const docTemplate = `{
                 }
             }
         },
-        "/product/list": {
-            "get": {
-                "operationId": "v1-GetProductList",
+        "/cart/{user_id}/items/{product_id}": {
+            "put": {
+                "description": "update the quantity of a product in cart, a quantity of 0 removes it. variant_id picks the variant of a product with variants",
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "update a cart item",
+                "operationId": "v1-UpdateCartItem",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "UpsertCartItem",
+                        "name": "UpsertCartItem",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpsertCartItem"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.GetProductListResponse"
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "remove a product from cart",
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "remove a cart item",
+                "operationId": "v1-RemoveCartItem",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Variant ID, for products with variants",
+                        "name": "variant_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
                         }
                     },
                     "400": {
@@ -70,22 +183,86 @@ const docTemplate = `{
                 }
             }
         },
-        "/product/review": {
+        "/cart/{user_id}/promotions": {
             "post": {
-                "description": "create a product review",
+                "description": "get the discount breakdown of the automatic promotions and the entered codes for the cart of a user, the codes which can't be applied are listed with the reason why",
                 "tags": [
-                    "Product"
+                    "Cart"
                 ],
-                "summary": "create a product review",
-                "operationId": "v1-CreateProductReview",
+                "summary": "evaluate promotions of a cart",
+                "operationId": "v1-EvaluateCartPromotions",
                 "parameters": [
                     {
-                        "description": "UpsertProductReview",
-                        "name": "UpsertProductReview",
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ApplyPromotions",
+                        "name": "ApplyPromotions",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.UpsertProductReview"
+                            "$ref": "#/definitions/request.ApplyPromotions"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetCartPromotionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/category": {
+            "get": {
+                "description": "get all categories nested under their parent",
+                "tags": [
+                    "Category"
+                ],
+                "summary": "get category tree",
+                "operationId": "v1-GetCategoryList",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetCategoryListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "create a category, the slug is generated from the name when it is empty",
+                "tags": [
+                    "Category"
+                ],
+                "summary": "create a category",
+                "operationId": "v1-CreateCategory",
+                "parameters": [
+                    {
+                        "description": "UpsertCategory",
+                        "name": "UpsertCategory",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpsertCategory"
                         }
                     }
                 ],
@@ -105,19 +282,19 @@ const docTemplate = `{
                 }
             }
         },
-        "/product/{product_id}": {
+        "/category/{category_id}": {
             "get": {
-                "description": "get a product",
+                "description": "get a category along with its sub categories",
                 "tags": [
-                    "Product"
+                    "Category"
                 ],
-                "summary": "get a product",
-                "operationId": "v1-GetDetailProduct",
+                "summary": "get a category",
+                "operationId": "v1-GetDetailCategory",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Product ID",
-                        "name": "product_id",
+                        "description": "Category ID",
+                        "name": "category_id",
                         "in": "path",
                         "required": true
                     }
@@ -126,7 +303,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.BaseResponse"
+                            "$ref": "#/definitions/response.GetCategoryDetailResponse"
                         }
                     },
                     "400": {
@@ -138,27 +315,27 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "update a product",
+                "description": "update a category, it can't be moved below one of its sub categories",
                 "tags": [
-                    "Product"
+                    "Category"
                 ],
-                "summary": "update a product",
-                "operationId": "v1-UpdateProduct",
+                "summary": "update a category",
+                "operationId": "v1-UpdateCategory",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Product ID",
-                        "name": "product_id",
+                        "description": "Category ID",
+                        "name": "category_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "UpsertProduct",
-                        "name": "UpsertProduct",
+                        "description": "UpsertCategory",
+                        "name": "UpsertCategory",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.UpsertProduct"
+                            "$ref": "#/definitions/request.UpsertCategory"
                         }
                     }
                 ],
@@ -176,111 +353,4305 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "entity.Product": {
-            "type": "object",
-            "properties": {
-                "category": {
-                    "type": "string"
-                },
-                "createdAt": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "etalase": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "price": {
-                    "type": "integer"
-                },
-                "rating": {
-                    "type": "number"
-                },
-                "sku": {
-                    "type": "string"
-                },
-                "title": {
-                    "type": "string"
-                },
-                "updatedAt": {
-                    "type": "string"
-                },
-                "userID": {
-                    "type": "integer"
-                },
-                "weight": {
-                    "type": "number"
+            },
+            "delete": {
+                "description": "delete a category which has no sub categories and no products",
+                "tags": [
+                    "Category"
+                ],
+                "summary": "delete a category",
+                "operationId": "v1-DeleteCategory",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category ID",
+                        "name": "category_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
                 }
             }
         },
-        "request.UpsertProduct": {
-            "type": "object",
-            "properties": {
-                "category": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "etalase": {
-                    "type": "string"
-                },
-                "price": {
-                    "type": "integer"
-                },
-                "product_images": {
-                    "type": "array",
-                    "items": {
-                        "type": "object",
-                        "properties": {
-                            "image_url": {
-                                "type": "string"
-                            },
-                            "short_description": {
-                                "type": "string"
-                            }
-                        }
+        "/feed/google.{format}": {
+            "get": {
+                "description": "get the last generated google merchant center feed of the catalog as xml or tsv, the feed is regenerated on a schedule",
+                "produces": [
+                    "application/xml",
+                    "text/tab-separated-values"
+                ],
+                "tags": [
+                    "Feed"
+                ],
+                "summary": "get google merchant product feed",
+                "operationId": "v1-GetProductFeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "xml or tsv",
+                        "name": "format",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory/{product_id}/adjust": {
+            "post": {
+                "description": "add or remove stock of a product, or of one of its variants when variant_id is set, every adjustment is recorded in the inventory ledger",
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "adjust stock of a product",
+                "operationId": "v1-AdjustStock",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "AdjustStock",
+                        "name": "AdjustStock",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AdjustStock"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory/{product_id}/ledger": {
+            "get": {
+                "description": "get every stock movement of a product, latest first",
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "get inventory ledger of a product",
+                "operationId": "v1-GetInventoryLedger",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetInventoryLedgerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders": {
+            "post": {
+                "description": "convert the cart of a user into an order waiting for payment, discounted by the automatic promotions and the promotion codes",
+                "tags": [
+                    "Order"
+                ],
+                "summary": "place an order",
+                "operationId": "v1-CreateOrder",
+                "parameters": [
+                    {
+                        "description": "CreateOrder",
+                        "name": "CreateOrder",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateOrder"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetOrderDetailResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{order_id}": {
+            "get": {
+                "description": "get an order along with its items and status history",
+                "tags": [
+                    "Order"
+                ],
+                "summary": "get an order",
+                "operationId": "v1-GetDetailOrder",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetOrderDetailResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{order_id}/invoice": {
+            "get": {
+                "description": "download the invoice a seller issued when the order was paid as pdf or html, seller_id is only required when the order has items of several sellers",
+                "produces": [
+                    "application/pdf",
+                    "text/html"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "download invoice of an order",
+                "operationId": "v1-GetOrderInvoice",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Seller ID",
+                        "name": "seller_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pdf or html, pdf by default",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{order_id}/payments": {
+            "get": {
+                "description": "get the charges created for an order at the payment gateway",
+                "tags": [
+                    "Order"
+                ],
+                "summary": "get payments of an order",
+                "operationId": "v1-GetOrderPayments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetPaymentListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "create a charge of an order waiting for payment at the payment gateway, the charge still waiting to be paid is returned when there is one",
+                "tags": [
+                    "Order"
+                ],
+                "summary": "pay an order",
+                "operationId": "v1-CreatePayment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetPaymentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{order_id}/refund": {
+            "post": {
+                "description": "give the whole payment of a paid order back before it is shipped and put its stock back, the goods of shipped orders are refunded through returns",
+                "tags": [
+                    "Order"
+                ],
+                "summary": "refund an order",
+                "operationId": "v1-RefundOrder",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{order_id}/returns": {
+            "get": {
+                "description": "get the return requests of an order along with their status and refund",
+                "tags": [
+                    "Order"
+                ],
+                "summary": "get return requests of an order",
+                "operationId": "v1-GetOrderReturnRequests",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetReturnRequestListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "ask the seller to take back part of an order line of a delivered order, with the reason and up to 5 photos",
+                "tags": [
+                    "Order"
+                ],
+                "summary": "create a return request",
+                "operationId": "v1-CreateReturnRequest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "CreateReturnRequest",
+                        "name": "CreateReturnRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateReturnRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetReturnRequestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{order_id}/status": {
+            "put": {
+                "description": "update status of an order, illegal transitions are rejected. Orders are paid through their payment and refunded through a refund or their returns, so paid and refunded are rejected",
+                "tags": [
+                    "Order"
+                ],
+                "summary": "update status of an order",
+                "operationId": "v1-UpdateOrderStatus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "UpdateOrderStatus",
+                        "name": "UpdateOrderStatus",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateOrderStatus"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/webhook": {
+            "post": {
+                "description": "apply a signed event of the payment gateway, the order of a captured charge is moved to paid. Redelivered events are ignored",
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "payment gateway webhook",
+                "operationId": "v1-HandlePaymentWebhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Signature of the body",
+                        "name": "X-Payment-Signature",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/product": {
+            "post": {
+                "description": "create a product",
+                "tags": [
+                    "Product"
+                ],
+                "summary": "create a product",
+                "operationId": "v1-CreateProduct",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Who makes the change, recorded in the product history",
+                        "name": "X-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "description": "UpsertProduct",
+                        "name": "UpsertProduct",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpsertProduct"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/export": {
+            "get": {
+                "description": "download the products matching the same filters as the product list as csv, ndjson or xlsx, attributes are filtered with attribute.\u003cname\u003e=\u003cvalue\u003e",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "export products",
+                "operationId": "v1-ExportProducts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv, ndjson or xlsx, csv by default",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "CategoryID filters on the category and all of its descendants",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency adds the price of every product converted to this currency as its display price",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "IncludeFacets returns the category, attribute and price counts of the filtered products",
+                        "name": "include_facets",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "name": "is_asc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "MinPrice and MaxPrice filter on the effective price in the minor unit of PriceCurrency, they only match the\nproducts priced in it",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort is a column of products, or popularity to sort by the number of wishlists a product is on",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "user_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/image": {
+            "post": {
+                "description": "upload a jpeg, png, gif or webp image, the returned image_url can be used in the product images of a product",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "upload a product image",
+                "operationId": "v1-UploadImage",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Image",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.UploadImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/import": {
+            "post": {
+                "description": "create or update the products of a seller by sku from a csv or ndjson file, every row is reported in the import report. Columns left out of the file, empty cells and keys left out of a json line keep the value of an existing product, price is required to create one",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "import products",
+                "operationId": "v1-ImportProducts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Who makes the change, recorded in the product history",
+                        "name": "X-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv or ndjson, taken from the file extension when empty",
+                        "name": "format",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "File",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetProductImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/import/{import_id}": {
+            "get": {
+                "description": "get the status and row counts of a product import",
+                "tags": [
+                    "Product"
+                ],
+                "summary": "get a product import",
+                "operationId": "v1-GetProductImport",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Import ID",
+                        "name": "import_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetProductImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/import/{import_id}/report": {
+            "get": {
+                "description": "download the result of every row of a product import as csv",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "download a product import report",
+                "operationId": "v1-GetProductImportReport",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Import ID",
+                        "name": "import_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/list": {
+            "get": {
+                "operationId": "v1-GetProductList",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetProductListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/review": {
+            "post": {
+                "description": "create a product review",
+                "tags": [
+                    "Product"
+                ],
+                "summary": "create a product review",
+                "operationId": "v1-CreateProductReview",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Who makes the change, recorded in the product history",
+                        "name": "X-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "description": "UpsertProductReview",
+                        "name": "UpsertProductReview",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpsertProductReview"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{product_id}": {
+            "get": {
+                "description": "get a product",
+                "tags": [
+                    "Product"
+                ],
+                "summary": "get a product",
+                "operationId": "v1-GetDetailProduct",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "update a product",
+                "tags": [
+                    "Product"
+                ],
+                "summary": "update a product",
+                "operationId": "v1-UpdateProduct",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Who makes the change, recorded in the product history",
+                        "name": "X-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "UpsertProduct",
+                        "name": "UpsertProduct",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpsertProduct"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{product_id}/history": {
+            "get": {
+                "description": "get the changes made to a product and its images, the latest first, with pagination",
+                "tags": [
+                    "Product"
+                ],
+                "summary": "get change history of a product",
+                "operationId": "v1-GetProductHistory",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Per page",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetProductHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{product_id}/images/order": {
+            "put": {
+                "description": "reorder the images of a product, image_ids should list every image of the product in its new order",
+                "tags": [
+                    "Product"
+                ],
+                "summary": "reorder images of a product",
+                "operationId": "v1-ReorderProductImages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Who makes the change, recorded in the product history",
+                        "name": "X-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ReorderProductImages",
+                        "name": "ReorderProductImages",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ReorderProductImages"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{product_id}/images/{image_id}/primary": {
+            "put": {
+                "description": "set the image shown for the product in the product list",
+                "tags": [
+                    "Product"
+                ],
+                "summary": "set primary image of a product",
+                "operationId": "v1-SetPrimaryProductImage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Who makes the change, recorded in the product history",
+                        "name": "X-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Image ID",
+                        "name": "image_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{product_id}/prices": {
+            "get": {
+                "description": "get the regular and sale prices of a product, including the ones scheduled for later",
+                "tags": [
+                    "Product"
+                ],
+                "summary": "get price history of a product",
+                "operationId": "v1-GetProductPrices",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetProductPricesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "schedule a regular price change or a time-boxed sale price of a product",
+                "tags": [
+                    "Product"
+                ],
+                "summary": "schedule a price of a product",
+                "operationId": "v1-ScheduleProductPrice",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Who makes the change, recorded in the product history",
+                        "name": "X-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ScheduleProductPrice",
+                        "name": "ScheduleProductPrice",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ScheduleProductPrice"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{product_id}/prices/{price_id}": {
+            "delete": {
+                "description": "cancel a price not in effect yet, or end a running sale",
+                "tags": [
+                    "Product"
+                ],
+                "summary": "cancel a price of a product",
+                "operationId": "v1-CancelProductPrice",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Who makes the change, recorded in the product history",
+                        "name": "X-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Price ID",
+                        "name": "price_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions": {
+            "get": {
+                "description": "get the promotion campaigns with pagination, the latest first",
+                "tags": [
+                    "Promotion"
+                ],
+                "summary": "get list of promotion",
+                "operationId": "v1-GetPromotionList",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Per page",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetPromotionListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "create a promotion campaign, it is applied automatically to every eligible cart when it has no code",
+                "tags": [
+                    "Promotion"
+                ],
+                "summary": "create a promotion",
+                "operationId": "v1-CreatePromotion",
+                "parameters": [
+                    {
+                        "description": "UpsertPromotion",
+                        "name": "UpsertPromotion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpsertPromotion"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions/{promotion_id}": {
+            "get": {
+                "description": "get a promotion campaign along with how many times it has been used",
+                "tags": [
+                    "Promotion"
+                ],
+                "summary": "get a promotion",
+                "operationId": "v1-GetDetailPromotion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Promotion ID",
+                        "name": "promotion_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetPromotionDetailResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "update a promotion campaign, the uses counted so far are kept",
+                "tags": [
+                    "Promotion"
+                ],
+                "summary": "update a promotion",
+                "operationId": "v1-UpdatePromotion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Promotion ID",
+                        "name": "promotion_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "UpsertPromotion",
+                        "name": "UpsertPromotion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpsertPromotion"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "delete a promotion which has never been used, a used promotion can only be deactivated",
+                "tags": [
+                    "Promotion"
+                ],
+                "summary": "delete a promotion",
+                "operationId": "v1-DeletePromotion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Promotion ID",
+                        "name": "promotion_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/{user_id}": {
+            "get": {
+                "description": "get seller profile, product count, average rating and products filtered like the product list",
+                "tags": [
+                    "Seller"
+                ],
+                "summary": "get storefront of a seller",
+                "operationId": "v1-GetSellerStorefront",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort column",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Sort ascending",
+                        "name": "is_asc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "category_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetSellerStorefrontResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "create or update the profile shown on the storefront of a seller",
+                "tags": [
+                    "Seller"
+                ],
+                "summary": "create or update seller profile",
+                "operationId": "v1-UpsertSeller",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "UpsertSeller",
+                        "name": "UpsertSeller",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpsertSeller"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/{user_id}/etalase": {
+            "get": {
+                "description": "get storefront sections of a seller ordered by position",
+                "tags": [
+                    "Etalase"
+                ],
+                "summary": "get etalases of a seller",
+                "operationId": "v1-GetEtalaseList",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetEtalaseListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "create a storefront section of a seller",
+                "tags": [
+                    "Etalase"
+                ],
+                "summary": "create an etalase",
+                "operationId": "v1-CreateEtalase",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "UpsertEtalase",
+                        "name": "UpsertEtalase",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpsertEtalase"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/{user_id}/etalase/{id}": {
+            "put": {
+                "description": "rename or reorder a storefront section of a seller",
+                "tags": [
+                    "Etalase"
+                ],
+                "summary": "update an etalase",
+                "operationId": "v1-UpdateEtalase",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Etalase ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "UpsertEtalase",
+                        "name": "UpsertEtalase",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpsertEtalase"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "delete a storefront section of a seller, its products are kept",
+                "tags": [
+                    "Etalase"
+                ],
+                "summary": "delete an etalase",
+                "operationId": "v1-DeleteEtalase",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Etalase ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/{user_id}/etalase/{id}/products": {
+            "get": {
+                "description": "get products of a storefront section with pagination",
+                "tags": [
+                    "Etalase"
+                ],
+                "summary": "get products of an etalase",
+                "operationId": "v1-GetEtalaseProductList",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Etalase ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Per page",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetEtalaseProductListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "assign products of the seller to a storefront section, a product can be in several sections",
+                "tags": [
+                    "Etalase"
+                ],
+                "summary": "assign products to an etalase",
+                "operationId": "v1-AssignEtalaseProducts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Etalase ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "AssignEtalaseProducts",
+                        "name": "AssignEtalaseProducts",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AssignEtalaseProducts"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/{user_id}/etalase/{id}/products/{product_id}": {
+            "delete": {
+                "description": "remove a product from a storefront section",
+                "tags": [
+                    "Etalase"
+                ],
+                "summary": "remove a product from an etalase",
+                "operationId": "v1-RemoveEtalaseProduct",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Etalase ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/{user_id}/returns": {
+            "get": {
+                "description": "get the return requests for the products of a seller with pagination, the latest first",
+                "tags": [
+                    "Return"
+                ],
+                "summary": "get return requests of a seller",
+                "operationId": "v1-GetSellerReturnRequests",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Per page",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetSellerReturnRequestListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/{user_id}/returns/{return_id}/approve": {
+            "put": {
+                "description": "accept a return, the refund defaults to what was paid for the returned units and is given back once the goods are received",
+                "tags": [
+                    "Return"
+                ],
+                "summary": "approve a return request",
+                "operationId": "v1-ApproveReturnRequest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Return ID",
+                        "name": "return_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ReviewReturnRequest",
+                        "name": "ReviewReturnRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ReviewReturnRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/{user_id}/returns/{return_id}/receive": {
+            "put": {
+                "description": "record that the goods of an approved return are back, they are restocked and the approved refund is issued through the payment gateway",
+                "tags": [
+                    "Return"
+                ],
+                "summary": "receive the goods of a return",
+                "operationId": "v1-ReceiveReturnRequest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Return ID",
+                        "name": "return_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/{user_id}/returns/{return_id}/reject": {
+            "put": {
+                "description": "turn a return down, the note telling the customer why is required",
+                "tags": [
+                    "Return"
+                ],
+                "summary": "reject a return request",
+                "operationId": "v1-RejectReturnRequest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Return ID",
+                        "name": "return_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ReviewReturnRequest",
+                        "name": "ReviewReturnRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ReviewReturnRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/{user_id}/webhooks": {
+            "get": {
+                "description": "get the webhooks a seller is notified on, the secrets are not returned",
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "get webhook subscriptions of a seller",
+                "operationId": "v1-GetWebhookSubscriptions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetWebhookSubscriptionListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "notify a url of the review.created, order.created or order.paid events of a seller, deliveries are signed with the hex encoded HMAC-SHA256 of the X-Webhook-Timestamp header, a dot and their body in the X-Webhook-Signature header. The url should resolve to a public address. The secret is generated when it is not given and is only returned here",
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "create a webhook subscription",
+                "operationId": "v1-CreateWebhookSubscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "UpsertWebhookSubscription",
+                        "name": "UpsertWebhookSubscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpsertWebhookSubscription"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetWebhookSubscriptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/{user_id}/webhooks/{webhook_id}": {
+            "put": {
+                "description": "update the url, event types and active flag of a webhook, the secret is rotated when one is given",
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "update a webhook subscription",
+                "operationId": "v1-UpdateWebhookSubscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "UpsertWebhookSubscription",
+                        "name": "UpsertWebhookSubscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpsertWebhookSubscription"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "delete a webhook along with its delivery log",
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "delete a webhook subscription",
+                "operationId": "v1-DeleteWebhookSubscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/{user_id}/webhooks/{webhook_id}/deliveries": {
+            "get": {
+                "description": "get the deliveries of a webhook with the response code of every attempt with pagination, the latest first",
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "get deliveries of a webhook",
+                "operationId": "v1-GetWebhookDeliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Per page",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetWebhookDeliveryListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/{user_id}/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver": {
+            "post": {
+                "description": "post a delivery again right away whatever its status, a successful redelivery marks the delivery as succeeded",
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "redeliver a webhook delivery",
+                "operationId": "v1-RedeliverWebhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Delivery ID",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetWebhookDeliveryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/shipping/quote": {
+            "post": {
+                "description": "get the courier services able to ship the cart of a user to a postal code, priced by the weight brackets of the destination zone. The items of every seller are a package charged by the heavier of its actual and volumetric weight",
+                "tags": [
+                    "Shipping"
+                ],
+                "summary": "quote shipping of a cart",
+                "operationId": "v1-QuoteShipping",
+                "parameters": [
+                    {
+                        "description": "ShippingQuote",
+                        "name": "ShippingQuote",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ShippingQuote"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetShippingQuoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/wishlist/{user_id}": {
+            "get": {
+                "description": "get the products on the wishlist of a user with their current price and stock with pagination, the latest added first",
+                "tags": [
+                    "Wishlist"
+                ],
+                "summary": "get wishlist of a user",
+                "operationId": "v1-GetWishlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Per page",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetWishlistResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/wishlist/{user_id}/items": {
+            "post": {
+                "description": "favourite a product for a user, adding a product already on the wishlist changes nothing",
+                "tags": [
+                    "Wishlist"
+                ],
+                "summary": "add product to wishlist",
+                "operationId": "v1-AddWishlistItem",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "AddWishlistItem",
+                        "name": "AddWishlistItem",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AddWishlistItem"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/wishlist/{user_id}/items/{product_id}": {
+            "delete": {
+                "description": "remove a product from the wishlist of a user",
+                "tags": [
+                    "Wishlist"
+                ],
+                "summary": "remove product from wishlist",
+                "operationId": "v1-RemoveWishlistItem",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "entity.AuditLog": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "diff": {
+                    "type": "object"
+                },
+                "entityID": {
+                    "type": "integer"
+                },
+                "entityType": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "productID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.Cart": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.CartItemDetail": {
+            "type": "object",
+            "properties": {
+                "cartID": {
+                    "type": "integer"
+                },
+                "categoryID": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "currentPrice": {
+                    "type": "integer"
+                },
+                "height": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "length": {
+                    "type": "number"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "productID": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "sellerID": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "variantID": {
+                    "description": "VariantID is the chosen variant of a product with variants, 0 otherwise",
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "number"
+                },
+                "width": {
+                    "type": "number"
+                }
+            }
+        },
+        "entity.Etalase": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.InventoryLedger": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "productID": {
+                    "type": "integer"
+                },
+                "quantityChange": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "stockAfter": {
+                    "type": "integer"
+                },
+                "variantID": {
+                    "description": "VariantID is 0 for the stock of a product without variants",
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.Order": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "totalPrice": {
+                    "description": "TotalPrice is what is charged, the subtotal of the items less Discount",
+                    "type": "integer"
+                },
+                "totalWeight": {
+                    "type": "number"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.OrderItem": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "discount": {
+                    "description": "Discount is the share of the order discount taken off this line",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "orderID": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "productID": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "sellerID": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "variantID": {
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "entity.OrderStatusHistory": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "fromStatus": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "orderID": {
+                    "type": "integer"
+                },
+                "toStatus": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.ProductImport": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdRows": {
+                    "type": "integer"
+                },
+                "failedRows": {
+                    "type": "integer"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reportKey": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "totalRows": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "updatedRows": {
+                    "type": "integer"
+                },
+                "userID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.Seller": {
+            "type": "object",
+            "properties": {
+                "avatarUrl": {
+                    "type": "string"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.WishlistItemDetail": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "currentPrice": {
+                    "type": "integer"
+                },
+                "favouritedCount": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "primaryImageUrl": {
+                    "type": "string"
+                },
+                "productID": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "sellerID": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "userID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "money.Money": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "description": "Currency is the ISO 4217 code of the currency",
+                    "type": "string"
+                }
+            }
+        },
+        "request.AddWishlistItem": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.AdjustStock": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.ApplyPromotions": {
+            "type": "object",
+            "properties": {
+                "codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "request.AssignEtalaseProducts": {
+            "type": "object",
+            "properties": {
+                "product_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "request.CreateOrder": {
+            "type": "object",
+            "properties": {
+                "promotion_codes": {
+                    "description": "PromotionCodes are the vouchers applied to the order on top of the automatic promotions",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.CreateReturnRequest": {
+            "type": "object",
+            "properties": {
+                "order_item_id": {
+                    "type": "integer"
+                },
+                "photo_urls": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.ReorderProductImages": {
+            "type": "object",
+            "properties": {
+                "image_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "request.ReviewReturnRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "description": "Note is shown to the customer, it is required to reject a return",
+                    "type": "string"
+                },
+                "refund_amount": {
+                    "description": "RefundAmount defaults to what was paid for the returned units when a return is approved",
+                    "type": "integer"
+                }
+            }
+        },
+        "request.ScheduleProductPrice": {
+            "type": "object",
+            "properties": {
+                "effective_from": {
+                    "type": "string"
+                },
+                "effective_to": {
+                    "description": "EffectiveTo is required for a sale price, a regular price is in effect until the next one starts",
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "price": {
+                    "description": "Price should be in the currency of the product, it is taken when the currency is empty",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/money.Money"
+                        }
+                    ]
+                }
+            }
+        },
+        "request.ShippingQuote": {
+            "type": "object",
+            "properties": {
+                "postal_code": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.UpdateOrderStatus": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.UpsertCartItem": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.UpsertCategory": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.UpsertEtalase": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.UpsertProduct": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/request.UpsertProductAttribute"
+                    }
+                },
+                "category": {
+                    "type": "string"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "etalase": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "number"
+                },
+                "length": {
+                    "description": "Length, Width and Height are the dimensions of the package in centimeters, they are used for its volumetric weight",
+                    "type": "number"
+                },
+                "options": {
+                    "description": "Options and Variants replace those of the product when either is sent, both are left as is when neither is",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/request.UpsertProductOption"
+                    }
+                },
+                "price": {
+                    "description": "Price takes the default currency of the store when its currency is empty",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/money.Money"
+                        }
+                    ]
+                },
+                "product_images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/request.UpsertProductImage"
+                    }
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/request.UpsertProductVariant"
+                    }
+                },
+                "weight": {
+                    "type": "number"
+                },
+                "width": {
+                    "type": "number"
+                }
+            }
+        },
+        "request.UpsertProductAttribute": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.UpsertProductImage": {
+            "type": "object",
+            "properties": {
+                "image_url": {
+                    "type": "string"
+                },
+                "is_primary": {
+                    "description": "IsPrimary marks the image shown in the product list, the first image is used when no image is marked",
+                    "type": "boolean"
+                },
+                "short_description": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.UpsertProductOption": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "request.UpsertProductReview": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.UpsertProductVariant": {
+            "type": "object",
+            "properties": {
+                "options": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "price": {
+                    "description": "Price is in the minor unit of the currency of the product",
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stock": {
+                    "description": "Stock is the initial stock of a new variant, the stock of an existing one is changed by adjusting it",
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "request.UpsertPromotion": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "description": "IsActive defaults to true",
+                    "type": "boolean"
+                },
+                "max_discount": {
+                    "type": "integer"
+                },
+                "min_spend": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "per_user_limit": {
+                    "type": "integer"
+                },
+                "scope": {
+                    "description": "Scope is all, seller, category or product, ScopeIDs lists the ids the promotion is limited to",
+                    "type": "string"
+                },
+                "scope_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "starts_at": {
+                    "description": "StartsAt defaults to now, the promotion runs without an end when EndsAt is not set",
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "usage_limit": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.UpsertSeller": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.UpsertWebhookSubscription": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Active defaults to true on create and is kept on update when it is not set",
+                    "type": "boolean"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "description": "Secret keys the signature of the deliveries, a secret is generated on create when it is empty and the current\none is kept on update",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.AttributeFacet": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.AttributeValueFacet"
+                    }
+                }
+            }
+        },
+        "response.AttributeValueFacet": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.BaseResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.CartDetail": {
+            "type": "object",
+            "properties": {
+                "cart": {
+                    "$ref": "#/definitions/entity.Cart"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.CartItemDetail"
+                    }
+                },
+                "summary": {
+                    "$ref": "#/definitions/response.CartSummary"
+                }
+            }
+        },
+        "response.CartPromotions": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "discounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.PromotionDiscount"
+                    }
+                },
+                "rejected": {
+                    "description": "Rejected lists the codes which can't be applied to the cart with the reason why",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.RejectedPromotion"
+                    }
+                },
+                "subtotal": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_discount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.CartSummary": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "subtotal": {
+                    "description": "Subtotal is in the minor unit of Currency, the currency of every product of the cart",
+                    "type": "integer"
+                },
+                "total_quantity": {
+                    "type": "integer"
+                },
+                "total_weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "response.Category": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.Category"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.CategoryFacet": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.Error": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "strconv.ParseInt: parsing \"a\": invalid syntax"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 400
+                }
+            }
+        },
+        "response.GetCartPromotionsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/response.CartPromotions"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.GetCartResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/response.CartDetail"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.GetCategoryDetailResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/response.Category"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.GetCategoryListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.Category"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.GetEtalaseListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Etalase"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.GetEtalaseProductListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.Product"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/sql.PaginationMetaMessage"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.GetInventoryLedgerResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.InventoryLedger"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.GetOrderDetailResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/response.OrderDetail"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.GetPaymentListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.Payment"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.GetPaymentResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/response.Payment"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.GetProductHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.AuditLog"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/sql.PaginationMetaMessage"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.GetProductImportResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/entity.ProductImport"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.GetProductListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.Product"
+                    }
+                },
+                "facets": {
+                    "$ref": "#/definitions/response.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.GetProductPricesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ProductPrice"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.GetPromotionDetailResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/response.Promotion"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.GetPromotionListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.Promotion"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/sql.PaginationMetaMessage"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.GetReturnRequestListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ReturnRequest"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.GetReturnRequestResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/response.ReturnRequest"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.GetSellerReturnRequestListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ReturnRequest"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/sql.PaginationMetaMessage"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.GetSellerStorefrontResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/response.SellerStorefront"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.GetShippingQuoteResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/response.ShippingQuote"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.GetWebhookDeliveryListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.WebhookDelivery"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/sql.PaginationMetaMessage"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.GetWebhookDeliveryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/response.WebhookDelivery"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.GetWebhookSubscriptionListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.WebhookSubscription"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.GetWebhookSubscriptionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/response.WebhookSubscription"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.GetWishlistResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.WishlistItemDetail"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/sql.PaginationMetaMessage"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.ItemDiscount": {
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.OrderDetail": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.OrderItem"
+                    }
+                },
+                "order": {
+                    "$ref": "#/definitions/entity.Order"
+                },
+                "status_history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.OrderStatusHistory"
+                    }
+                }
+            }
+        },
+        "response.Payment": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Amount is the total price of the order when the charge was created",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/money.Money"
+                        }
+                    ]
+                },
+                "charge_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "gateway": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "payment_url": {
+                    "description": "PaymentUrl is where the customer pays a pending charge",
+                    "type": "string"
+                },
+                "refunded_amount": {
+                    "description": "RefundedAmount is the part of Amount given back, refunds the gateway is still asked for included",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/money.Money"
+                        }
+                    ]
+                },
+                "status": {
+                    "description": "Status is created until the charge exists at the gateway, then pending, authorized, captured, failed,\npartially_refunded or refunded",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.PriceFacet": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "max": {
+                    "type": "integer"
+                },
+                "min": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.Product": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "categoryID": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "display_price": {
+                    "$ref": "#/definitions/money.Money"
+                },
+                "effectivePrice": {
+                    "description": "EffectivePrice is not a column of products, it is the price charged now: the running sale price, else the\nregular price in effect, else Price",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/money.Money"
+                        }
+                    ]
+                },
+                "etalase": {
+                    "type": "string"
+                },
+                "favouritedCount": {
+                    "description": "FavouritedCount is the number of wishlists the product is on",
+                    "type": "integer"
+                },
+                "height": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "length": {
+                    "description": "Length, Width and Height are the dimensions of the package in centimeters, 0 when they are not known",
+                    "type": "number"
+                },
+                "price": {
+                    "description": "Price is the price column in the currency column, the currency of a product can't be changed once it is created",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/money.Money"
+                        }
+                    ]
+                },
+                "primaryImageUrl": {
+                    "description": "PrimaryImageUrl is not a column of products, it is the url of the primary image of the product",
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userID": {
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "number"
+                },
+                "width": {
+                    "type": "number"
+                }
+            }
+        },
+        "response.ProductFacets": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.AttributeFacet"
+                    }
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CategoryFacet"
+                    }
+                },
+                "price_currency": {
+                    "description": "PriceCurrency is the currency of the bounds of Prices, only the products priced in it are counted in Prices",
+                    "type": "string"
+                },
+                "prices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.PriceFacet"
+                    }
+                }
+            }
+        },
+        "response.ProductPrice": {
+            "type": "object",
+            "properties": {
+                "effective_from": {
+                    "type": "string"
+                },
+                "effective_to": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "price": {
+                    "$ref": "#/definitions/money.Money"
+                },
+                "status": {
+                    "description": "Status is expired, active or scheduled, an active regular price is not charged while a sale is active",
+                    "type": "string"
+                }
+            }
+        },
+        "response.Promotion": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "max_discount": {
+                    "type": "integer"
+                },
+                "min_spend": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "per_user_limit": {
+                    "type": "integer"
+                },
+                "scope": {
+                    "type": "string"
+                },
+                "scope_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
                     }
                 },
-                "sku": {
+                "starts_at": {
                     "type": "string"
                 },
-                "title": {
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "usage_count": {
+                    "type": "integer"
+                },
+                "usage_limit": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.PromotionDiscount": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ItemDiscount"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "promotion_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.RejectedPromotion": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ReturnRequest": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "order_item_id": {
+                    "type": "integer"
+                },
+                "photo_urls": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "refund_amount": {
+                    "description": "RefundAmount is given back when the seller receives the goods of an approved return",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/money.Money"
+                        }
+                    ]
+                },
+                "seller_id": {
+                    "type": "integer"
+                },
+                "seller_note": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is requested, approved, rejected or refunded",
+                    "type": "string"
+                },
+                "updated_at": {
                     "type": "string"
                 },
                 "user_id": {
                     "type": "integer"
                 },
-                "weight": {
+                "variant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.SellerStorefront": {
+            "type": "object",
+            "properties": {
+                "average_rating": {
                     "type": "number"
+                },
+                "product_count": {
+                    "type": "integer"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.Product"
+                    }
+                },
+                "seller": {
+                    "$ref": "#/definitions/entity.Seller"
                 }
             }
         },
-        "request.UpsertProductReview": {
+        "response.ShippingOption": {
             "type": "object",
             "properties": {
-                "comment": {
+                "courier": {
                     "type": "string"
                 },
-                "product_id": {
+                "estimated_days": {
                     "type": "integer"
                 },
-                "rating": {
+                "price": {
                     "type": "integer"
+                },
+                "service": {
+                    "type": "string"
                 }
             }
         },
-        "response.BaseResponse": {
+        "response.ShippingPackage": {
+            "type": "object",
+            "properties": {
+                "actual_weight": {
+                    "type": "number"
+                },
+                "chargeable_weight": {
+                    "type": "number"
+                },
+                "seller_id": {
+                    "type": "integer"
+                },
+                "volumetric_weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "response.ShippingQuote": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "options": {
+                    "description": "Options are sorted from the cheapest",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ShippingOption"
+                    }
+                },
+                "packages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ShippingPackage"
+                    }
+                },
+                "zone": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.UploadImageResponse": {
             "type": "object",
             "properties": {
+                "data": {
+                    "$ref": "#/definitions/response.UploadedImage"
+                },
                 "message": {
                     "type": "string"
                 },
@@ -289,32 +4660,150 @@ const docTemplate = `{
                 }
             }
         },
-        "response.Error": {
+        "response.UploadedImage": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "strconv.ParseInt: parsing \"a\": invalid syntax"
+                "content_type": {
+                    "type": "string"
                 },
-                "status_code": {
-                    "type": "integer",
-                    "example": 400
+                "image_url": {
+                    "description": "ImageUrl can be sent as the image_url of the product images when upserting a product",
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
                 }
             }
         },
-        "response.GetProductListResponse": {
+        "response.WebhookDelivery": {
             "type": "object",
             "properties": {
-                "data": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "integer"
+                },
+                "event_type": {
+                    "type": "string"
+                },
+                "history": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/entity.Product"
+                        "$ref": "#/definitions/response.WebhookDeliveryAttempt"
                     }
                 },
-                "message": {
+                "id": {
+                    "type": "integer"
+                },
+                "last_error": {
                     "type": "string"
                 },
-                "status_code": {
+                "next_attempt_at": {
+                    "description": "NextAttemptAt is only set for pending deliveries",
+                    "type": "string"
+                },
+                "response_code": {
+                    "description": "ResponseCode is the status code of the last attempt, 0 when it got no response",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Status is pending, succeeded or failed",
+                    "type": "string"
+                },
+                "subscription_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.WebhookDeliveryAttempt": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "manual": {
+                    "description": "Manual is set for the attempts of a redelivery by hand",
+                    "type": "boolean"
+                },
+                "response_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.WebhookSubscription": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "secret": {
+                    "description": "Secret is only returned when the subscription is created",
+                    "type": "string"
+                },
+                "seller_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "sql.PaginationMetaMessage": {
+            "type": "object",
+            "properties": {
+                "current_page": {
+                    "type": "integer"
+                },
+                "from_item": {
+                    "type": "integer"
+                },
+                "next_url": {
+                    "type": "string"
+                },
+                "per_page": {
+                    "type": "integer"
+                },
+                "previous_url": {
+                    "type": "string"
+                },
+                "sort": {
+                    "type": "string"
+                },
+                "to_item": {
+                    "type": "integer"
+                },
+                "total_items": {
+                    "type": "integer"
+                },
+                "total_page": {
                     "type": "integer"
                 }
             }
@@ -332,6 +4821,8 @@ var SwaggerInfo = &swag.Spec{
 	Description:      "",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
 }
 
 func init() {
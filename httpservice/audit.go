@@ -0,0 +1,61 @@
+package httpservice
+
+import (
+	"context"
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"ecommerce/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// actorContext returns the context of the request carrying the actor sent in the X-Actor header, the changes made
+// with it are recorded in the product history as made by that actor.
+func actorContext(c *fiber.Ctx) context.Context {
+	return service.WithActor(c.Context(), c.Get("X-Actor"))
+}
+
+// GetProductHistory is a handler to get the change history of a product
+// GetProductHistory godoc
+// @Summary      get change history of a product
+// @Description  get the changes made to a product and its images, the latest first, with pagination
+// @Tags         Product
+// @Param 	product_id path  string true "Product ID"
+// @Param 	page query  int false "Page"
+// @Param 	per_page query  int false "Per page"
+// @Success 200 {object} response.GetProductHistoryResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-GetProductHistory
+// @Router       /product/{product_id}/history   [get]
+func (d *Handler) GetProductHistory(c *fiber.Ctx) error {
+	productID, err := strconv.ParseUint(c.Params("product_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "product_id can'b be null and should be an integer",
+		})
+	}
+
+	request := request.Pagination{}
+	if err := c.QueryParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	resp, err := d.ecommerceSrv.GetProductHistory(c.Context(), int64(productID), request, c.Path())
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	resp.StatusCode = http.StatusOK
+	resp.Message = "success"
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
@@ -0,0 +1,187 @@
+package httpservice
+
+import (
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetCart is a handler to get the cart of a user
+// GetCart godoc
+// @Summary      get cart of a user
+// @Description  get cart of a user along with its subtotal and total weight
+// @Tags         Cart
+// @Param 	user_id path  string true "User ID"
+// @Success 200 {object} response.GetCartResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-GetCart
+// @Router       /cart/{user_id}   [get]
+func (d *Handler) GetCart(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "user_id can'b be null and should be an integer",
+		})
+	}
+
+	resp, err := d.cartSrv.GetCart(c.Context(), int64(userID))
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	resp.StatusCode = http.StatusOK
+	resp.Message = "success"
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// AddCartItem is a handler to add a product to the cart of a user
+// AddCartItem godoc
+// @Summary      add a product to cart
+// @Description  add a product to cart, the product price is snapshotted when it is added. Products with variants are added by variant_id and take the price of the variant when it has one
+// @Tags         Cart
+// @Param 	user_id path  string true "User ID"
+// @Param UpsertCartItem body request.UpsertCartItem true "UpsertCartItem"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-AddCartItem
+// @Router       /cart/{user_id}/items   [post]
+func (d *Handler) AddCartItem(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "user_id can'b be null and should be an integer",
+		})
+	}
+
+	request := request.UpsertCartItem{}
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	err = d.cartSrv.AddCartItem(c.Context(), int64(userID), request)
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusCreated).JSON(response.BaseResponse{
+		StatusCode: http.StatusCreated,
+		Message:    "success",
+	})
+}
+
+// UpdateCartItem is a handler to update the quantity of a product in the cart of a user
+// UpdateCartItem godoc
+// @Summary      update a cart item
+// @Description  update the quantity of a product in cart, a quantity of 0 removes it. variant_id picks the variant of a product with variants
+// @Tags         Cart
+// @Param 	user_id path  string true "User ID"
+// @Param 	product_id path  string true "Product ID"
+// @Param UpsertCartItem body request.UpsertCartItem true "UpsertCartItem"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-UpdateCartItem
+// @Router       /cart/{user_id}/items/{product_id}   [put]
+func (d *Handler) UpdateCartItem(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "user_id can'b be null and should be an integer",
+		})
+	}
+
+	productID, err := strconv.ParseUint(c.Params("product_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "product_id can'b be null and should be an integer",
+		})
+	}
+
+	request := request.UpsertCartItem{}
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	err = d.cartSrv.UpdateCartItem(c.Context(), int64(userID), int64(productID), request)
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(response.BaseResponse{
+		StatusCode: http.StatusOK,
+		Message:    "success",
+	})
+}
+
+// RemoveCartItem is a handler to remove a product from the cart of a user
+// RemoveCartItem godoc
+// @Summary      remove a cart item
+// @Description  remove a product from cart
+// @Tags         Cart
+// @Param 	user_id path  string true "User ID"
+// @Param 	product_id path  string true "Product ID"
+// @Param 	variant_id query  string false "Variant ID, for products with variants"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-RemoveCartItem
+// @Router       /cart/{user_id}/items/{product_id}   [delete]
+func (d *Handler) RemoveCartItem(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "user_id can'b be null and should be an integer",
+		})
+	}
+
+	productID, err := strconv.ParseUint(c.Params("product_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "product_id can'b be null and should be an integer",
+		})
+	}
+
+	request := request.RemoveCartItem{}
+	if err := c.QueryParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	err = d.cartSrv.RemoveCartItem(c.Context(), int64(userID), int64(productID), request.VariantID)
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(response.BaseResponse{
+		StatusCode: http.StatusOK,
+		Message:    "success",
+	})
+}
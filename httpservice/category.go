@@ -0,0 +1,175 @@
+package httpservice
+
+import (
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetCategoryList is a handler to get the category tree
+// GetCategoryList godoc
+// @Summary      get category tree
+// @Description  get all categories nested under their parent
+// @Tags         Category
+// @Success 200 {object} response.GetCategoryListResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-GetCategoryList
+// @Router       /category   [get]
+func (d *Handler) GetCategoryList(c *fiber.Ctx) error {
+	resp, err := d.categorySrv.GetCategoryTree(c.Context())
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	resp.StatusCode = http.StatusOK
+	resp.Message = "success"
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// GetDetailCategory is a handler to get a category
+// GetDetailCategory godoc
+// @Summary      get a category
+// @Description  get a category along with its sub categories
+// @Tags         Category
+// @Param 	category_id path  string true "Category ID"
+// @Success 200 {object} response.GetCategoryDetailResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-GetDetailCategory
+// @Router       /category/{category_id}   [get]
+func (d *Handler) GetDetailCategory(c *fiber.Ctx) error {
+	categoryID, err := strconv.ParseUint(c.Params("category_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "category_id can'b be null and should be an integer",
+		})
+	}
+
+	resp, err := d.categorySrv.GetCategoryByID(c.Context(), int64(categoryID))
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	resp.StatusCode = http.StatusOK
+	resp.Message = "success"
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// CreateCategory is a handler to create a category
+// CreateCategory godoc
+// @Summary      create a category
+// @Description  create a category, the slug is generated from the name when it is empty
+// @Tags         Category
+// @Param UpsertCategory body request.UpsertCategory true "UpsertCategory"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-CreateCategory
+// @Router       /category   [post]
+func (d *Handler) CreateCategory(c *fiber.Ctx) error {
+	request := request.UpsertCategory{}
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	err := d.categorySrv.CreateCategory(c.Context(), request)
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusCreated).JSON(response.BaseResponse{
+		StatusCode: http.StatusCreated,
+		Message:    "success",
+	})
+}
+
+// UpdateCategory is a handler to update a category
+// UpdateCategory godoc
+// @Summary      update a category
+// @Description  update a category, it can't be moved below one of its sub categories
+// @Tags         Category
+// @Param 	category_id path  string true "Category ID"
+// @Param UpsertCategory body request.UpsertCategory true "UpsertCategory"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-UpdateCategory
+// @Router       /category/{category_id}   [put]
+func (d *Handler) UpdateCategory(c *fiber.Ctx) error {
+	categoryID, err := strconv.ParseUint(c.Params("category_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "category_id can'b be null and should be an integer",
+		})
+	}
+
+	request := request.UpsertCategory{}
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	err = d.categorySrv.UpdateCategory(c.Context(), int64(categoryID), request)
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(response.BaseResponse{
+		StatusCode: http.StatusOK,
+		Message:    "success",
+	})
+}
+
+// DeleteCategory is a handler to delete a category
+// DeleteCategory godoc
+// @Summary      delete a category
+// @Description  delete a category which has no sub categories and no products
+// @Tags         Category
+// @Param 	category_id path  string true "Category ID"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-DeleteCategory
+// @Router       /category/{category_id}   [delete]
+func (d *Handler) DeleteCategory(c *fiber.Ctx) error {
+	categoryID, err := strconv.ParseUint(c.Params("category_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "category_id can'b be null and should be an integer",
+		})
+	}
+
+	err = d.categorySrv.DeleteCategory(c.Context(), int64(categoryID))
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(response.BaseResponse{
+		StatusCode: http.StatusOK,
+		Message:    "success",
+	})
+}
@@ -13,6 +13,18 @@ import (
 func NewHandler(cfg HandlerConfig) *Handler {
 	return &Handler{
 		ecommerceSrv: cfg.EcommerceSrv,
+		cartSrv:      cfg.CartSrv,
+		orderSrv:     cfg.OrderSrv,
+		inventorySrv: cfg.InventorySrv,
+		categorySrv:  cfg.CategorySrv,
+		etalaseSrv:   cfg.EtalaseSrv,
+		sellerSrv:    cfg.SellerSrv,
+		imageSrv:     cfg.ImageSrv,
+		feedSrv:      cfg.FeedSrv,
+		promotionSrv: cfg.PromotionSrv,
+		shippingSrv:  cfg.ShippingSrv,
+		wishlistSrv:  cfg.WishlistSrv,
+		webhookSrv:   cfg.WebhookSrv,
 	}
 }
 
@@ -55,6 +67,7 @@ func (d *Handler) GetProductList(c *fiber.Ctx) error {
 // @Summary      create a product
 // @Description  create a product
 // @Tags         Product
+// @Param 	X-Actor header  string false "Who makes the change, recorded in the product history"
 // @Param UpsertProduct body request.UpsertProduct true "UpsertProduct"
 // @Success 200 {object} response.BaseResponse{}
 // @Failure 400 {object} response.Error{}
@@ -69,10 +82,10 @@ func (d *Handler) CreateProduct(c *fiber.Ctx) error {
 		})
 	}
 
-	err := d.ecommerceSrv.CreateProduct(c.Context(), request)
+	err := d.ecommerceSrv.CreateProduct(actorContext(c), request)
 	if err != nil {
-		return c.Status(http.StatusInternalServerError).JSON(response.Error{
-			StatusCode: http.StatusInternalServerError,
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
 			Message:    err.Error(),
 		})
 	}
@@ -88,6 +101,7 @@ func (d *Handler) CreateProduct(c *fiber.Ctx) error {
 // @Summary      update a product
 // @Description  update a product
 // @Tags         Product
+// @Param 	X-Actor header  string false "Who makes the change, recorded in the product history"
 // @Param 	product_id path  string true "Product ID"
 // @Param UpsertProduct body request.UpsertProduct true "UpsertProduct"
 // @Success 200 {object} response.BaseResponse{}
@@ -111,10 +125,10 @@ func (d *Handler) UpdateProduct(c *fiber.Ctx) error {
 		})
 	}
 
-	err = d.ecommerceSrv.UpdateProduct(c.Context(), int64(productID), request)
+	err = d.ecommerceSrv.UpdateProduct(actorContext(c), int64(productID), request)
 	if err != nil {
-		return c.Status(http.StatusInternalServerError).JSON(response.Error{
-			StatusCode: http.StatusInternalServerError,
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
 			Message:    err.Error(),
 		})
 	}
@@ -130,6 +144,7 @@ func (d *Handler) UpdateProduct(c *fiber.Ctx) error {
 // @Summary      create a product review
 // @Description  create a product review
 // @Tags         Product
+// @Param 	X-Actor header  string false "Who makes the change, recorded in the product history"
 // @Param UpsertProductReview body request.UpsertProductReview true "UpsertProductReview"
 // @Success 200 {object} response.BaseResponse{}
 // @Failure 400 {object} response.Error{}
@@ -144,7 +159,7 @@ func (d *Handler) CreateProductReview(c *fiber.Ctx) error {
 		})
 	}
 
-	err := d.ecommerceSrv.CreateProductReview(c.Context(), request)
+	err := d.ecommerceSrv.CreateProductReview(actorContext(c), request)
 	if err != nil {
 		return c.Status(http.StatusInternalServerError).JSON(response.Error{
 			StatusCode: http.StatusInternalServerError,
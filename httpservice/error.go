@@ -0,0 +1,61 @@
+package httpservice
+
+import (
+	"database/sql"
+	"ecommerce/service"
+	"errors"
+	"net/http"
+)
+
+// errorStatusCode maps an error returned by the service layer to its http status code
+func errorStatusCode(err error) int {
+	switch {
+	case errors.Is(err, sql.ErrNoRows),
+		errors.Is(err, service.ErrCartItemNotFound),
+		errors.Is(err, service.ErrEtalaseNotFound),
+		errors.Is(err, service.ErrProductImageNotFound),
+		errors.Is(err, service.ErrImportReportNotFound),
+		errors.Is(err, service.ErrFeedNotFound),
+		errors.Is(err, service.ErrProductPriceNotFound),
+		errors.Is(err, service.ErrReturnNotFound),
+		errors.Is(err, service.ErrWishlistItemNotFound),
+		errors.Is(err, service.ErrInvoiceNotFound),
+		errors.Is(err, service.ErrSubscriptionNotFound),
+		errors.Is(err, service.ErrDeliveryNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidQuantity),
+		errors.Is(err, service.ErrEmptyCart),
+		errors.Is(err, service.ErrInvalidOrderStatus),
+		errors.Is(err, service.ErrInvalidStock),
+		errors.Is(err, service.ErrInvalidVariant),
+		errors.Is(err, service.ErrInvalidCategory),
+		errors.Is(err, service.ErrInvalidEtalase),
+		errors.Is(err, service.ErrInvalidSeller),
+		errors.Is(err, service.ErrInvalidAttribute),
+		errors.Is(err, service.ErrInvalidImage),
+		errors.Is(err, service.ErrInvalidProductImage),
+		errors.Is(err, service.ErrInvalidImport),
+		errors.Is(err, service.ErrInvalidExport),
+		errors.Is(err, service.ErrInvalidProductPrice),
+		errors.Is(err, service.ErrInvalidCurrency),
+		errors.Is(err, service.ErrInvalidPromotion),
+		errors.Is(err, service.ErrInvalidDestination),
+		errors.Is(err, service.ErrInvalidPayment),
+		errors.Is(err, service.ErrInvalidReturn),
+		errors.Is(err, service.ErrInvalidInvoice),
+		errors.Is(err, service.ErrInvalidSubscription):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrInvalidWebhook):
+		return http.StatusUnauthorized
+	case errors.Is(err, service.ErrImageTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, service.ErrInsufficientStock),
+		errors.Is(err, service.ErrCategoryInUse),
+		errors.Is(err, service.ErrPromotionInUse):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrIllegalOrderTransition):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,332 @@
+package httpservice
+
+import (
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetEtalaseList is a handler to get the etalases of a seller
+// GetEtalaseList godoc
+// @Summary      get etalases of a seller
+// @Description  get storefront sections of a seller ordered by position
+// @Tags         Etalase
+// @Param 	user_id path  string true "User ID"
+// @Success 200 {object} response.GetEtalaseListResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-GetEtalaseList
+// @Router       /seller/{user_id}/etalase   [get]
+func (d *Handler) GetEtalaseList(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "user_id can'b be null and should be an integer",
+		})
+	}
+
+	resp, err := d.etalaseSrv.GetEtalaseList(c.Context(), int64(userID))
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	resp.StatusCode = http.StatusOK
+	resp.Message = "success"
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// CreateEtalase is a handler to create an etalase
+// CreateEtalase godoc
+// @Summary      create an etalase
+// @Description  create a storefront section of a seller
+// @Tags         Etalase
+// @Param 	user_id path  string true "User ID"
+// @Param UpsertEtalase body request.UpsertEtalase true "UpsertEtalase"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-CreateEtalase
+// @Router       /seller/{user_id}/etalase   [post]
+func (d *Handler) CreateEtalase(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "user_id can'b be null and should be an integer",
+		})
+	}
+
+	request := request.UpsertEtalase{}
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	err = d.etalaseSrv.CreateEtalase(c.Context(), int64(userID), request)
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusCreated).JSON(response.BaseResponse{
+		StatusCode: http.StatusCreated,
+		Message:    "success",
+	})
+}
+
+// UpdateEtalase is a handler to update an etalase
+// UpdateEtalase godoc
+// @Summary      update an etalase
+// @Description  rename or reorder a storefront section of a seller
+// @Tags         Etalase
+// @Param 	user_id path  string true "User ID"
+// @Param 	id path  string true "Etalase ID"
+// @Param UpsertEtalase body request.UpsertEtalase true "UpsertEtalase"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-UpdateEtalase
+// @Router       /seller/{user_id}/etalase/{id}   [put]
+func (d *Handler) UpdateEtalase(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "user_id can'b be null and should be an integer",
+		})
+	}
+
+	etalaseID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "id can'b be null and should be an integer",
+		})
+	}
+
+	request := request.UpsertEtalase{}
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	err = d.etalaseSrv.UpdateEtalase(c.Context(), int64(userID), int64(etalaseID), request)
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(response.BaseResponse{
+		StatusCode: http.StatusOK,
+		Message:    "success",
+	})
+}
+
+// DeleteEtalase is a handler to delete an etalase
+// DeleteEtalase godoc
+// @Summary      delete an etalase
+// @Description  delete a storefront section of a seller, its products are kept
+// @Tags         Etalase
+// @Param 	user_id path  string true "User ID"
+// @Param 	id path  string true "Etalase ID"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-DeleteEtalase
+// @Router       /seller/{user_id}/etalase/{id}   [delete]
+func (d *Handler) DeleteEtalase(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "user_id can'b be null and should be an integer",
+		})
+	}
+
+	etalaseID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "id can'b be null and should be an integer",
+		})
+	}
+
+	err = d.etalaseSrv.DeleteEtalase(c.Context(), int64(userID), int64(etalaseID))
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(response.BaseResponse{
+		StatusCode: http.StatusOK,
+		Message:    "success",
+	})
+}
+
+// AssignEtalaseProducts is a handler to put products into an etalase
+// AssignEtalaseProducts godoc
+// @Summary      assign products to an etalase
+// @Description  assign products of the seller to a storefront section, a product can be in several sections
+// @Tags         Etalase
+// @Param 	user_id path  string true "User ID"
+// @Param 	id path  string true "Etalase ID"
+// @Param AssignEtalaseProducts body request.AssignEtalaseProducts true "AssignEtalaseProducts"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-AssignEtalaseProducts
+// @Router       /seller/{user_id}/etalase/{id}/products   [post]
+func (d *Handler) AssignEtalaseProducts(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "user_id can'b be null and should be an integer",
+		})
+	}
+
+	etalaseID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "id can'b be null and should be an integer",
+		})
+	}
+
+	request := request.AssignEtalaseProducts{}
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	err = d.etalaseSrv.AssignEtalaseProducts(c.Context(), int64(userID), int64(etalaseID), request)
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(response.BaseResponse{
+		StatusCode: http.StatusOK,
+		Message:    "success",
+	})
+}
+
+// RemoveEtalaseProduct is a handler to take a product out of an etalase
+// RemoveEtalaseProduct godoc
+// @Summary      remove a product from an etalase
+// @Description  remove a product from a storefront section
+// @Tags         Etalase
+// @Param 	user_id path  string true "User ID"
+// @Param 	id path  string true "Etalase ID"
+// @Param 	product_id path  string true "Product ID"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-RemoveEtalaseProduct
+// @Router       /seller/{user_id}/etalase/{id}/products/{product_id}   [delete]
+func (d *Handler) RemoveEtalaseProduct(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "user_id can'b be null and should be an integer",
+		})
+	}
+
+	etalaseID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "id can'b be null and should be an integer",
+		})
+	}
+
+	productID, err := strconv.ParseUint(c.Params("product_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "product_id can'b be null and should be an integer",
+		})
+	}
+
+	err = d.etalaseSrv.RemoveEtalaseProduct(c.Context(), int64(userID), int64(etalaseID), int64(productID))
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(response.BaseResponse{
+		StatusCode: http.StatusOK,
+		Message:    "success",
+	})
+}
+
+// GetEtalaseProductList is a handler to get the products of an etalase
+// GetEtalaseProductList godoc
+// @Summary      get products of an etalase
+// @Description  get products of a storefront section with pagination
+// @Tags         Etalase
+// @Param 	user_id path  string true "User ID"
+// @Param 	id path  string true "Etalase ID"
+// @Param 	page query  int false "Page"
+// @Param 	per_page query  int false "Per page"
+// @Success 200 {object} response.GetEtalaseProductListResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-GetEtalaseProductList
+// @Router       /seller/{user_id}/etalase/{id}/products   [get]
+func (d *Handler) GetEtalaseProductList(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "user_id can'b be null and should be an integer",
+		})
+	}
+
+	etalaseID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "id can'b be null and should be an integer",
+		})
+	}
+
+	request := request.Pagination{}
+	if err := c.QueryParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	resp, err := d.etalaseSrv.GetEtalaseProductList(c.Context(), int64(userID), int64(etalaseID), request, c.Path())
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	resp.StatusCode = http.StatusOK
+	resp.Message = "success"
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
@@ -0,0 +1,70 @@
+package httpservice
+
+import (
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// attributeFilterPrefix prefixes the query parameters filtering on a product attribute, e.g. attribute.color=red.
+const attributeFilterPrefix = "attribute."
+
+// ExportProducts is a handler to export the product catalog
+// ExportProducts godoc
+// @Summary      export products
+// @Description  download the products matching the same filters as the product list as csv, ndjson or xlsx, attributes are filtered with attribute.<name>=<value>
+// @Tags         Product
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param 	format query string false "csv, ndjson or xlsx, csv by default"
+// @Param FilterProduct query request.FilterProduct false "FilterProduct"
+// @Success 200 {file} file
+// @Failure 400 {object} response.Error{}
+// @ID v1-ExportProducts
+// @Router       /product/export   [get]
+func (d *Handler) ExportProducts(c *fiber.Ctx) error {
+	request := request.FilterProduct{}
+	if err := c.QueryParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	c.Context().QueryArgs().VisitAll(func(key []byte, value []byte) {
+		name, ok := strings.CutPrefix(string(key), attributeFilterPrefix)
+		if !ok || name == "" {
+			return
+		}
+
+		if request.Attributes == nil {
+			request.Attributes = map[string]string{}
+		}
+		request.Attributes[name] = string(value)
+	})
+
+	// the export is written after the handler returns, so it can't use the request context which is reused by then
+	export, err := d.ecommerceSrv.ExportProducts(c.UserContext(), request, c.Query("format"))
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	c.Attachment(export.FileName)
+	c.Set(fiber.HeaderContentType, export.ContentType)
+
+	// an error while streaming can't change the status anymore, it ends the response early instead
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(export.Write(writer))
+	}()
+
+	return c.Status(http.StatusOK).SendStream(reader)
+}
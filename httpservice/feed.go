@@ -0,0 +1,35 @@
+package httpservice
+
+import (
+	"ecommerce/model/response"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetProductFeed is a handler to get the google merchant product feed
+// GetProductFeed godoc
+// @Summary      get google merchant product feed
+// @Description  get the last generated google merchant center feed of the catalog as xml or tsv, the feed is regenerated on a schedule
+// @Tags         Feed
+// @Produce      application/xml
+// @Produce      text/tab-separated-values
+// @Param 	format path  string true "xml or tsv"
+// @Success 200 {file} file
+// @Failure 404 {object} response.Error{}
+// @ID v1-GetProductFeed
+// @Router       /feed/google.{format}   [get]
+func (d *Handler) GetProductFeed(c *fiber.Ctx) error {
+	feed, contentType, err := d.feedSrv.GetProductFeed(c.Context(), c.Params("format"))
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, contentType)
+
+	// the feed is closed once it has been sent
+	return c.Status(http.StatusOK).SendStream(feed)
+}
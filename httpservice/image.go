@@ -0,0 +1,141 @@
+package httpservice
+
+import (
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// UploadImage is a handler to upload a product image
+// UploadImage godoc
+// @Summary      upload a product image
+// @Description  upload a jpeg, png, gif or webp image, the returned image_url can be used in the product images of a product
+// @Tags         Product
+// @Accept       multipart/form-data
+// @Param 	image formData file true "Image"
+// @Success 200 {object} response.UploadImageResponse{}
+// @Failure 400 {object} response.Error{}
+// @Failure 413 {object} response.Error{}
+// @ID v1-UploadImage
+// @Router       /product/image   [post]
+func (d *Handler) UploadImage(c *fiber.Ctx) error {
+	fileHeader, err := c.FormFile("image")
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "image can'b be null and should be a file",
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).JSON(response.Error{
+			StatusCode: http.StatusInternalServerError,
+			Message:    err.Error(),
+		})
+	}
+	defer file.Close()
+
+	resp, err := d.imageSrv.UploadImage(c.Context(), file)
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	resp.StatusCode = http.StatusOK
+	resp.Message = "success"
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// ReorderProductImages is a handler to reorder the images of a product
+// ReorderProductImages godoc
+// @Summary      reorder images of a product
+// @Description  reorder the images of a product, image_ids should list every image of the product in its new order
+// @Tags         Product
+// @Param 	X-Actor header  string false "Who makes the change, recorded in the product history"
+// @Param 	product_id path  string true "Product ID"
+// @Param ReorderProductImages body request.ReorderProductImages true "ReorderProductImages"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-ReorderProductImages
+// @Router       /product/{product_id}/images/order   [put]
+func (d *Handler) ReorderProductImages(c *fiber.Ctx) error {
+	productID, err := strconv.ParseUint(c.Params("product_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "product_id can'b be null and should be an integer",
+		})
+	}
+
+	request := request.ReorderProductImages{}
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	err = d.ecommerceSrv.ReorderProductImages(actorContext(c), int64(productID), request)
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(response.BaseResponse{
+		StatusCode: http.StatusOK,
+		Message:    "success",
+	})
+}
+
+// SetPrimaryProductImage is a handler to set the primary image of a product
+// SetPrimaryProductImage godoc
+// @Summary      set primary image of a product
+// @Description  set the image shown for the product in the product list
+// @Tags         Product
+// @Param 	X-Actor header  string false "Who makes the change, recorded in the product history"
+// @Param 	product_id path  string true "Product ID"
+// @Param 	image_id path  string true "Image ID"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @Failure 404 {object} response.Error{}
+// @ID v1-SetPrimaryProductImage
+// @Router       /product/{product_id}/images/{image_id}/primary   [put]
+func (d *Handler) SetPrimaryProductImage(c *fiber.Ctx) error {
+	productID, err := strconv.ParseUint(c.Params("product_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "product_id can'b be null and should be an integer",
+		})
+	}
+
+	imageID, err := strconv.ParseUint(c.Params("image_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "image_id can'b be null and should be an integer",
+		})
+	}
+
+	err = d.ecommerceSrv.SetPrimaryProductImage(actorContext(c), int64(productID), int64(imageID))
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(response.BaseResponse{
+		StatusCode: http.StatusOK,
+		Message:    "success",
+	})
+}
@@ -0,0 +1,139 @@
+package httpservice
+
+import (
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ImportProducts is a handler to import products from a file
+// ImportProducts godoc
+// @Summary      import products
+// @Description  create or update the products of a seller by sku from a csv or ndjson file, every row is reported in the import report. Columns left out of the file, empty cells and keys left out of a json line keep the value of an existing product, price is required to create one
+// @Tags         Product
+// @Param 	X-Actor header  string false "Who makes the change, recorded in the product history"
+// @Accept       multipart/form-data
+// @Param 	user_id formData string true "User ID"
+// @Param 	format formData string false "csv or ndjson, taken from the file extension when empty"
+// @Param 	file formData file true "File"
+// @Success 200 {object} response.GetProductImportResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-ImportProducts
+// @Router       /product/import   [post]
+func (d *Handler) ImportProducts(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.FormValue("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "user_id can'b be null and should be an integer",
+		})
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "file can'b be null and should be a file",
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).JSON(response.Error{
+			StatusCode: http.StatusInternalServerError,
+			Message:    err.Error(),
+		})
+	}
+	defer file.Close()
+
+	request := request.ImportProducts{
+		UserID:   int64(userID),
+		Format:   c.FormValue("format"),
+		FileName: fileHeader.Filename,
+	}
+
+	resp, err := d.ecommerceSrv.ImportProducts(actorContext(c), request, file)
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	resp.StatusCode = http.StatusOK
+	resp.Message = "success"
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// GetProductImport is a handler to get a product import
+// GetProductImport godoc
+// @Summary      get a product import
+// @Description  get the status and row counts of a product import
+// @Tags         Product
+// @Param 	import_id path  string true "Import ID"
+// @Success 200 {object} response.GetProductImportResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-GetProductImport
+// @Router       /product/import/{import_id}   [get]
+func (d *Handler) GetProductImport(c *fiber.Ctx) error {
+	importID, err := strconv.ParseUint(c.Params("import_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "import_id can'b be null and should be an integer",
+		})
+	}
+
+	resp, err := d.ecommerceSrv.GetProductImport(c.Context(), int64(importID))
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	resp.StatusCode = http.StatusOK
+	resp.Message = "success"
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// GetProductImportReport is a handler to download the report of a product import
+// GetProductImportReport godoc
+// @Summary      download a product import report
+// @Description  download the result of every row of a product import as csv
+// @Tags         Product
+// @Produce      text/csv
+// @Param 	import_id path  string true "Import ID"
+// @Success 200 {file} file
+// @Failure 400 {object} response.Error{}
+// @Failure 404 {object} response.Error{}
+// @ID v1-GetProductImportReport
+// @Router       /product/import/{import_id}/report   [get]
+func (d *Handler) GetProductImportReport(c *fiber.Ctx) error {
+	importID, err := strconv.ParseUint(c.Params("import_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "import_id can'b be null and should be an integer",
+		})
+	}
+
+	report, err := d.ecommerceSrv.GetProductImportReport(c.Context(), int64(importID))
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	c.Attachment("import-" + strconv.FormatUint(importID, 10) + ".csv")
+	c.Set(fiber.HeaderContentType, "text/csv")
+
+	// the report is closed once it has been sent
+	return c.Status(http.StatusOK).SendStream(report)
+}
@@ -0,0 +1,85 @@
+package httpservice
+
+import (
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdjustStock is a handler to adjust the stock of a product
+// AdjustStock godoc
+// @Summary      adjust stock of a product
+// @Description  add or remove stock of a product, or of one of its variants when variant_id is set, every adjustment is recorded in the inventory ledger
+// @Tags         Inventory
+// @Param 	product_id path  string true "Product ID"
+// @Param AdjustStock body request.AdjustStock true "AdjustStock"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-AdjustStock
+// @Router       /inventory/{product_id}/adjust   [post]
+func (d *Handler) AdjustStock(c *fiber.Ctx) error {
+	productID, err := strconv.ParseUint(c.Params("product_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "product_id can'b be null and should be an integer",
+		})
+	}
+
+	request := request.AdjustStock{}
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	err = d.inventorySrv.AdjustStock(c.Context(), int64(productID), request)
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(response.BaseResponse{
+		StatusCode: http.StatusOK,
+		Message:    "success",
+	})
+}
+
+// GetInventoryLedger is a handler to get the stock movements of a product
+// GetInventoryLedger godoc
+// @Summary      get inventory ledger of a product
+// @Description  get every stock movement of a product, latest first
+// @Tags         Inventory
+// @Param 	product_id path  string true "Product ID"
+// @Success 200 {object} response.GetInventoryLedgerResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-GetInventoryLedger
+// @Router       /inventory/{product_id}/ledger   [get]
+func (d *Handler) GetInventoryLedger(c *fiber.Ctx) error {
+	productID, err := strconv.ParseUint(c.Params("product_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "product_id can'b be null and should be an integer",
+		})
+	}
+
+	resp, err := d.inventorySrv.GetInventoryLedger(c.Context(), int64(productID))
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	resp.StatusCode = http.StatusOK
+	resp.Message = "success"
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
@@ -0,0 +1,57 @@
+package httpservice
+
+import (
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetOrderInvoice is a handler to download the invoice of an order
+// GetOrderInvoice godoc
+// @Summary      download invoice of an order
+// @Description  download the invoice a seller issued when the order was paid as pdf or html, seller_id is only required when the order has items of several sellers
+// @Tags         Order
+// @Produce      application/pdf
+// @Produce      text/html
+// @Param 	order_id path  string true "Order ID"
+// @Param 	seller_id query  int false "Seller ID"
+// @Param 	format query string false "pdf or html, pdf by default"
+// @Success 200 {file} file
+// @Failure 400 {object} response.Error{}
+// @Failure 404 {object} response.Error{}
+// @ID v1-GetOrderInvoice
+// @Router       /orders/{order_id}/invoice   [get]
+func (d *Handler) GetOrderInvoice(c *fiber.Ctx) error {
+	orderID, err := strconv.ParseUint(c.Params("order_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "order_id can'b be null and should be an integer",
+		})
+	}
+
+	request := request.GetOrderInvoice{}
+	if err := c.QueryParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	invoice, err := d.orderSrv.GetOrderInvoice(c.Context(), int64(orderID), request)
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	c.Attachment(invoice.FileName)
+	c.Set(fiber.HeaderContentType, invoice.ContentType)
+
+	// the invoice is closed once it has been sent
+	return c.Status(http.StatusOK).SendStream(invoice.Body)
+}
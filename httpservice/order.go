@@ -0,0 +1,118 @@
+package httpservice
+
+import (
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CreateOrder is a handler to place an order from the cart of a user
+// CreateOrder godoc
+// @Summary      place an order
+// @Description  convert the cart of a user into an order waiting for payment, discounted by the automatic promotions and the promotion codes
+// @Tags         Order
+// @Param CreateOrder body request.CreateOrder true "CreateOrder"
+// @Success 200 {object} response.GetOrderDetailResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-CreateOrder
+// @Router       /orders   [post]
+func (d *Handler) CreateOrder(c *fiber.Ctx) error {
+	request := request.CreateOrder{}
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	resp, err := d.orderSrv.CreateOrder(c.Context(), request)
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	resp.StatusCode = http.StatusCreated
+	resp.Message = "success"
+
+	return c.Status(http.StatusCreated).JSON(resp)
+}
+
+// GetDetailOrder is a handler to get an order
+// GetDetailOrder godoc
+// @Summary      get an order
+// @Description  get an order along with its items and status history
+// @Tags         Order
+// @Param 	order_id path  string true "Order ID"
+// @Success 200 {object} response.GetOrderDetailResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-GetDetailOrder
+// @Router       /orders/{order_id}   [get]
+func (d *Handler) GetDetailOrder(c *fiber.Ctx) error {
+	orderID, err := strconv.ParseUint(c.Params("order_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "order_id can'b be null and should be an integer",
+		})
+	}
+
+	resp, err := d.orderSrv.GetOrderByID(c.Context(), int64(orderID))
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	resp.StatusCode = http.StatusOK
+	resp.Message = "success"
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// UpdateOrderStatus is a handler to move an order to another status
+// UpdateOrderStatus godoc
+// @Summary      update status of an order
+// @Description  update status of an order, illegal transitions are rejected. Orders are paid through their payment and refunded through a refund or their returns, so paid and refunded are rejected
+// @Tags         Order
+// @Param 	order_id path  string true "Order ID"
+// @Param UpdateOrderStatus body request.UpdateOrderStatus true "UpdateOrderStatus"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-UpdateOrderStatus
+// @Router       /orders/{order_id}/status   [put]
+func (d *Handler) UpdateOrderStatus(c *fiber.Ctx) error {
+	orderID, err := strconv.ParseUint(c.Params("order_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "order_id can'b be null and should be an integer",
+		})
+	}
+
+	request := request.UpdateOrderStatus{}
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	err = d.orderSrv.UpdateOrderStatus(c.Context(), int64(orderID), request)
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(response.BaseResponse{
+		StatusCode: http.StatusOK,
+		Message:    "success",
+	})
+}
@@ -0,0 +1,135 @@
+package httpservice
+
+import (
+	"ecommerce/model/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CreatePayment is a handler to start the payment of an order
+// CreatePayment godoc
+// @Summary      pay an order
+// @Description  create a charge of an order waiting for payment at the payment gateway, the charge still waiting to be paid is returned when there is one
+// @Tags         Order
+// @Param 	order_id path  string true "Order ID"
+// @Success 201 {object} response.GetPaymentResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-CreatePayment
+// @Router       /orders/{order_id}/payments   [post]
+func (d *Handler) CreatePayment(c *fiber.Ctx) error {
+	orderID, err := strconv.ParseUint(c.Params("order_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "order_id can'b be null and should be an integer",
+		})
+	}
+
+	resp, err := d.orderSrv.CreatePayment(c.Context(), int64(orderID))
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	resp.StatusCode = http.StatusCreated
+	resp.Message = "success"
+
+	return c.Status(http.StatusCreated).JSON(resp)
+}
+
+// GetOrderPayments is a handler to get the payments of an order
+// GetOrderPayments godoc
+// @Summary      get payments of an order
+// @Description  get the charges created for an order at the payment gateway
+// @Tags         Order
+// @Param 	order_id path  string true "Order ID"
+// @Success 200 {object} response.GetPaymentListResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-GetOrderPayments
+// @Router       /orders/{order_id}/payments   [get]
+func (d *Handler) GetOrderPayments(c *fiber.Ctx) error {
+	orderID, err := strconv.ParseUint(c.Params("order_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "order_id can'b be null and should be an integer",
+		})
+	}
+
+	resp, err := d.orderSrv.GetOrderPayments(c.Context(), int64(orderID))
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	resp.StatusCode = http.StatusOK
+	resp.Message = "success"
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// RefundOrder is a handler to refund a paid order
+// RefundOrder godoc
+// @Summary      refund an order
+// @Description  give the whole payment of a paid order back before it is shipped and put its stock back, the goods of shipped orders are refunded through returns
+// @Tags         Order
+// @Param 	order_id path  string true "Order ID"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @Failure 422 {object} response.Error{}
+// @ID v1-RefundOrder
+// @Router       /orders/{order_id}/refund   [post]
+func (d *Handler) RefundOrder(c *fiber.Ctx) error {
+	orderID, err := strconv.ParseUint(c.Params("order_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "order_id can'b be null and should be an integer",
+		})
+	}
+
+	err = d.orderSrv.RefundOrder(c.Context(), int64(orderID))
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(response.BaseResponse{
+		StatusCode: http.StatusOK,
+		Message:    "success",
+	})
+}
+
+// HandlePaymentWebhook is a handler to receive the events of the payment gateway
+// HandlePaymentWebhook godoc
+// @Summary      payment gateway webhook
+// @Description  apply a signed event of the payment gateway, the order of a captured charge is moved to paid. Redelivered events are ignored
+// @Tags         Payment
+// @Param 	X-Payment-Signature header  string true "Signature of the body"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @Failure 401 {object} response.Error{}
+// @ID v1-HandlePaymentWebhook
+// @Router       /payments/webhook   [post]
+func (d *Handler) HandlePaymentWebhook(c *fiber.Ctx) error {
+	err := d.orderSrv.HandlePaymentWebhook(c.Context(), c.Body(), c.Get("X-Payment-Signature"))
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(response.BaseResponse{
+		StatusCode: http.StatusOK,
+		Message:    "success",
+	})
+}
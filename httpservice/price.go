@@ -0,0 +1,132 @@
+package httpservice
+
+import (
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetProductPrices is a handler to get the price history of a product
+// GetProductPrices godoc
+// @Summary      get price history of a product
+// @Description  get the regular and sale prices of a product, including the ones scheduled for later
+// @Tags         Product
+// @Param 	product_id path  string true "Product ID"
+// @Success 200 {object} response.GetProductPricesResponse{}
+// @Failure 400 {object} response.Error{}
+// @Failure 404 {object} response.Error{}
+// @ID v1-GetProductPrices
+// @Router       /product/{product_id}/prices   [get]
+func (d *Handler) GetProductPrices(c *fiber.Ctx) error {
+	productID, err := strconv.ParseUint(c.Params("product_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "product_id can'b be null and should be an integer",
+		})
+	}
+
+	resp, err := d.ecommerceSrv.GetProductPrices(c.Context(), int64(productID))
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	resp.StatusCode = http.StatusOK
+	resp.Message = "success"
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// ScheduleProductPrice is a handler to schedule a price of a product
+// ScheduleProductPrice godoc
+// @Summary      schedule a price of a product
+// @Description  schedule a regular price change or a time-boxed sale price of a product
+// @Tags         Product
+// @Param 	X-Actor header  string false "Who makes the change, recorded in the product history"
+// @Param 	product_id path  string true "Product ID"
+// @Param ScheduleProductPrice body request.ScheduleProductPrice true "ScheduleProductPrice"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @Failure 404 {object} response.Error{}
+// @ID v1-ScheduleProductPrice
+// @Router       /product/{product_id}/prices   [post]
+func (d *Handler) ScheduleProductPrice(c *fiber.Ctx) error {
+	productID, err := strconv.ParseUint(c.Params("product_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "product_id can'b be null and should be an integer",
+		})
+	}
+
+	request := request.ScheduleProductPrice{}
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	err = d.ecommerceSrv.ScheduleProductPrice(actorContext(c), int64(productID), request)
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusCreated).JSON(response.BaseResponse{
+		StatusCode: http.StatusCreated,
+		Message:    "success",
+	})
+}
+
+// CancelProductPrice is a handler to cancel a price of a product
+// CancelProductPrice godoc
+// @Summary      cancel a price of a product
+// @Description  cancel a price not in effect yet, or end a running sale
+// @Tags         Product
+// @Param 	X-Actor header  string false "Who makes the change, recorded in the product history"
+// @Param 	product_id path  string true "Product ID"
+// @Param 	price_id path  string true "Price ID"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @Failure 404 {object} response.Error{}
+// @ID v1-CancelProductPrice
+// @Router       /product/{product_id}/prices/{price_id}   [delete]
+func (d *Handler) CancelProductPrice(c *fiber.Ctx) error {
+	productID, err := strconv.ParseUint(c.Params("product_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "product_id can'b be null and should be an integer",
+		})
+	}
+
+	priceID, err := strconv.ParseUint(c.Params("price_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "price_id can'b be null and should be an integer",
+		})
+	}
+
+	err = d.ecommerceSrv.CancelProductPrice(actorContext(c), int64(productID), int64(priceID))
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(response.BaseResponse{
+		StatusCode: http.StatusOK,
+		Message:    "success",
+	})
+}
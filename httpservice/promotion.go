@@ -0,0 +1,227 @@
+package httpservice
+
+import (
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetPromotionList is a handler to get the promotions
+// GetPromotionList godoc
+// @Summary      get list of promotion
+// @Description  get the promotion campaigns with pagination, the latest first
+// @Tags         Promotion
+// @Param 	page query  int false "Page"
+// @Param 	per_page query  int false "Per page"
+// @Success 200 {object} response.GetPromotionListResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-GetPromotionList
+// @Router       /promotions   [get]
+func (d *Handler) GetPromotionList(c *fiber.Ctx) error {
+	request := request.Pagination{}
+	if err := c.QueryParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	resp, err := d.promotionSrv.GetPromotionList(c.Context(), request, c.Path())
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	resp.StatusCode = http.StatusOK
+	resp.Message = "success"
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// GetDetailPromotion is a handler to get a promotion
+// GetDetailPromotion godoc
+// @Summary      get a promotion
+// @Description  get a promotion campaign along with how many times it has been used
+// @Tags         Promotion
+// @Param 	promotion_id path  string true "Promotion ID"
+// @Success 200 {object} response.GetPromotionDetailResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-GetDetailPromotion
+// @Router       /promotions/{promotion_id}   [get]
+func (d *Handler) GetDetailPromotion(c *fiber.Ctx) error {
+	promotionID, err := strconv.ParseUint(c.Params("promotion_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "promotion_id can'b be null and should be an integer",
+		})
+	}
+
+	resp, err := d.promotionSrv.GetPromotionByID(c.Context(), int64(promotionID))
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	resp.StatusCode = http.StatusOK
+	resp.Message = "success"
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// CreatePromotion is a handler to create a promotion
+// CreatePromotion godoc
+// @Summary      create a promotion
+// @Description  create a promotion campaign, it is applied automatically to every eligible cart when it has no code
+// @Tags         Promotion
+// @Param UpsertPromotion body request.UpsertPromotion true "UpsertPromotion"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-CreatePromotion
+// @Router       /promotions   [post]
+func (d *Handler) CreatePromotion(c *fiber.Ctx) error {
+	request := request.UpsertPromotion{}
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	err := d.promotionSrv.CreatePromotion(c.Context(), request)
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusCreated).JSON(response.BaseResponse{
+		StatusCode: http.StatusCreated,
+		Message:    "success",
+	})
+}
+
+// UpdatePromotion is a handler to update a promotion
+// UpdatePromotion godoc
+// @Summary      update a promotion
+// @Description  update a promotion campaign, the uses counted so far are kept
+// @Tags         Promotion
+// @Param 	promotion_id path  string true "Promotion ID"
+// @Param UpsertPromotion body request.UpsertPromotion true "UpsertPromotion"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-UpdatePromotion
+// @Router       /promotions/{promotion_id}   [put]
+func (d *Handler) UpdatePromotion(c *fiber.Ctx) error {
+	promotionID, err := strconv.ParseUint(c.Params("promotion_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "promotion_id can'b be null and should be an integer",
+		})
+	}
+
+	request := request.UpsertPromotion{}
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	err = d.promotionSrv.UpdatePromotion(c.Context(), int64(promotionID), request)
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(response.BaseResponse{
+		StatusCode: http.StatusOK,
+		Message:    "success",
+	})
+}
+
+// DeletePromotion is a handler to delete a promotion
+// DeletePromotion godoc
+// @Summary      delete a promotion
+// @Description  delete a promotion which has never been used, a used promotion can only be deactivated
+// @Tags         Promotion
+// @Param 	promotion_id path  string true "Promotion ID"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-DeletePromotion
+// @Router       /promotions/{promotion_id}   [delete]
+func (d *Handler) DeletePromotion(c *fiber.Ctx) error {
+	promotionID, err := strconv.ParseUint(c.Params("promotion_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "promotion_id can'b be null and should be an integer",
+		})
+	}
+
+	err = d.promotionSrv.DeletePromotion(c.Context(), int64(promotionID))
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(response.BaseResponse{
+		StatusCode: http.StatusOK,
+		Message:    "success",
+	})
+}
+
+// EvaluateCartPromotions is a handler to get the discount the cart of a user gets
+// EvaluateCartPromotions godoc
+// @Summary      evaluate promotions of a cart
+// @Description  get the discount breakdown of the automatic promotions and the entered codes for the cart of a user, the codes which can't be applied are listed with the reason why
+// @Tags         Cart
+// @Param 	user_id path  string true "User ID"
+// @Param ApplyPromotions body request.ApplyPromotions true "ApplyPromotions"
+// @Success 200 {object} response.GetCartPromotionsResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-EvaluateCartPromotions
+// @Router       /cart/{user_id}/promotions   [post]
+func (d *Handler) EvaluateCartPromotions(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "user_id can'b be null and should be an integer",
+		})
+	}
+
+	request := request.ApplyPromotions{}
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	resp, err := d.promotionSrv.EvaluateCartPromotions(c.Context(), int64(userID), request)
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	resp.StatusCode = http.StatusOK
+	resp.Message = "success"
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
@@ -0,0 +1,272 @@
+package httpservice
+
+import (
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CreateReturnRequest is a handler to ask for the return of an order line
+// CreateReturnRequest godoc
+// @Summary      create a return request
+// @Description  ask the seller to take back part of an order line of a delivered order, with the reason and up to 5 photos
+// @Tags         Order
+// @Param 	order_id path  string true "Order ID"
+// @Param CreateReturnRequest body request.CreateReturnRequest true "CreateReturnRequest"
+// @Success 201 {object} response.GetReturnRequestResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-CreateReturnRequest
+// @Router       /orders/{order_id}/returns   [post]
+func (d *Handler) CreateReturnRequest(c *fiber.Ctx) error {
+	orderID, err := strconv.ParseUint(c.Params("order_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "order_id can'b be null and should be an integer",
+		})
+	}
+
+	request := request.CreateReturnRequest{}
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	resp, err := d.orderSrv.CreateReturnRequest(c.Context(), int64(orderID), request)
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	resp.StatusCode = http.StatusCreated
+	resp.Message = "success"
+
+	return c.Status(http.StatusCreated).JSON(resp)
+}
+
+// GetOrderReturnRequests is a handler to get the return requests of an order
+// GetOrderReturnRequests godoc
+// @Summary      get return requests of an order
+// @Description  get the return requests of an order along with their status and refund
+// @Tags         Order
+// @Param 	order_id path  string true "Order ID"
+// @Success 200 {object} response.GetReturnRequestListResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-GetOrderReturnRequests
+// @Router       /orders/{order_id}/returns   [get]
+func (d *Handler) GetOrderReturnRequests(c *fiber.Ctx) error {
+	orderID, err := strconv.ParseUint(c.Params("order_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "order_id can'b be null and should be an integer",
+		})
+	}
+
+	resp, err := d.orderSrv.GetOrderReturnRequests(c.Context(), int64(orderID))
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	resp.StatusCode = http.StatusOK
+	resp.Message = "success"
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// GetSellerReturnRequests is a handler to get the return requests of a seller
+// GetSellerReturnRequests godoc
+// @Summary      get return requests of a seller
+// @Description  get the return requests for the products of a seller with pagination, the latest first
+// @Tags         Return
+// @Param 	user_id path  string true "User ID"
+// @Param 	page query  int false "Page"
+// @Param 	per_page query  int false "Per page"
+// @Success 200 {object} response.GetSellerReturnRequestListResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-GetSellerReturnRequests
+// @Router       /seller/{user_id}/returns   [get]
+func (d *Handler) GetSellerReturnRequests(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "user_id can'b be null and should be an integer",
+		})
+	}
+
+	request := request.Pagination{}
+	if err := c.QueryParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	resp, err := d.orderSrv.GetSellerReturnRequests(c.Context(), int64(userID), request, c.Path())
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	resp.StatusCode = http.StatusOK
+	resp.Message = "success"
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// ApproveReturnRequest is a handler to approve a return request
+// ApproveReturnRequest godoc
+// @Summary      approve a return request
+// @Description  accept a return, the refund defaults to what was paid for the returned units and is given back once the goods are received
+// @Tags         Return
+// @Param 	user_id path  string true "User ID"
+// @Param 	return_id path  string true "Return ID"
+// @Param ReviewReturnRequest body request.ReviewReturnRequest true "ReviewReturnRequest"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-ApproveReturnRequest
+// @Router       /seller/{user_id}/returns/{return_id}/approve   [put]
+func (d *Handler) ApproveReturnRequest(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "user_id can'b be null and should be an integer",
+		})
+	}
+
+	returnID, err := strconv.ParseUint(c.Params("return_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "return_id can'b be null and should be an integer",
+		})
+	}
+
+	request := request.ReviewReturnRequest{}
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	err = d.orderSrv.ApproveReturnRequest(c.Context(), int64(userID), int64(returnID), request)
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(response.BaseResponse{
+		StatusCode: http.StatusOK,
+		Message:    "success",
+	})
+}
+
+// RejectReturnRequest is a handler to reject a return request
+// RejectReturnRequest godoc
+// @Summary      reject a return request
+// @Description  turn a return down, the note telling the customer why is required
+// @Tags         Return
+// @Param 	user_id path  string true "User ID"
+// @Param 	return_id path  string true "Return ID"
+// @Param ReviewReturnRequest body request.ReviewReturnRequest true "ReviewReturnRequest"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-RejectReturnRequest
+// @Router       /seller/{user_id}/returns/{return_id}/reject   [put]
+func (d *Handler) RejectReturnRequest(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "user_id can'b be null and should be an integer",
+		})
+	}
+
+	returnID, err := strconv.ParseUint(c.Params("return_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "return_id can'b be null and should be an integer",
+		})
+	}
+
+	request := request.ReviewReturnRequest{}
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	err = d.orderSrv.RejectReturnRequest(c.Context(), int64(userID), int64(returnID), request)
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(response.BaseResponse{
+		StatusCode: http.StatusOK,
+		Message:    "success",
+	})
+}
+
+// ReceiveReturnRequest is a handler to receive the goods of a return
+// ReceiveReturnRequest godoc
+// @Summary      receive the goods of a return
+// @Description  record that the goods of an approved return are back, they are restocked and the approved refund is issued through the payment gateway
+// @Tags         Return
+// @Param 	user_id path  string true "User ID"
+// @Param 	return_id path  string true "Return ID"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-ReceiveReturnRequest
+// @Router       /seller/{user_id}/returns/{return_id}/receive   [put]
+func (d *Handler) ReceiveReturnRequest(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "user_id can'b be null and should be an integer",
+		})
+	}
+
+	returnID, err := strconv.ParseUint(c.Params("return_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "return_id can'b be null and should be an integer",
+		})
+	}
+
+	err = d.orderSrv.ReceiveReturnRequest(c.Context(), int64(userID), int64(returnID))
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(response.BaseResponse{
+		StatusCode: http.StatusOK,
+		Message:    "success",
+	})
+}
@@ -0,0 +1,97 @@
+package httpservice
+
+import (
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetSellerStorefront is a handler to get the storefront of a seller
+// GetSellerStorefront godoc
+// @Summary      get storefront of a seller
+// @Description  get seller profile, product count, average rating and products filtered like the product list
+// @Tags         Seller
+// @Param 	user_id path  string true "User ID"
+// @Param 	search query  string false "Search"
+// @Param 	sort query  string false "Sort column"
+// @Param 	is_asc query  bool false "Sort ascending"
+// @Param 	category_id query  int false "Category ID"
+// @Success 200 {object} response.GetSellerStorefrontResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-GetSellerStorefront
+// @Router       /seller/{user_id}   [get]
+func (d *Handler) GetSellerStorefront(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "user_id can'b be null and should be an integer",
+		})
+	}
+
+	request := request.FilterProduct{}
+	if err := c.QueryParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	resp, err := d.sellerSrv.GetSellerStorefront(c.Context(), int64(userID), request)
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	resp.StatusCode = http.StatusOK
+	resp.Message = "success"
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// UpsertSeller is a handler to create or update the profile of a seller
+// UpsertSeller godoc
+// @Summary      create or update seller profile
+// @Description  create or update the profile shown on the storefront of a seller
+// @Tags         Seller
+// @Param 	user_id path  string true "User ID"
+// @Param UpsertSeller body request.UpsertSeller true "UpsertSeller"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-UpsertSeller
+// @Router       /seller/{user_id}   [put]
+func (d *Handler) UpsertSeller(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "user_id can'b be null and should be an integer",
+		})
+	}
+
+	request := request.UpsertSeller{}
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	err = d.sellerSrv.UpsertSeller(c.Context(), int64(userID), request)
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(response.BaseResponse{
+		StatusCode: http.StatusOK,
+		Message:    "success",
+	})
+}
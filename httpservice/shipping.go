@@ -0,0 +1,42 @@
+package httpservice
+
+import (
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// QuoteShipping is a handler to get the shipping options of a cart
+// QuoteShipping godoc
+// @Summary      quote shipping of a cart
+// @Description  get the courier services able to ship the cart of a user to a postal code, priced by the weight brackets of the destination zone. The items of every seller are a package charged by the heavier of its actual and volumetric weight
+// @Tags         Shipping
+// @Param ShippingQuote body request.ShippingQuote true "ShippingQuote"
+// @Success 200 {object} response.GetShippingQuoteResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-QuoteShipping
+// @Router       /shipping/quote   [post]
+func (d *Handler) QuoteShipping(c *fiber.Ctx) error {
+	request := request.ShippingQuote{}
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	resp, err := d.shippingSrv.QuoteShipping(c.Context(), request)
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	resp.StatusCode = http.StatusOK
+	resp.Message = "success"
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
@@ -5,9 +5,33 @@ import "ecommerce/service"
 // Handler is struct standart to handle accounting_journal handler
 type Handler struct {
 	ecommerceSrv service.EcommerceProvider
+	cartSrv      service.CartProvider
+	orderSrv     service.OrderProvider
+	inventorySrv service.InventoryProvider
+	categorySrv  service.CategoryProvider
+	etalaseSrv   service.EtalaseProvider
+	sellerSrv    service.SellerProvider
+	imageSrv     service.ImageProvider
+	feedSrv      service.FeedProvider
+	promotionSrv service.PromotionProvider
+	shippingSrv  service.ShippingProvider
+	wishlistSrv  service.WishlistProvider
+	webhookSrv   service.WebhookProvider
 }
 
 // HandlerConfig is standart configuration for accounting_journal config
 type HandlerConfig struct {
 	EcommerceSrv service.EcommerceProvider
+	CartSrv      service.CartProvider
+	OrderSrv     service.OrderProvider
+	InventorySrv service.InventoryProvider
+	CategorySrv  service.CategoryProvider
+	EtalaseSrv   service.EtalaseProvider
+	SellerSrv    service.SellerProvider
+	ImageSrv     service.ImageProvider
+	FeedSrv      service.FeedProvider
+	PromotionSrv service.PromotionProvider
+	ShippingSrv  service.ShippingProvider
+	WishlistSrv  service.WishlistProvider
+	WebhookSrv   service.WebhookProvider
 }
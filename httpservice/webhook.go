@@ -0,0 +1,281 @@
+package httpservice
+
+import (
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetWebhookSubscriptions is a handler to get the webhook subscriptions of a seller
+// GetWebhookSubscriptions godoc
+// @Summary      get webhook subscriptions of a seller
+// @Description  get the webhooks a seller is notified on, the secrets are not returned
+// @Tags         Webhook
+// @Param 	user_id path  string true "User ID"
+// @Success 200 {object} response.GetWebhookSubscriptionListResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-GetWebhookSubscriptions
+// @Router       /seller/{user_id}/webhooks   [get]
+func (d *Handler) GetWebhookSubscriptions(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "user_id can'b be null and should be an integer",
+		})
+	}
+
+	resp, err := d.webhookSrv.GetWebhookSubscriptions(c.Context(), int64(userID))
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	resp.StatusCode = http.StatusOK
+	resp.Message = "success"
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// CreateWebhookSubscription is a handler to create a webhook subscription
+// CreateWebhookSubscription godoc
+// @Summary      create a webhook subscription
+// @Description  notify a url of the review.created, order.created or order.paid events of a seller, deliveries are signed with the hex encoded HMAC-SHA256 of the X-Webhook-Timestamp header, a dot and their body in the X-Webhook-Signature header. The url should resolve to a public address. The secret is generated when it is not given and is only returned here
+// @Tags         Webhook
+// @Param 	user_id path  string true "User ID"
+// @Param UpsertWebhookSubscription body request.UpsertWebhookSubscription true "UpsertWebhookSubscription"
+// @Success 201 {object} response.GetWebhookSubscriptionResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-CreateWebhookSubscription
+// @Router       /seller/{user_id}/webhooks   [post]
+func (d *Handler) CreateWebhookSubscription(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "user_id can'b be null and should be an integer",
+		})
+	}
+
+	request := request.UpsertWebhookSubscription{}
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	resp, err := d.webhookSrv.CreateWebhookSubscription(c.Context(), int64(userID), request)
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	resp.StatusCode = http.StatusCreated
+	resp.Message = "success"
+
+	return c.Status(http.StatusCreated).JSON(resp)
+}
+
+// UpdateWebhookSubscription is a handler to update a webhook subscription
+// UpdateWebhookSubscription godoc
+// @Summary      update a webhook subscription
+// @Description  update the url, event types and active flag of a webhook, the secret is rotated when one is given
+// @Tags         Webhook
+// @Param 	user_id path  string true "User ID"
+// @Param 	webhook_id path  string true "Webhook ID"
+// @Param UpsertWebhookSubscription body request.UpsertWebhookSubscription true "UpsertWebhookSubscription"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-UpdateWebhookSubscription
+// @Router       /seller/{user_id}/webhooks/{webhook_id}   [put]
+func (d *Handler) UpdateWebhookSubscription(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "user_id can'b be null and should be an integer",
+		})
+	}
+
+	webhookID, err := strconv.ParseUint(c.Params("webhook_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "webhook_id can'b be null and should be an integer",
+		})
+	}
+
+	request := request.UpsertWebhookSubscription{}
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	err = d.webhookSrv.UpdateWebhookSubscription(c.Context(), int64(userID), int64(webhookID), request)
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(response.BaseResponse{
+		StatusCode: http.StatusOK,
+		Message:    "success",
+	})
+}
+
+// DeleteWebhookSubscription is a handler to delete a webhook subscription
+// DeleteWebhookSubscription godoc
+// @Summary      delete a webhook subscription
+// @Description  delete a webhook along with its delivery log
+// @Tags         Webhook
+// @Param 	user_id path  string true "User ID"
+// @Param 	webhook_id path  string true "Webhook ID"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-DeleteWebhookSubscription
+// @Router       /seller/{user_id}/webhooks/{webhook_id}   [delete]
+func (d *Handler) DeleteWebhookSubscription(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "user_id can'b be null and should be an integer",
+		})
+	}
+
+	webhookID, err := strconv.ParseUint(c.Params("webhook_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "webhook_id can'b be null and should be an integer",
+		})
+	}
+
+	err = d.webhookSrv.DeleteWebhookSubscription(c.Context(), int64(userID), int64(webhookID))
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(response.BaseResponse{
+		StatusCode: http.StatusOK,
+		Message:    "success",
+	})
+}
+
+// GetWebhookDeliveries is a handler to get the delivery log of a webhook subscription
+// GetWebhookDeliveries godoc
+// @Summary      get deliveries of a webhook
+// @Description  get the deliveries of a webhook with the response code of every attempt with pagination, the latest first
+// @Tags         Webhook
+// @Param 	user_id path  string true "User ID"
+// @Param 	webhook_id path  string true "Webhook ID"
+// @Param 	page query  int false "Page"
+// @Param 	per_page query  int false "Per page"
+// @Success 200 {object} response.GetWebhookDeliveryListResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-GetWebhookDeliveries
+// @Router       /seller/{user_id}/webhooks/{webhook_id}/deliveries   [get]
+func (d *Handler) GetWebhookDeliveries(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "user_id can'b be null and should be an integer",
+		})
+	}
+
+	webhookID, err := strconv.ParseUint(c.Params("webhook_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "webhook_id can'b be null and should be an integer",
+		})
+	}
+
+	request := request.Pagination{}
+	if err := c.QueryParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	resp, err := d.webhookSrv.GetWebhookDeliveries(c.Context(), int64(userID), int64(webhookID), request, c.Path())
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	resp.StatusCode = http.StatusOK
+	resp.Message = "success"
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// RedeliverWebhook is a handler to post a webhook delivery again
+// RedeliverWebhook godoc
+// @Summary      redeliver a webhook delivery
+// @Description  post a delivery again right away whatever its status, a successful redelivery marks the delivery as succeeded
+// @Tags         Webhook
+// @Param 	user_id path  string true "User ID"
+// @Param 	webhook_id path  string true "Webhook ID"
+// @Param 	delivery_id path  string true "Delivery ID"
+// @Success 200 {object} response.GetWebhookDeliveryResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-RedeliverWebhook
+// @Router       /seller/{user_id}/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver   [post]
+func (d *Handler) RedeliverWebhook(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "user_id can'b be null and should be an integer",
+		})
+	}
+
+	webhookID, err := strconv.ParseUint(c.Params("webhook_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "webhook_id can'b be null and should be an integer",
+		})
+	}
+
+	deliveryID, err := strconv.ParseUint(c.Params("delivery_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "delivery_id can'b be null and should be an integer",
+		})
+	}
+
+	resp, err := d.webhookSrv.RedeliverWebhook(c.Context(), int64(userID), int64(webhookID), int64(deliveryID))
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	resp.StatusCode = http.StatusOK
+	resp.Message = "success"
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
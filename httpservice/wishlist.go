@@ -0,0 +1,137 @@
+package httpservice
+
+import (
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetWishlist is a handler to get the wishlist of a user
+// GetWishlist godoc
+// @Summary      get wishlist of a user
+// @Description  get the products on the wishlist of a user with their current price and stock with pagination, the latest added first
+// @Tags         Wishlist
+// @Param 	user_id path  string true "User ID"
+// @Param 	page query  int false "Page"
+// @Param 	per_page query  int false "Per page"
+// @Success 200 {object} response.GetWishlistResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-GetWishlist
+// @Router       /wishlist/{user_id}   [get]
+func (d *Handler) GetWishlist(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "user_id can'b be null and should be an integer",
+		})
+	}
+
+	request := request.Pagination{}
+	if err := c.QueryParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	resp, err := d.wishlistSrv.GetWishlist(c.Context(), int64(userID), request, c.Path())
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	resp.StatusCode = http.StatusOK
+	resp.Message = "success"
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// AddWishlistItem is a handler to add a product to the wishlist of a user
+// AddWishlistItem godoc
+// @Summary      add product to wishlist
+// @Description  favourite a product for a user, adding a product already on the wishlist changes nothing
+// @Tags         Wishlist
+// @Param 	user_id path  string true "User ID"
+// @Param AddWishlistItem body request.AddWishlistItem true "AddWishlistItem"
+// @Success 201 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-AddWishlistItem
+// @Router       /wishlist/{user_id}/items   [post]
+func (d *Handler) AddWishlistItem(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "user_id can'b be null and should be an integer",
+		})
+	}
+
+	request := request.AddWishlistItem{}
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+
+	err = d.wishlistSrv.AddWishlistItem(c.Context(), int64(userID), request)
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusCreated).JSON(response.BaseResponse{
+		StatusCode: http.StatusCreated,
+		Message:    "success",
+	})
+}
+
+// RemoveWishlistItem is a handler to remove a product from the wishlist of a user
+// RemoveWishlistItem godoc
+// @Summary      remove product from wishlist
+// @Description  remove a product from the wishlist of a user
+// @Tags         Wishlist
+// @Param 	user_id path  string true "User ID"
+// @Param 	product_id path  string true "Product ID"
+// @Success 200 {object} response.BaseResponse{}
+// @Failure 400 {object} response.Error{}
+// @ID v1-RemoveWishlistItem
+// @Router       /wishlist/{user_id}/items/{product_id}   [delete]
+func (d *Handler) RemoveWishlistItem(c *fiber.Ctx) error {
+	userID, err := strconv.ParseUint(c.Params("user_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "user_id can'b be null and should be an integer",
+		})
+	}
+
+	productID, err := strconv.ParseUint(c.Params("product_id"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Error{
+			StatusCode: http.StatusBadRequest,
+			Message:    "product_id can'b be null and should be an integer",
+		})
+	}
+
+	err = d.wishlistSrv.RemoveWishlistItem(c.Context(), int64(userID), int64(productID))
+	if err != nil {
+		return c.Status(errorStatusCode(err)).JSON(response.Error{
+			StatusCode: errorStatusCode(err),
+			Message:    err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(response.BaseResponse{
+		StatusCode: http.StatusOK,
+		Message:    "success",
+	})
+}
@@ -15,6 +15,26 @@ type Config struct {
 	Env string `yaml:"environment"`
 	// Database configuration
 	Database map[string]*DatabaseConfig `yaml:"database"`
+	// Inventory configuration
+	Inventory InventoryConfig `yaml:"inventory"`
+	// Storage configuration of uploaded files
+	Storage StorageConfig `yaml:"storage"`
+	// Image upload configuration
+	Image ImageConfig `yaml:"image"`
+	// Product feed configuration
+	Feed FeedConfig `yaml:"feed"`
+	// Currency and exchange rate configuration
+	Currency CurrencyConfig `yaml:"currency"`
+	// Shipping rate configuration
+	Shipping ShippingConfig `yaml:"shipping"`
+	// Payment gateway configuration
+	Payment PaymentConfig `yaml:"payment"`
+	// Domain event configuration
+	Events EventsConfig `yaml:"events"`
+	// Seller webhook configuration
+	Webhook WebhookConfig `yaml:"webhook"`
+	// Invoice document configuration
+	Invoice InvoiceConfig `yaml:"invoice"`
 }
 
 type DatabaseConfig struct {
@@ -27,6 +47,123 @@ type DatabaseConfig struct {
 	MaxOpenConn int           `yaml:"max_open_conns"`
 }
 
+type InventoryConfig struct {
+	ReservationTTL            time.Duration `yaml:"reservation_ttl"`
+	ReservationExpiryInterval time.Duration `yaml:"reservation_expiry_interval"`
+}
+
+type StorageConfig struct {
+	// Driver is the storage implementation, only local is supported for now
+	Driver string             `yaml:"driver"`
+	Local  LocalStorageConfig `yaml:"local"`
+}
+
+type LocalStorageConfig struct {
+	// Dir is the directory the files are written to
+	Dir string `yaml:"dir"`
+	// Path is the http path the files are served from
+	Path string `yaml:"path"`
+	// BaseURL is prepended to Path to build the public url of a file
+	BaseURL string `yaml:"base_url"`
+	// PrivateDir is the directory the files only served through the api, such as the invoices, are written to. It
+	// must not be inside Dir, which is served as is
+	PrivateDir string `yaml:"private_dir"`
+}
+
+type ImageConfig struct {
+	// MaxSize is the maximum size in bytes of an uploaded image
+	MaxSize int64 `yaml:"max_size"`
+	// ProcessInterval is how often the variants of the uploaded images are generated
+	ProcessInterval time.Duration `yaml:"process_interval"`
+}
+
+type FeedConfig struct {
+	// Interval is how often the product feeds are generated
+	Interval time.Duration `yaml:"interval"`
+	// Title is the name of the store in the feeds
+	Title string `yaml:"title"`
+	// LinkBaseURL is prepended to the product id to build the link of a product page
+	LinkBaseURL string `yaml:"link_base_url"`
+}
+
+type CurrencyConfig struct {
+	// Default is the ISO 4217 code of the currency of the products created without one
+	Default string `yaml:"default"`
+	// ExchangeRates is the value of one unit of each currency in a common base currency, product prices can be
+	// displayed in any currency of the table
+	ExchangeRates map[string]float64 `yaml:"exchange_rates"`
+}
+
+type ShippingConfig struct {
+	// Source is where the zones and rates are read from, config for the tables below or database
+	Source string `yaml:"source"`
+	// VolumetricDivisor is the volume in cubic centimeters charged as one kilogram
+	VolumetricDivisor float64 `yaml:"volumetric_divisor"`
+	// Zones maps postal code prefixes to zones
+	Zones []ShippingZoneConfig `yaml:"zones"`
+	// Rates are the weight brackets of every courier service per zone, weights are in grams
+	Rates []ShippingRateConfig `yaml:"rates"`
+}
+
+type ShippingZoneConfig struct {
+	Name               string   `yaml:"name"`
+	PostalCodePrefixes []string `yaml:"postal_code_prefixes"`
+}
+
+type ShippingRateConfig struct {
+	Courier   string  `yaml:"courier"`
+	Service   string  `yaml:"service"`
+	Zone      string  `yaml:"zone"`
+	MinWeight float64 `yaml:"min_weight"`
+	// MaxWeight is the heaviest package of the bracket, 0 has no maximum
+	MaxWeight float64 `yaml:"max_weight"`
+	Price     int64   `yaml:"price"`
+	// Currency defaults to the default currency of the store
+	Currency      string `yaml:"currency"`
+	EstimatedDays int    `yaml:"estimated_days"`
+}
+
+type PaymentConfig struct {
+	// Gateway is the payment gateway orders are charged with, only fake is supported for now
+	Gateway string            `yaml:"gateway"`
+	Fake    FakePaymentConfig `yaml:"fake"`
+}
+
+type FakePaymentConfig struct {
+	// Secret keys the HMAC-SHA256 signature of the webhooks
+	Secret string `yaml:"secret"`
+	// PaymentURL is prepended to the charge id to build the payment url of a charge
+	PaymentURL string `yaml:"payment_url"`
+}
+
+type EventsConfig struct {
+	// Publisher is where the domain events are published to, log writes them to stdout and memory keeps them in the
+	// process
+	Publisher string `yaml:"publisher"`
+	// RelayInterval is how often the events written to the outbox are published
+	RelayInterval time.Duration `yaml:"relay_interval"`
+}
+
+type WebhookConfig struct {
+	// Timeout is how long a subscriber has to answer a delivery
+	Timeout time.Duration `yaml:"timeout"`
+	// DeliveryInterval is how often the due deliveries are posted
+	DeliveryInterval time.Duration `yaml:"delivery_interval"`
+	// MaxAttempts is the number of times a delivery is attempted before it is marked as failed
+	MaxAttempts int `yaml:"max_attempts"`
+	// RetryBackoff is the wait before the first retry of a delivery, it doubles on every retry
+	RetryBackoff time.Duration `yaml:"retry_backoff"`
+	// AllowPrivateNetworks lets the webhooks be posted to loopback, link-local and private addresses, it is only
+	// meant for local development
+	AllowPrivateNetworks bool `yaml:"allow_private_networks"`
+}
+
+type InvoiceConfig struct {
+	// FontFile is the TrueType font the pdf invoices are set in, DejaVu Sans Mono when it is empty. DejaVu Sans Mono
+	// has no chinese, japanese or korean characters, the stores selling with them need a font that has them
+	FontFile string `yaml:"font_file"`
+}
+
 // InitConfig Read and process config file
 func InitConfig() Config {
 	appconfig := Config{}
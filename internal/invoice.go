@@ -0,0 +1,28 @@
+package internal
+
+import (
+	"ecommerce/utils/pdf"
+	"fmt"
+	"io"
+	"os"
+)
+
+// NewInvoiceFont reads the font the pdf invoices are set in, nil is returned for the default font. The font is tried
+// out so a file that isn't a TrueType font fails at startup rather than when the first invoice is downloaded.
+func NewInvoiceFont(config Config) ([]byte, error) {
+	if config.Invoice.FontFile == "" {
+		return nil, nil
+	}
+
+	font, err := os.ReadFile(config.Invoice.FontFile)
+	if err != nil {
+		return nil, err
+	}
+
+	err = pdf.Write(io.Discard, font, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", config.Invoice.FontFile, err)
+	}
+
+	return font, nil
+}
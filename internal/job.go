@@ -0,0 +1,54 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	support "ecommerce/utils/logger"
+)
+
+// Job is work run in the background every Interval.
+type Job struct {
+	// Name describes what the job does, it is logged when the job fails
+	Name     string
+	Interval time.Duration
+	// Immediate runs the job when it is started instead of waiting for the first tick
+	Immediate bool
+	Run       func(ctx context.Context) error
+}
+
+// StartJobs runs every job in its own goroutine, a failed run is logged and the job runs again on the next tick. The
+// intervals are checked before any job is started since a job without a positive interval would never run.
+func StartJobs(logger *support.Logger, jobs ...Job) error {
+	for _, v := range jobs {
+		if v.Interval <= 0 {
+			return fmt.Errorf("the interval to %s should be positive, got %s", v.Name, v.Interval)
+		}
+	}
+
+	for _, v := range jobs {
+		go runJob(logger, v)
+	}
+
+	return nil
+}
+
+func runJob(logger *support.Logger, job Job) {
+	run := func() {
+		if err := job.Run(context.Background()); err != nil {
+			logger.Errorf("failed to %s: %v", job.Name, err)
+		}
+	}
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	if job.Immediate {
+		run()
+	}
+
+	for range ticker.C {
+		run()
+	}
+}
@@ -0,0 +1,18 @@
+package internal
+
+import (
+	"ecommerce/repository"
+	"ecommerce/repository/payment"
+	"fmt"
+)
+
+// NewPaymentGateway initialises the configured payment gateway.
+func NewPaymentGateway(config Config) (repository.PaymentGateway, error) {
+	switch config.Payment.Gateway {
+	case "", payment.FakeName:
+		fake := config.Payment.Fake
+		return payment.NewFake(fake.Secret, fake.PaymentURL), nil
+	default:
+		return nil, fmt.Errorf("unknown payment gateway %q", config.Payment.Gateway)
+	}
+}
@@ -0,0 +1,20 @@
+package internal
+
+import (
+	"ecommerce/repository"
+	"ecommerce/repository/publisher"
+	"fmt"
+	"os"
+)
+
+// NewEventPublisher initialises the configured publisher of the domain events.
+func NewEventPublisher(config Config) (repository.EventPublisher, error) {
+	switch config.Events.Publisher {
+	case "", publisher.LogName:
+		return publisher.NewLog(os.Stdout), nil
+	case publisher.MemoryName:
+		return publisher.NewMemory(), nil
+	default:
+		return nil, fmt.Errorf("unknown event publisher %q", config.Events.Publisher)
+	}
+}
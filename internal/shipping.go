@@ -0,0 +1,49 @@
+package internal
+
+import (
+	"ecommerce/model/entity"
+	"ecommerce/model/weight"
+	"ecommerce/repository"
+	"ecommerce/repository/postgre"
+	"ecommerce/repository/static"
+	"ecommerce/utils/sql"
+	"fmt"
+)
+
+// NewShippingRate initialises the shipping rate repository of the configured source.
+func NewShippingRate(config Config, db sql.DBer) (repository.ShippingRateProvider, error) {
+	switch config.Shipping.Source {
+	case "", "config":
+		var zones []entity.ShippingZone
+		for _, v := range config.Shipping.Zones {
+			for _, prefix := range v.PostalCodePrefixes {
+				zones = append(zones, entity.ShippingZone{Zone: v.Name, PostalCodePrefix: prefix})
+			}
+		}
+
+		var rates []entity.ShippingRate
+		for _, v := range config.Shipping.Rates {
+			currency := v.Currency
+			if currency == "" {
+				currency = config.Currency.Default
+			}
+
+			rates = append(rates, entity.ShippingRate{
+				Courier:       v.Courier,
+				Service:       v.Service,
+				Zone:          v.Zone,
+				MinWeight:     weight.Grams(v.MinWeight),
+				MaxWeight:     weight.Grams(v.MaxWeight),
+				Price:         v.Price,
+				Currency:      currency,
+				EstimatedDays: v.EstimatedDays,
+			})
+		}
+
+		return static.NewShippingRate(zones, rates), nil
+	case "database":
+		return postgre.NewShippingRate(db), nil
+	default:
+		return nil, fmt.Errorf("unknown shipping rate source %q", config.Shipping.Source)
+	}
+}
@@ -0,0 +1,51 @@
+package internal
+
+import (
+	"ecommerce/repository"
+	"ecommerce/repository/storage"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// NewStorage initialises the storage of uploaded files configured by the storage driver.
+func NewStorage(config Config) (repository.StorageProvider, error) {
+	switch config.Storage.Driver {
+	case "", "local":
+		local := config.Storage.Local
+		return storage.NewLocal(local.Dir, local.BaseURL+local.Path), nil
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", config.Storage.Driver)
+	}
+}
+
+// NewPrivateStorage initialises the storage of the files only served through the api, such as the invoices, configured
+// by the storage driver. Unlike NewStorage its files have no public url.
+func NewPrivateStorage(config Config) (repository.StorageProvider, error) {
+	switch config.Storage.Driver {
+	case "", "local":
+		local := config.Storage.Local
+		if local.PrivateDir == "" {
+			return nil, fmt.Errorf("storage.local.private_dir is required")
+		}
+
+		dir, err := filepath.Abs(local.Dir)
+		if err != nil {
+			return nil, err
+		}
+
+		privateDir, err := filepath.Abs(local.PrivateDir)
+		if err != nil {
+			return nil, err
+		}
+
+		rel, err := filepath.Rel(dir, privateDir)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return nil, fmt.Errorf("storage.local.private_dir %q is served publicly from storage.local.dir %q", local.PrivateDir, local.Dir)
+		}
+
+		return storage.NewLocal(local.PrivateDir, ""), nil
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", config.Storage.Driver)
+	}
+}
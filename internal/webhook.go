@@ -0,0 +1,20 @@
+package internal
+
+import (
+	"ecommerce/repository"
+	"ecommerce/repository/webhook"
+	"time"
+)
+
+// defaultWebhookTimeout is used when no webhook timeout is configured.
+const defaultWebhookTimeout = 10 * time.Second
+
+// NewWebhookClient initialises the client the webhooks of the sellers are posted with.
+func NewWebhookClient(config Config) repository.WebhookClient {
+	timeout := config.Webhook.Timeout
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+
+	return webhook.NewHTTP(timeout, config.Webhook.AllowPrivateNetworks)
+}
@@ -12,33 +12,343 @@ import (
 )
 
 func main() {
-	db := internal.NewDatabases(internal.InitConfig(), support.NewLogger())
+	config := internal.InitConfig()
+	logger := support.NewLogger()
+	db := internal.NewDatabases(config, logger)
 	ecommerceRepo := postgre.NewEcommerce(db["main"])
+	cartRepo := postgre.NewCart(db["main"])
+	orderRepo := postgre.NewOrder(db["main"])
+	inventoryRepo := postgre.NewInventory(db["main"])
+	variantRepo := postgre.NewVariant(db["main"])
+	categoryRepo := postgre.NewCategory(db["main"])
+	etalaseRepo := postgre.NewEtalase(db["main"])
+	sellerRepo := postgre.NewSeller(db["main"])
+	attributeRepo := postgre.NewAttribute(db["main"])
+	imageRepo := postgre.NewImage(db["main"])
+	productImportRepo := postgre.NewProductImport(db["main"])
+	auditLogRepo := postgre.NewAuditLog(db["main"])
+	productPriceRepo := postgre.NewProductPrice(db["main"])
+	promotionRepo := postgre.NewPromotion(db["main"])
+	paymentRepo := postgre.NewPayment(db["main"])
+	returnRequestRepo := postgre.NewReturnRequest(db["main"])
+	wishlistRepo := postgre.NewWishlist(db["main"])
+	invoiceRepo := postgre.NewInvoice(db["main"])
+	outboxRepo := postgre.NewOutbox(db["main"])
+	webhookRepo := postgre.NewWebhook(db["main"])
+	transactionRepo := postgre.NewTransaction(db["main"])
+	storageRepo, err := internal.NewStorage(config)
+	if err != nil {
+		logger.Fatalf("failed to initialize storage: %v", err)
+	}
+	privateStorageRepo, err := internal.NewPrivateStorage(config)
+	if err != nil {
+		logger.Fatalf("failed to initialize private storage: %v", err)
+	}
+	shippingRateRepo, err := internal.NewShippingRate(config, db["main"])
+	if err != nil {
+		logger.Fatalf("failed to initialize shipping rates: %v", err)
+	}
+	paymentGateway, err := internal.NewPaymentGateway(config)
+	if err != nil {
+		logger.Fatalf("failed to initialize payment gateway: %v", err)
+	}
+	eventPublisher, err := internal.NewEventPublisher(config)
+	if err != nil {
+		logger.Fatalf("failed to initialize event publisher: %v", err)
+	}
+	webhookClient := internal.NewWebhookClient(config)
+	invoiceFont, err := internal.NewInvoiceFont(config)
+	if err != nil {
+		logger.Fatalf("failed to load invoice font: %v", err)
+	}
 
 	ecommerceService := service.NewEcommerceService(
 		service.EcommerceConfig{
+			EcommerceRepo:     ecommerceRepo,
+			InventoryRepo:     inventoryRepo,
+			VariantRepo:       variantRepo,
+			CategoryRepo:      categoryRepo,
+			AttributeRepo:     attributeRepo,
+			ImageRepo:         imageRepo,
+			ProductImportRepo: productImportRepo,
+			StorageRepo:       storageRepo,
+			AuditLogRepo:      auditLogRepo,
+			ProductPriceRepo:  productPriceRepo,
+			OutboxRepo:        outboxRepo,
+			WebhookRepo:       webhookRepo,
+			TransactionRepo:   transactionRepo,
+			Currency:          config.Currency.Default,
+			ExchangeRates:     config.Currency.ExchangeRates,
+		},
+	)
+	cartService := service.NewCartService(
+		service.CartConfig{
+			CartRepo:      cartRepo,
+			EcommerceRepo: ecommerceRepo,
+			VariantRepo:   variantRepo,
+		},
+	)
+	orderService := service.NewOrderService(
+		service.OrderConfig{
+			OrderRepo:         orderRepo,
+			EcommerceRepo:     ecommerceRepo,
+			CartRepo:          cartRepo,
+			InventoryRepo:     inventoryRepo,
+			PromotionRepo:     promotionRepo,
+			CategoryRepo:      categoryRepo,
+			PaymentRepo:       paymentRepo,
+			PaymentGateway:    paymentGateway,
+			ReturnRequestRepo: returnRequestRepo,
+			InvoiceRepo:       invoiceRepo,
+			SellerRepo:        sellerRepo,
+			StorageRepo:       privateStorageRepo,
+			OutboxRepo:        outboxRepo,
+			WebhookRepo:       webhookRepo,
+			TransactionRepo:   transactionRepo,
+			ReservationTTL:    config.Inventory.ReservationTTL,
+			InvoiceFont:       invoiceFont,
+		},
+	)
+	inventoryService := service.NewInventoryService(
+		service.InventoryConfig{
+			InventoryRepo:   inventoryRepo,
+			TransactionRepo: transactionRepo,
+		},
+	)
+	categoryService := service.NewCategoryService(
+		service.CategoryConfig{
+			CategoryRepo:    categoryRepo,
+			TransactionRepo: transactionRepo,
+		},
+	)
+	etalaseService := service.NewEtalaseService(
+		service.EtalaseConfig{
+			EtalaseRepo:     etalaseRepo,
+			EcommerceRepo:   ecommerceRepo,
+			TransactionRepo: transactionRepo,
+		},
+	)
+	sellerService := service.NewSellerService(
+		service.SellerConfig{
+			SellerRepo:    sellerRepo,
+			EcommerceRepo: ecommerceRepo,
+			ExchangeRates: config.Currency.ExchangeRates,
+			Currency:      config.Currency.Default,
+		},
+	)
+	imageService := service.NewImageService(
+		service.ImageConfig{
+			StorageRepo: storageRepo,
+			ImageRepo:   imageRepo,
+			MaxSize:     config.Image.MaxSize,
+		},
+	)
+	feedService := service.NewFeedService(
+		service.FeedConfig{
 			EcommerceRepo: ecommerceRepo,
+			StorageRepo:   storageRepo,
+			Title:         config.Feed.Title,
+			LinkBaseURL:   config.Feed.LinkBaseURL,
+		},
+	)
+	promotionService := service.NewPromotionService(
+		service.PromotionConfig{
+			PromotionRepo: promotionRepo,
+			CategoryRepo:  categoryRepo,
+			CartRepo:      cartRepo,
+			Currency:      config.Currency.Default,
+		},
+	)
+	shippingService := service.NewShippingService(
+		service.ShippingConfig{
+			CartRepo:          cartRepo,
+			ShippingRateRepo:  shippingRateRepo,
+			VolumetricDivisor: config.Shipping.VolumetricDivisor,
+		},
+	)
+	wishlistService := service.NewWishlistService(
+		service.WishlistConfig{
+			WishlistRepo:    wishlistRepo,
+			EcommerceRepo:   ecommerceRepo,
+			TransactionRepo: transactionRepo,
+		},
+	)
+	eventService := service.NewEventService(
+		service.EventConfig{
+			OutboxRepo:      outboxRepo,
+			Publisher:       eventPublisher,
+			TransactionRepo: transactionRepo,
+		},
+	)
+	webhookService := service.NewWebhookService(
+		service.WebhookConfig{
+			WebhookRepo:     webhookRepo,
+			WebhookClient:   webhookClient,
+			TransactionRepo: transactionRepo,
+			MaxAttempts:     config.Webhook.MaxAttempts,
+			RetryBackoff:    config.Webhook.RetryBackoff,
 		},
 	)
 	httpService := httpservice.NewHandler(httpservice.HandlerConfig{
 		EcommerceSrv: &ecommerceService,
+		CartSrv:      &cartService,
+		OrderSrv:     &orderService,
+		InventorySrv: &inventoryService,
+		CategorySrv:  &categoryService,
+		EtalaseSrv:   &etalaseService,
+		SellerSrv:    &sellerService,
+		ImageSrv:     &imageService,
+		FeedSrv:      &feedService,
+		PromotionSrv: &promotionService,
+		ShippingSrv:  &shippingService,
+		WishlistSrv:  &wishlistService,
+		WebhookSrv:   &webhookService,
 	})
 
+	err = internal.StartJobs(logger,
+		internal.Job{
+			Name:     "expire stock reservations",
+			Interval: config.Inventory.ReservationExpiryInterval,
+			Run:      orderService.ExpireStockReservations,
+		},
+		internal.Job{
+			Name:     "process pending images",
+			Interval: config.Image.ProcessInterval,
+			Run:      imageService.ProcessPendingImages,
+		},
+		internal.Job{
+			Name:     "relay events",
+			Interval: config.Events.RelayInterval,
+			Run:      eventService.RelayEvents,
+		},
+		internal.Job{
+			Name:     "deliver webhooks",
+			Interval: config.Webhook.DeliveryInterval,
+			Run:      webhookService.DeliverWebhooks,
+		},
+		internal.Job{
+			Name:     "generate product feeds",
+			Interval: config.Feed.Interval,
+			// the feeds are generated right away so they are available before the first tick
+			Immediate: true,
+			Run:       feedService.GenerateProductFeeds,
+		},
+	)
+	if err != nil {
+		logger.Fatalf("failed to start background jobs: %v", err)
+	}
+
 	app := fiber.New()
 
 	app.Get("/", func(c *fiber.Ctx) error {
 		return c.SendString("Hello, World!")
 	})
 
+	if config.Storage.Driver == "" || config.Storage.Driver == "local" {
+		app.Static(config.Storage.Local.Path, config.Storage.Local.Dir)
+	}
+
 	api := app.Group("/api") // /api
 
 	productApi := api.Group("/product") // /api
 
 	productApi.Get("/list", httpService.GetProductList)
+	productApi.Get("/export", httpService.ExportProducts)
 	productApi.Post("/", httpService.CreateProduct)
 	productApi.Put("/:product_id", httpService.UpdateProduct)
 	productApi.Post("/review", httpService.CreateProductReview)
+	productApi.Post("/image", httpService.UploadImage)
+	productApi.Post("/import", httpService.ImportProducts)
+	productApi.Get("/import/:import_id", httpService.GetProductImport)
+	productApi.Get("/import/:import_id/report", httpService.GetProductImportReport)
+	productApi.Put("/:product_id/images/order", httpService.ReorderProductImages)
+	productApi.Put("/:product_id/images/:image_id/primary", httpService.SetPrimaryProductImage)
+	productApi.Get("/:product_id/history", httpService.GetProductHistory)
+	productApi.Get("/:product_id/prices", httpService.GetProductPrices)
+	productApi.Post("/:product_id/prices", httpService.ScheduleProductPrice)
+	productApi.Delete("/:product_id/prices/:price_id", httpService.CancelProductPrice)
 	productApi.Get("/:product_id", httpService.GetDetailProduct)
 
+	cartApi := api.Group("/cart") // /api/cart
+
+	cartApi.Get("/:user_id", httpService.GetCart)
+	cartApi.Post("/:user_id/items", httpService.AddCartItem)
+	cartApi.Put("/:user_id/items/:product_id", httpService.UpdateCartItem)
+	cartApi.Delete("/:user_id/items/:product_id", httpService.RemoveCartItem)
+	cartApi.Post("/:user_id/promotions", httpService.EvaluateCartPromotions)
+
+	wishlistApi := api.Group("/wishlist") // /api/wishlist
+
+	wishlistApi.Get("/:user_id", httpService.GetWishlist)
+	wishlistApi.Post("/:user_id/items", httpService.AddWishlistItem)
+	wishlistApi.Delete("/:user_id/items/:product_id", httpService.RemoveWishlistItem)
+
+	orderApi := api.Group("/orders") // /api/orders
+
+	orderApi.Post("/", httpService.CreateOrder)
+	orderApi.Get("/:order_id", httpService.GetDetailOrder)
+	orderApi.Put("/:order_id/status", httpService.UpdateOrderStatus)
+	orderApi.Post("/:order_id/payments", httpService.CreatePayment)
+	orderApi.Get("/:order_id/payments", httpService.GetOrderPayments)
+	orderApi.Post("/:order_id/refund", httpService.RefundOrder)
+	orderApi.Post("/:order_id/returns", httpService.CreateReturnRequest)
+	orderApi.Get("/:order_id/returns", httpService.GetOrderReturnRequests)
+	orderApi.Get("/:order_id/invoice", httpService.GetOrderInvoice)
+
+	paymentApi := api.Group("/payments") // /api/payments
+
+	paymentApi.Post("/webhook", httpService.HandlePaymentWebhook)
+
+	promotionApi := api.Group("/promotions") // /api/promotions
+
+	promotionApi.Get("/", httpService.GetPromotionList)
+	promotionApi.Post("/", httpService.CreatePromotion)
+	promotionApi.Get("/:promotion_id", httpService.GetDetailPromotion)
+	promotionApi.Put("/:promotion_id", httpService.UpdatePromotion)
+	promotionApi.Delete("/:promotion_id", httpService.DeletePromotion)
+
+	shippingApi := api.Group("/shipping") // /api/shipping
+
+	shippingApi.Post("/quote", httpService.QuoteShipping)
+
+	inventoryApi := api.Group("/inventory") // /api/inventory
+
+	inventoryApi.Post("/:product_id/adjust", httpService.AdjustStock)
+	inventoryApi.Get("/:product_id/ledger", httpService.GetInventoryLedger)
+
+	categoryApi := api.Group("/category") // /api/category
+
+	categoryApi.Get("/", httpService.GetCategoryList)
+	categoryApi.Post("/", httpService.CreateCategory)
+	categoryApi.Get("/:category_id", httpService.GetDetailCategory)
+	categoryApi.Put("/:category_id", httpService.UpdateCategory)
+	categoryApi.Delete("/:category_id", httpService.DeleteCategory)
+
+	feedApi := api.Group("/feed") // /api/feed
+
+	feedApi.Get("/google.:format", httpService.GetProductFeed)
+
+	sellerApi := api.Group("/seller") // /api/seller
+
+	sellerApi.Get("/:user_id", httpService.GetSellerStorefront)
+	sellerApi.Put("/:user_id", httpService.UpsertSeller)
+	sellerApi.Get("/:user_id/etalase", httpService.GetEtalaseList)
+	sellerApi.Post("/:user_id/etalase", httpService.CreateEtalase)
+	sellerApi.Put("/:user_id/etalase/:id", httpService.UpdateEtalase)
+	sellerApi.Delete("/:user_id/etalase/:id", httpService.DeleteEtalase)
+	sellerApi.Get("/:user_id/etalase/:id/products", httpService.GetEtalaseProductList)
+	sellerApi.Post("/:user_id/etalase/:id/products", httpService.AssignEtalaseProducts)
+	sellerApi.Delete("/:user_id/etalase/:id/products/:product_id", httpService.RemoveEtalaseProduct)
+	sellerApi.Get("/:user_id/returns", httpService.GetSellerReturnRequests)
+	sellerApi.Put("/:user_id/returns/:return_id/approve", httpService.ApproveReturnRequest)
+	sellerApi.Put("/:user_id/returns/:return_id/reject", httpService.RejectReturnRequest)
+	sellerApi.Put("/:user_id/returns/:return_id/receive", httpService.ReceiveReturnRequest)
+	sellerApi.Get("/:user_id/webhooks", httpService.GetWebhookSubscriptions)
+	sellerApi.Post("/:user_id/webhooks", httpService.CreateWebhookSubscription)
+	sellerApi.Put("/:user_id/webhooks/:webhook_id", httpService.UpdateWebhookSubscription)
+	sellerApi.Delete("/:user_id/webhooks/:webhook_id", httpService.DeleteWebhookSubscription)
+	sellerApi.Get("/:user_id/webhooks/:webhook_id/deliveries", httpService.GetWebhookDeliveries)
+	sellerApi.Post("/:user_id/webhooks/:webhook_id/deliveries/:delivery_id/redeliver", httpService.RedeliverWebhook)
+
 	app.Listen(":3000")
 }
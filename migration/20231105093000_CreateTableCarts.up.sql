@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS carts (
+  id serial PRIMARY KEY,
+  user_id bigint NOT NULL UNIQUE,
+  created_at timestamp NOT NULL default NOW(),
+  updated_at timestamp default NOW()
+);
+
+CREATE TABLE IF NOT EXISTS cart_items (
+  id serial PRIMARY KEY,
+  cart_id bigint NOT NULL,
+  product_id bigint NOT NULL,
+  quantity int NOT NULL,
+  price int NOT NULL,
+  created_at timestamp NOT NULL default NOW(),
+  updated_at timestamp default NOW(),
+  UNIQUE (cart_id, product_id)
+);
@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS orders (
+  id serial PRIMARY KEY,
+  user_id bigint NOT NULL,
+  status varchar(50) NOT NULL,
+  total_price bigint NOT NULL,
+  total_weight float NOT NULL,
+  created_at timestamp NOT NULL default NOW(),
+  updated_at timestamp default NOW()
+);
+
+CREATE INDEX IF NOT EXISTS orders_user_id_idx ON orders (user_id);
+
+CREATE TABLE IF NOT EXISTS order_items (
+  id serial PRIMARY KEY,
+  order_id bigint NOT NULL,
+  product_id bigint NOT NULL,
+  sku varchar(255) NOT NULL,
+  title varchar(255) NOT NULL,
+  price int NOT NULL,
+  quantity int NOT NULL,
+  weight float NOT NULL,
+  created_at timestamp NOT NULL default NOW(),
+  updated_at timestamp default NOW()
+);
+
+CREATE INDEX IF NOT EXISTS order_items_order_id_idx ON order_items (order_id);
+
+CREATE TABLE IF NOT EXISTS order_status_history (
+  id serial PRIMARY KEY,
+  order_id bigint NOT NULL,
+  from_status varchar(50) NOT NULL default '',
+  to_status varchar(50) NOT NULL,
+  created_at timestamp NOT NULL default NOW()
+);
+
+CREATE INDEX IF NOT EXISTS order_status_history_order_id_idx ON order_status_history (order_id);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS stock_reservations;
+
+DROP TABLE IF EXISTS inventory_ledger;
+
+ALTER TABLE products DROP COLUMN IF EXISTS stock;
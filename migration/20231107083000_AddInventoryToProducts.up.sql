@@ -0,0 +1,28 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS stock int NOT NULL default 0 CHECK (stock >= 0);
+
+CREATE TABLE IF NOT EXISTS inventory_ledger (
+  id serial PRIMARY KEY,
+  product_id bigint NOT NULL,
+  quantity_change int NOT NULL,
+  stock_after int NOT NULL,
+  reason varchar(50) NOT NULL,
+  reference varchar(255) NOT NULL default '',
+  created_at timestamp NOT NULL default NOW()
+);
+
+CREATE INDEX IF NOT EXISTS inventory_ledger_product_id_idx ON inventory_ledger (product_id);
+
+CREATE TABLE IF NOT EXISTS stock_reservations (
+  id serial PRIMARY KEY,
+  product_id bigint NOT NULL,
+  order_id bigint NOT NULL,
+  quantity int NOT NULL,
+  status varchar(50) NOT NULL,
+  expires_at timestamp NOT NULL,
+  created_at timestamp NOT NULL default NOW(),
+  updated_at timestamp default NOW()
+);
+
+CREATE INDEX IF NOT EXISTS stock_reservations_order_id_idx ON stock_reservations (order_id);
+
+CREATE INDEX IF NOT EXISTS stock_reservations_active_expires_at_idx ON stock_reservations (expires_at) WHERE status = 'active';
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS product_variant_option_values;
+
+DROP TABLE IF EXISTS product_variants;
+
+DROP TABLE IF EXISTS product_option_values;
+
+DROP TABLE IF EXISTS product_options;
@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS product_options (
+  id serial PRIMARY KEY,
+  product_id bigint NOT NULL,
+  name varchar(50) NOT NULL,
+  position int NOT NULL default 0,
+  created_at timestamp NOT NULL default NOW(),
+  updated_at timestamp default NOW(),
+  UNIQUE (product_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS product_option_values (
+  id serial PRIMARY KEY,
+  option_id bigint NOT NULL,
+  value varchar(50) NOT NULL,
+  position int NOT NULL default 0,
+  created_at timestamp NOT NULL default NOW(),
+  updated_at timestamp default NOW(),
+  UNIQUE (option_id, value)
+);
+
+CREATE TABLE IF NOT EXISTS product_variants (
+  id serial PRIMARY KEY,
+  product_id bigint NOT NULL,
+  sku varchar(255) NOT NULL,
+  price int,
+  weight float,
+  stock int NOT NULL default 0 CHECK (stock >= 0),
+  created_at timestamp NOT NULL default NOW(),
+  updated_at timestamp default NOW(),
+  UNIQUE (product_id, sku)
+);
+
+CREATE TABLE IF NOT EXISTS product_variant_option_values (
+  variant_id bigint NOT NULL,
+  option_value_id bigint NOT NULL,
+  PRIMARY KEY (variant_id, option_value_id)
+);
@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS categories (
+  id serial PRIMARY KEY,
+  parent_id bigint,
+  name varchar(255) NOT NULL,
+  slug varchar(255) NOT NULL UNIQUE,
+  position int NOT NULL default 0,
+  created_at timestamp NOT NULL default NOW(),
+  updated_at timestamp default NOW()
+);
+
+CREATE INDEX IF NOT EXISTS categories_parent_id_idx ON categories (parent_id);
+
+-- backfill one category per slug so "Shoes" and "shoes" end up in the same category
+INSERT INTO categories (name, slug)
+SELECT DISTINCT ON (slug)
+  name,
+  slug
+FROM (
+  SELECT
+    id,
+    trim(category) AS name,
+    trim(both '-' from lower(regexp_replace(trim(category), '[^a-zA-Z0-9]+', '-', 'g'))) AS slug
+  FROM
+    products
+) c
+WHERE
+  slug <> ''
+ORDER BY
+  slug, id
+ON CONFLICT (slug) DO NOTHING;
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS category_id bigint NOT NULL default 0;
+
+UPDATE
+  products p
+SET
+  category_id = c.id
+FROM
+  categories c
+WHERE
+  c.slug = trim(both '-' from lower(regexp_replace(trim(p.category), '[^a-zA-Z0-9]+', '-', 'g')));
+
+CREATE INDEX IF NOT EXISTS products_category_id_idx ON products (category_id);
@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS etalases (
+  id serial PRIMARY KEY,
+  user_id bigint NOT NULL,
+  name varchar(255) NOT NULL,
+  position int NOT NULL default 0,
+  created_at timestamp NOT NULL default NOW(),
+  updated_at timestamp default NOW(),
+  UNIQUE (user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS product_etalases (
+  product_id bigint NOT NULL,
+  etalase_id bigint NOT NULL,
+  created_at timestamp NOT NULL default NOW(),
+  PRIMARY KEY (etalase_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS product_etalases_product_id_idx ON product_etalases (product_id);
+
+-- backfill the sections sellers already typed in products.etalase
+INSERT INTO etalases (user_id, name)
+SELECT DISTINCT
+  user_id,
+  trim(etalase)
+FROM
+  products
+WHERE
+  trim(coalesce(etalase, '')) <> ''
+ON CONFLICT (user_id, name) DO NOTHING;
+
+INSERT INTO product_etalases (product_id, etalase_id)
+SELECT
+  p.id,
+  e.id
+FROM
+  products p
+JOIN
+  etalases e ON e.user_id = p.user_id AND e.name = trim(p.etalase)
+ON CONFLICT DO NOTHING;
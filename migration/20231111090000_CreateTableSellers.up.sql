@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS sellers (
+  user_id bigint PRIMARY KEY,
+  name varchar(255) NOT NULL,
+  description varchar(255) NOT NULL default '',
+  city varchar(255) NOT NULL default '',
+  avatar_url varchar(255) NOT NULL default '',
+  created_at timestamp NOT NULL default NOW(),
+  updated_at timestamp default NOW()
+);
+
+CREATE INDEX IF NOT EXISTS products_user_id_idx ON products (user_id);
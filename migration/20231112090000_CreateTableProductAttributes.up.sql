@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS product_attributes (
+  id serial PRIMARY KEY,
+  product_id bigint NOT NULL,
+  name varchar(50) NOT NULL,
+  type varchar(20) NOT NULL,
+  value varchar(255) NOT NULL,
+  created_at timestamp NOT NULL default NOW(),
+  updated_at timestamp default NOW(),
+  UNIQUE (product_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS product_attributes_name_value_idx ON product_attributes (name, value);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS image_variants;
+DROP TABLE IF EXISTS images;
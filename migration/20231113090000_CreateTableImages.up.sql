@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS images (
+  id serial PRIMARY KEY,
+  storage_key varchar(255) NOT NULL UNIQUE,
+  image_url text NOT NULL,
+  content_type varchar(50) NOT NULL,
+  size bigint NOT NULL,
+  status varchar(20) NOT NULL default 'pending',
+  attempts int NOT NULL default 0,
+  last_error text NOT NULL default '',
+  created_at timestamp NOT NULL default NOW(),
+  updated_at timestamp default NOW()
+);
+
+CREATE INDEX IF NOT EXISTS images_image_url_idx ON images (image_url);
+CREATE INDEX IF NOT EXISTS images_status_idx ON images (status, updated_at);
+
+CREATE TABLE IF NOT EXISTS image_variants (
+  id serial PRIMARY KEY,
+  image_id bigint NOT NULL REFERENCES images (id) ON DELETE CASCADE,
+  name varchar(20) NOT NULL,
+  format varchar(20) NOT NULL,
+  url text NOT NULL,
+  width int NOT NULL,
+  height int NOT NULL,
+  created_at timestamp NOT NULL default NOW(),
+  updated_at timestamp default NOW(),
+  UNIQUE (image_id, name, format)
+);
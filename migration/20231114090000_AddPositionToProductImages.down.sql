@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS product_images_primary_idx;
+
+ALTER TABLE product_images
+  DROP COLUMN IF EXISTS position,
+  DROP COLUMN IF EXISTS is_primary;
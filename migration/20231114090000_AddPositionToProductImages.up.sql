@@ -0,0 +1,16 @@
+ALTER TABLE product_images
+  ADD COLUMN IF NOT EXISTS position int NOT NULL default 0,
+  ADD COLUMN IF NOT EXISTS is_primary boolean NOT NULL default false;
+
+-- existing images keep their insertion order, the first one becomes the primary image
+UPDATE product_images pi
+SET
+  position = ordered.position,
+  is_primary = ordered.position = 0
+FROM (
+  SELECT id, ROW_NUMBER() OVER (PARTITION BY product_id ORDER BY id) - 1 AS position
+  FROM product_images
+) ordered
+WHERE ordered.id = pi.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS product_images_primary_idx ON product_images (product_id) WHERE is_primary;
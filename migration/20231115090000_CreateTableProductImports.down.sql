@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS product_imports;
+
+DROP INDEX IF EXISTS products_user_id_sku_idx;
@@ -0,0 +1,18 @@
+-- imports upsert products by seller and sku, duplicated skus of a seller have to be resolved before migrating
+CREATE UNIQUE INDEX IF NOT EXISTS products_user_id_sku_idx ON products (user_id, sku);
+
+CREATE TABLE IF NOT EXISTS product_imports (
+  id serial PRIMARY KEY,
+  user_id bigint NOT NULL,
+  format varchar(20) NOT NULL,
+  status varchar(20) NOT NULL,
+  total_rows int NOT NULL default 0,
+  created_rows int NOT NULL default 0,
+  updated_rows int NOT NULL default 0,
+  failed_rows int NOT NULL default 0,
+  report_key varchar(255) NOT NULL default '',
+  created_at timestamp NOT NULL default NOW(),
+  updated_at timestamp default NOW()
+);
+
+CREATE INDEX IF NOT EXISTS product_imports_user_id_idx ON product_imports (user_id);
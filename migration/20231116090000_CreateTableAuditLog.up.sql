@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS audit_log (
+  id serial PRIMARY KEY,
+  entity_type varchar(50) NOT NULL,
+  entity_id bigint NOT NULL,
+  product_id bigint NOT NULL,
+  action varchar(20) NOT NULL,
+  actor varchar(255) NOT NULL default '',
+  diff jsonb NOT NULL default '{}',
+  created_at timestamp NOT NULL default NOW()
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_product_id_idx ON audit_log (product_id, id);
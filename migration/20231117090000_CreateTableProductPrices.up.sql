@@ -0,0 +1,15 @@
+-- effective_from and effective_to are compared with NOW() when prices are read, so they keep their time zone
+CREATE TABLE IF NOT EXISTS product_prices (
+  id serial PRIMARY KEY,
+  product_id bigint NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+  kind varchar(20) NOT NULL,
+  price bigint NOT NULL,
+  effective_from timestamptz NOT NULL,
+  effective_to timestamptz,
+  created_at timestamp NOT NULL default NOW()
+);
+
+CREATE INDEX IF NOT EXISTS product_prices_product_id_idx ON product_prices (product_id, effective_from);
+
+INSERT INTO product_prices (product_id, kind, price, effective_from)
+SELECT id, 'regular', price, created_at FROM products;
@@ -0,0 +1,3 @@
+-- prices were stored without a currency, they are all in the default currency of the store
+ALTER TABLE products ADD COLUMN IF NOT EXISTS currency varchar(3) NOT NULL default 'IDR';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS currency varchar(3) NOT NULL default 'IDR';
@@ -0,0 +1,4 @@
+ALTER TABLE order_items DROP COLUMN IF EXISTS discount;
+ALTER TABLE orders DROP COLUMN IF EXISTS discount;
+DROP TABLE IF EXISTS promotion_usages;
+DROP TABLE IF EXISTS promotions;
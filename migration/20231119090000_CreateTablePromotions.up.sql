@@ -0,0 +1,38 @@
+-- a promotion without a code is applied automatically to every cart it fits, a promotion with a code is a voucher
+CREATE TABLE IF NOT EXISTS promotions (
+  id serial PRIMARY KEY,
+  code varchar(50) NOT NULL default '',
+  name varchar(255) NOT NULL,
+  type varchar(20) NOT NULL,
+  value bigint NOT NULL,
+  max_discount bigint NOT NULL default 0,
+  min_spend bigint NOT NULL default 0,
+  currency varchar(3) NOT NULL default 'IDR',
+  usage_limit bigint NOT NULL default 0,
+  per_user_limit bigint NOT NULL default 0,
+  usage_count bigint NOT NULL default 0,
+  scope varchar(20) NOT NULL default 'all',
+  scope_ids bigint[] NOT NULL default '{}',
+  starts_at timestamptz NOT NULL,
+  ends_at timestamptz,
+  is_active boolean NOT NULL default true,
+  created_at timestamp NOT NULL default NOW(),
+  updated_at timestamp default NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS promotions_code_idx ON promotions (upper(code)) WHERE code <> '';
+
+CREATE TABLE IF NOT EXISTS promotion_usages (
+  id serial PRIMARY KEY,
+  promotion_id bigint NOT NULL REFERENCES promotions (id),
+  user_id bigint NOT NULL,
+  order_id bigint NOT NULL,
+  discount bigint NOT NULL,
+  created_at timestamp NOT NULL default NOW()
+);
+
+CREATE INDEX IF NOT EXISTS promotion_usages_promotion_id_idx ON promotion_usages (promotion_id, user_id);
+CREATE INDEX IF NOT EXISTS promotion_usages_order_id_idx ON promotion_usages (order_id);
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS discount bigint NOT NULL default 0;
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS discount bigint NOT NULL default 0;
@@ -0,0 +1,4 @@
+-- dimensions are in centimeters, products without them are shipped by their weight only
+ALTER TABLE products ADD COLUMN IF NOT EXISTS length float NOT NULL default 0;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS width float NOT NULL default 0;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS height float NOT NULL default 0;
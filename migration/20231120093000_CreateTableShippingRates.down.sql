@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS shipping_rates;
+DROP TABLE IF EXISTS shipping_zones;
@@ -0,0 +1,26 @@
+-- a destination belongs to the zone with the longest postal code prefix matching its postal code
+CREATE TABLE IF NOT EXISTS shipping_zones (
+  id serial PRIMARY KEY,
+  zone varchar(100) NOT NULL,
+  postal_code_prefix varchar(10) NOT NULL,
+  created_at timestamp NOT NULL default NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS shipping_zones_postal_code_prefix_idx ON shipping_zones (postal_code_prefix);
+
+-- weights are in grams, a rate applies to packages heavier than min_weight up to max_weight, 0 being no maximum
+CREATE TABLE IF NOT EXISTS shipping_rates (
+  id serial PRIMARY KEY,
+  courier varchar(50) NOT NULL,
+  service varchar(50) NOT NULL,
+  zone varchar(100) NOT NULL,
+  min_weight float NOT NULL default 0,
+  max_weight float NOT NULL default 0,
+  price bigint NOT NULL,
+  currency varchar(3) NOT NULL default 'IDR',
+  estimated_days int NOT NULL default 0,
+  created_at timestamp NOT NULL default NOW(),
+  updated_at timestamp default NOW()
+);
+
+CREATE INDEX IF NOT EXISTS shipping_rates_zone_idx ON shipping_rates (zone, courier, service, min_weight);
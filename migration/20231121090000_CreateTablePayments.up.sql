@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS payments (
+  id serial PRIMARY KEY,
+  order_id bigint NOT NULL,
+  gateway varchar(50) NOT NULL,
+  charge_id varchar(255) NOT NULL,
+  amount bigint NOT NULL,
+  currency varchar(3) NOT NULL,
+  status varchar(20) NOT NULL,
+  payment_url text NOT NULL default '',
+  created_at timestamp NOT NULL default NOW(),
+  updated_at timestamp default NOW()
+);
+
+CREATE INDEX IF NOT EXISTS payments_order_id_idx ON payments (order_id);
+CREATE UNIQUE INDEX IF NOT EXISTS payments_charge_id_idx ON payments (gateway, charge_id);
+
+-- webhooks are delivered at least once, the unique event id makes a redelivered event a no-op
+CREATE TABLE IF NOT EXISTS payment_events (
+  id serial PRIMARY KEY,
+  gateway varchar(50) NOT NULL,
+  event_id varchar(255) NOT NULL,
+  payment_id bigint NOT NULL,
+  type varchar(50) NOT NULL,
+  payload jsonb NOT NULL,
+  created_at timestamp NOT NULL default NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS payment_events_event_id_idx ON payment_events (gateway, event_id);
@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS return_requests (
+  id serial PRIMARY KEY,
+  order_id bigint NOT NULL,
+  order_item_id bigint NOT NULL,
+  product_id bigint NOT NULL,
+  seller_id bigint NOT NULL,
+  user_id bigint NOT NULL,
+  quantity int NOT NULL,
+  reason text NOT NULL,
+  photo_urls text[] NOT NULL default '{}',
+  status varchar(20) NOT NULL,
+  seller_note text NOT NULL default '',
+  refund_amount bigint NOT NULL default 0,
+  created_at timestamp NOT NULL default NOW(),
+  updated_at timestamp default NOW()
+);
+
+CREATE INDEX IF NOT EXISTS return_requests_order_id_idx ON return_requests (order_id);
+CREATE INDEX IF NOT EXISTS return_requests_order_item_id_idx ON return_requests (order_item_id);
+CREATE INDEX IF NOT EXISTS return_requests_seller_id_idx ON return_requests (seller_id, id);
+
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS refunded_amount bigint NOT NULL default 0;
+
+CREATE TABLE IF NOT EXISTS refunds (
+  id serial PRIMARY KEY,
+  order_id bigint NOT NULL,
+  payment_id bigint NOT NULL,
+  return_request_id bigint NOT NULL,
+  amount bigint NOT NULL,
+  created_at timestamp NOT NULL default NOW()
+);
+
+CREATE INDEX IF NOT EXISTS refunds_order_id_idx ON refunds (order_id);
@@ -0,0 +1,2 @@
+ALTER TABLE products DROP COLUMN IF EXISTS favourited_count;
+DROP TABLE IF EXISTS wishlist_items;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS wishlist_items (
+  id serial PRIMARY KEY,
+  user_id bigint NOT NULL,
+  product_id bigint NOT NULL,
+  created_at timestamp NOT NULL default NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS wishlist_items_user_id_product_id_idx ON wishlist_items (user_id, product_id);
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS favourited_count bigint NOT NULL default 0;
+
+UPDATE products SET favourited_count = (SELECT COUNT(*) FROM wishlist_items wi WHERE wi.product_id = products.id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS invoices;
+DROP TABLE IF EXISTS invoice_sequences;
+ALTER TABLE order_items DROP COLUMN IF EXISTS seller_id;
@@ -0,0 +1,25 @@
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS seller_id bigint NOT NULL default 0;
+
+UPDATE order_items SET seller_id = p.user_id FROM products p WHERE p.id = order_items.product_id;
+
+CREATE TABLE IF NOT EXISTS invoice_sequences (
+  seller_id bigint PRIMARY KEY,
+  last_sequence bigint NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS invoices (
+  id serial PRIMARY KEY,
+  order_id bigint NOT NULL,
+  seller_id bigint NOT NULL,
+  sequence bigint NOT NULL,
+  number varchar(50) NOT NULL,
+  subtotal bigint NOT NULL,
+  discount bigint NOT NULL,
+  total bigint NOT NULL,
+  currency varchar(3) NOT NULL,
+  issued_at timestamptz NOT NULL,
+  created_at timestamp NOT NULL default NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS invoices_seller_id_sequence_idx ON invoices (seller_id, sequence);
+CREATE UNIQUE INDEX IF NOT EXISTS invoices_order_id_seller_id_idx ON invoices (order_id, seller_id);
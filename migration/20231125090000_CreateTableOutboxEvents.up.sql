@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS outbox_events (
+  id bigserial PRIMARY KEY,
+  aggregate_type varchar(50) NOT NULL,
+  aggregate_id bigint NOT NULL,
+  type varchar(100) NOT NULL,
+  payload jsonb NOT NULL,
+  attempts int NOT NULL default 0,
+  last_error text NOT NULL default '',
+  published_at timestamptz,
+  created_at timestamptz NOT NULL default NOW()
+);
+
+CREATE INDEX IF NOT EXISTS outbox_events_unpublished_idx ON outbox_events (id) WHERE published_at IS NULL;
@@ -0,0 +1,41 @@
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+  id bigserial PRIMARY KEY,
+  seller_id bigint NOT NULL,
+  url text NOT NULL,
+  secret varchar(255) NOT NULL,
+  event_types text[] NOT NULL,
+  active boolean NOT NULL default true,
+  created_at timestamptz NOT NULL default NOW(),
+  updated_at timestamptz NOT NULL default NOW()
+);
+
+CREATE INDEX IF NOT EXISTS webhook_subscriptions_seller_id_idx ON webhook_subscriptions (seller_id);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+  id bigserial PRIMARY KEY,
+  subscription_id bigint NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+  event_id bigint NOT NULL REFERENCES outbox_events (id),
+  status varchar(20) NOT NULL default 'pending',
+  attempts int NOT NULL default 0,
+  response_code int NOT NULL default 0,
+  last_error text NOT NULL default '',
+  next_attempt_at timestamptz NOT NULL default NOW(),
+  delivered_at timestamptz,
+  created_at timestamptz NOT NULL default NOW(),
+  updated_at timestamptz NOT NULL default NOW()
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_subscription_id_idx ON webhook_deliveries (subscription_id, id);
+CREATE INDEX IF NOT EXISTS webhook_deliveries_pending_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS webhook_delivery_attempts (
+  id bigserial PRIMARY KEY,
+  delivery_id bigint NOT NULL REFERENCES webhook_deliveries (id) ON DELETE CASCADE,
+  response_code int NOT NULL default 0,
+  error text NOT NULL default '',
+  duration_ms bigint NOT NULL default 0,
+  manual boolean NOT NULL default false,
+  created_at timestamptz NOT NULL default NOW()
+);
+
+CREATE INDEX IF NOT EXISTS webhook_delivery_attempts_delivery_id_idx ON webhook_delivery_attempts (delivery_id);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS refunds_pending_idx;
+ALTER TABLE refunds DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE refunds DROP COLUMN IF EXISTS status;
+DROP INDEX IF EXISTS payments_charge_id_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS payments_charge_id_idx ON payments (gateway, charge_id);
+ALTER TABLE payments ALTER COLUMN charge_id DROP DEFAULT;
@@ -0,0 +1,10 @@
+-- payments are recorded before their charge is created at the gateway, so their charge id is empty until then
+ALTER TABLE payments ALTER COLUMN charge_id SET DEFAULT '';
+DROP INDEX IF EXISTS payments_charge_id_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS payments_charge_id_idx ON payments (gateway, charge_id) WHERE charge_id <> '';
+
+-- refunds are recorded as pending before the gateway is asked for them, the refunds made so far already succeeded
+ALTER TABLE refunds ADD COLUMN IF NOT EXISTS status varchar(20) NOT NULL default 'succeeded';
+ALTER TABLE refunds ADD COLUMN IF NOT EXISTS updated_at timestamp default NOW();
+
+CREATE INDEX IF NOT EXISTS refunds_pending_idx ON refunds (order_id) WHERE status = 'pending';
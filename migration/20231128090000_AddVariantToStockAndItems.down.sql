@@ -0,0 +1,9 @@
+ALTER TABLE cart_items DROP CONSTRAINT IF EXISTS cart_items_cart_id_product_id_variant_id_key;
+DELETE FROM cart_items WHERE variant_id <> 0;
+ALTER TABLE cart_items ADD CONSTRAINT cart_items_cart_id_product_id_key UNIQUE (cart_id, product_id);
+ALTER TABLE cart_items DROP COLUMN IF EXISTS variant_id;
+
+ALTER TABLE return_requests DROP COLUMN IF EXISTS variant_id;
+ALTER TABLE order_items DROP COLUMN IF EXISTS variant_id;
+ALTER TABLE stock_reservations DROP COLUMN IF EXISTS variant_id;
+ALTER TABLE inventory_ledger DROP COLUMN IF EXISTS variant_id;
@@ -0,0 +1,10 @@
+-- variant_id is 0 for products without variants, their stock is kept on the product itself
+ALTER TABLE inventory_ledger ADD COLUMN IF NOT EXISTS variant_id bigint NOT NULL default 0;
+ALTER TABLE stock_reservations ADD COLUMN IF NOT EXISTS variant_id bigint NOT NULL default 0;
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS variant_id bigint NOT NULL default 0;
+ALTER TABLE return_requests ADD COLUMN IF NOT EXISTS variant_id bigint NOT NULL default 0;
+
+-- every variant of a product is a cart item of its own
+ALTER TABLE cart_items ADD COLUMN IF NOT EXISTS variant_id bigint NOT NULL default 0;
+ALTER TABLE cart_items DROP CONSTRAINT IF EXISTS cart_items_cart_id_product_id_key;
+ALTER TABLE cart_items ADD CONSTRAINT cart_items_cart_id_product_id_variant_id_key UNIQUE (cart_id, product_id, variant_id);
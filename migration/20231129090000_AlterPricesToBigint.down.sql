@@ -0,0 +1,5 @@
+-- fails while a price larger than an int is stored
+ALTER TABLE order_items ALTER COLUMN price TYPE int;
+ALTER TABLE cart_items ALTER COLUMN price TYPE int;
+ALTER TABLE product_variants ALTER COLUMN price TYPE int;
+ALTER TABLE products ALTER COLUMN price TYPE int;
@@ -0,0 +1,5 @@
+-- the money columns are in the minor unit of their currency, prices in currencies such as IDR outgrow an int
+ALTER TABLE products ALTER COLUMN price TYPE bigint;
+ALTER TABLE product_variants ALTER COLUMN price TYPE bigint;
+ALTER TABLE cart_items ALTER COLUMN price TYPE bigint;
+ALTER TABLE order_items ALTER COLUMN price TYPE bigint;
@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	AuditEntityProduct      = "product"
+	AuditEntityProductImage = "product_image"
+	AuditEntityProductPrice = "product_price"
+
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+)
+
+// AuditLog is a change made to a product or one of its images. Diff holds the old and new value of every changed
+// column, e.g. {"price": {"old": 1000, "new": 1200}}.
+type AuditLog struct {
+	ID         int64           `db:"id"`
+	EntityType string          `db:"entity_type"`
+	EntityID   int64           `db:"entity_id"`
+	ProductID  int64           `db:"product_id"`
+	Action     string          `db:"action"`
+	Actor      string          `db:"actor"`
+	Diff       json.RawMessage `db:"diff" swaggertype:"object"`
+	CreatedAt  time.Time       `db:"created_at"`
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"ecommerce/model/weight"
+	"time"
+)
+
+type Cart struct {
+	ID        int64     `db:"id"`
+	UserID    int64     `db:"user_id"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+type CartItem struct {
+	ID        int64 `db:"id"`
+	CartID    int64 `db:"cart_id"`
+	ProductID int64 `db:"product_id"`
+	// VariantID is the chosen variant of a product with variants, 0 otherwise
+	VariantID int64     `db:"variant_id"`
+	Quantity  int64     `db:"quantity"`
+	Price     int64     `db:"price"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// CartItemDetail is a cart item joined with the current state of its product.
+type CartItemDetail struct {
+	CartItem
+	Sku          string       `db:"sku"`
+	Title        string       `db:"title"`
+	Weight       weight.Grams `db:"weight"`
+	Length       float64      `db:"length"`
+	Width        float64      `db:"width"`
+	Height       float64      `db:"height"`
+	CurrentPrice int64        `db:"current_price"`
+	Currency     string       `db:"currency"`
+	SellerID     int64        `db:"seller_id"`
+	CategoryID   int64        `db:"category_id"`
+}
@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+)
+
+type Category struct {
+	ID        int64         `db:"id"`
+	ParentID  sql.NullInt64 `db:"parent_id"`
+	Name      string        `db:"name"`
+	Slug      string        `db:"slug"`
+	Position  int           `db:"position"`
+	CreatedAt time.Time     `db:"created_at"`
+	UpdatedAt time.Time     `db:"updated_at"`
+}
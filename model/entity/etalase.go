@@ -0,0 +1,14 @@
+package entity
+
+import (
+	"time"
+)
+
+type Etalase struct {
+	ID        int64     `db:"id"`
+	UserID    int64     `db:"user_id"`
+	Name      string    `db:"name"`
+	Position  int       `db:"position"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
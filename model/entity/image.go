@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	ImageStatusPending    = "pending"
+	ImageStatusProcessing = "processing"
+	ImageStatusProcessed  = "processed"
+	ImageStatusFailed     = "failed"
+
+	ImageVariantThumb  = "thumb"
+	ImageVariantMedium = "medium"
+	ImageVariantLarge  = "large"
+
+	ImageFormatJPEG = "jpeg"
+	ImageFormatPNG  = "png"
+	ImageFormatWebP = "webp"
+)
+
+// Image is an uploaded image waiting for or done with the generation of its variants.
+type Image struct {
+	ID          int64     `db:"id"`
+	StorageKey  string    `db:"storage_key"`
+	ImageUrl    string    `db:"image_url"`
+	ContentType string    `db:"content_type"`
+	Size        int64     `db:"size"`
+	Status      string    `db:"status"`
+	Attempts    int       `db:"attempts"`
+	LastError   string    `db:"last_error"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// ImageVariant is a resized copy of an image in one format.
+type ImageVariant struct {
+	ID        int64     `db:"id"`
+	ImageID   int64     `db:"image_id"`
+	Name      string    `db:"name"`
+	Format    string    `db:"format"`
+	Url       string    `db:"url"`
+	Width     int       `db:"width"`
+	Height    int       `db:"height"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// ImageVariantDetail is an image variant with the url of its original image.
+type ImageVariantDetail struct {
+	ImageVariant
+	OriginalUrl string `db:"original_url"`
+}
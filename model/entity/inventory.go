@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	InventoryReasonInitialStock        = "initial_stock"
+	InventoryReasonAdjustment          = "adjustment"
+	InventoryReasonReservation         = "reservation"
+	InventoryReasonReservationReleased = "reservation_released"
+	InventoryReasonReservationExpired  = "reservation_expired"
+	InventoryReasonImport              = "import"
+	InventoryReasonReturn              = "return"
+	InventoryReasonOrderRefunded       = "order_refunded"
+
+	StockReservationStatusActive    = "active"
+	StockReservationStatusCommitted = "committed"
+	StockReservationStatusReleased  = "released"
+	StockReservationStatusExpired   = "expired"
+)
+
+type InventoryLedger struct {
+	ID        int64 `db:"id"`
+	ProductID int64 `db:"product_id"`
+	// VariantID is 0 for the stock of a product without variants
+	VariantID      int64     `db:"variant_id"`
+	QuantityChange int64     `db:"quantity_change"`
+	StockAfter     int64     `db:"stock_after"`
+	Reason         string    `db:"reason"`
+	Reference      string    `db:"reference"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+type StockReservation struct {
+	ID        int64     `db:"id"`
+	ProductID int64     `db:"product_id"`
+	VariantID int64     `db:"variant_id"`
+	OrderID   int64     `db:"order_id"`
+	Quantity  int64     `db:"quantity"`
+	Status    string    `db:"status"`
+	ExpiresAt time.Time `db:"expires_at"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+)
+
+// Invoice is what a seller bills for its items of a paid order. Sequence counts the invoices of the seller from 1
+// without gaps, Number is how it is printed.
+type Invoice struct {
+	ID       int64  `db:"id"`
+	OrderID  int64  `db:"order_id"`
+	SellerID int64  `db:"seller_id"`
+	Sequence int64  `db:"sequence"`
+	Number   string `db:"number"`
+	// Subtotal, Discount and Total are in the minor unit of Currency
+	Subtotal  int64     `db:"subtotal"`
+	Discount  int64     `db:"discount"`
+	Total     int64     `db:"total"`
+	Currency  string    `db:"currency"`
+	IssuedAt  time.Time `db:"issued_at"`
+	CreatedAt time.Time `db:"created_at"`
+}
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"ecommerce/model/weight"
+	"time"
+)
+
+const (
+	OrderStatusPendingPayment = "pending_payment"
+	OrderStatusPaid           = "paid"
+	OrderStatusShipped        = "shipped"
+	OrderStatusDelivered      = "delivered"
+	OrderStatusCancelled      = "cancelled"
+	OrderStatusRefunded       = "refunded"
+)
+
+type Order struct {
+	ID     int64  `db:"id"`
+	UserID int64  `db:"user_id"`
+	Status string `db:"status"`
+	// TotalPrice is what is charged, the subtotal of the items less Discount
+	TotalPrice  int64        `db:"total_price"`
+	Discount    int64        `db:"discount"`
+	Currency    string       `db:"currency"`
+	TotalWeight weight.Grams `db:"total_weight"`
+	CreatedAt   time.Time    `db:"created_at"`
+	UpdatedAt   time.Time    `db:"updated_at"`
+}
+
+type OrderItem struct {
+	ID        int64  `db:"id"`
+	OrderID   int64  `db:"order_id"`
+	ProductID int64  `db:"product_id"`
+	VariantID int64  `db:"variant_id"`
+	SellerID  int64  `db:"seller_id"`
+	Sku       string `db:"sku"`
+	Title     string `db:"title"`
+	Price     int64  `db:"price"`
+	Quantity  int64  `db:"quantity"`
+	// Discount is the share of the order discount taken off this line
+	Discount  int64        `db:"discount"`
+	Weight    weight.Grams `db:"weight"`
+	CreatedAt time.Time    `db:"created_at"`
+	UpdatedAt time.Time    `db:"updated_at"`
+}
+
+type OrderStatusHistory struct {
+	ID         int64     `db:"id"`
+	OrderID    int64     `db:"order_id"`
+	FromStatus string    `db:"from_status"`
+	ToStatus   string    `db:"to_status"`
+	CreatedAt  time.Time `db:"created_at"`
+}
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+const (
+	EventAggregateProduct = "product"
+	EventAggregateOrder   = "order"
+)
+
+const (
+	EventProductCreated        = "product.created"
+	EventProductUpdated        = "product.updated"
+	EventProductImagesChanged  = "product.images_changed"
+	EventProductPriceScheduled = "product.price_scheduled"
+	EventProductPriceCancelled = "product.price_cancelled"
+	EventReviewCreated         = "review.created"
+	EventOrderCreated          = "order.created"
+	EventOrderPaid             = "order.paid"
+)
+
+// OutboxEvent is a domain event written in the transaction of the change it describes, it is published afterwards
+// by the relay. Events of an aggregate are published in the order of their ids.
+type OutboxEvent struct {
+	ID            int64           `db:"id"`
+	AggregateType string          `db:"aggregate_type"`
+	AggregateID   int64           `db:"aggregate_id"`
+	Type          string          `db:"type"`
+	Payload       json.RawMessage `db:"payload"`
+	// Attempts counts the failed publishes, LastError is the error of the last one
+	Attempts    int          `db:"attempts"`
+	LastError   string       `db:"last_error"`
+	PublishedAt sql.NullTime `db:"published_at"`
+	CreatedAt   time.Time    `db:"created_at"`
+}
@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	// PaymentStatusCreated payments are recorded before their charge is created at the gateway.
+	PaymentStatusCreated    = "created"
+	PaymentStatusPending    = "pending"
+	PaymentStatusAuthorized = "authorized"
+	PaymentStatusCaptured   = "captured"
+	PaymentStatusFailed     = "failed"
+	// PaymentStatusPartiallyRefunded payments were captured and part of their amount was given back.
+	PaymentStatusPartiallyRefunded = "partially_refunded"
+	PaymentStatusRefunded          = "refunded"
+)
+
+const (
+	// PaymentEventAuthorized is sent when the customer authorized the charge, it still has to be captured.
+	PaymentEventAuthorized = "charge.authorized"
+	// PaymentEventCaptured is sent when the money of the charge is taken.
+	PaymentEventCaptured = "charge.captured"
+	// PaymentEventFailed is sent when the customer couldn't pay the charge.
+	PaymentEventFailed = "charge.failed"
+)
+
+// Payment is a charge of an order at a payment gateway, Amount is in the minor unit of Currency.
+type Payment struct {
+	ID         int64  `db:"id"`
+	OrderID    int64  `db:"order_id"`
+	Gateway    string `db:"gateway"`
+	ChargeID   string `db:"charge_id"`
+	Amount     int64  `db:"amount"`
+	Currency   string `db:"currency"`
+	Status     string `db:"status"`
+	PaymentUrl string `db:"payment_url"`
+	// RefundedAmount is the part of Amount given back to the customer
+	RefundedAmount int64     `db:"refunded_amount"`
+	CreatedAt      time.Time `db:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
+
+// PaymentEvent is a webhook event received from a payment gateway.
+type PaymentEvent struct {
+	ID        int64           `db:"id"`
+	Gateway   string          `db:"gateway"`
+	EventID   string          `db:"event_id"`
+	PaymentID int64           `db:"payment_id"`
+	Type      string          `db:"type"`
+	Payload   json.RawMessage `db:"payload"`
+	CreatedAt time.Time       `db:"created_at"`
+}
+
+// PaymentCharge is a charge created at a payment gateway, the customer pays it on PaymentUrl.
+type PaymentCharge struct {
+	ID         string
+	Status     string
+	PaymentUrl string
+}
+
+// PaymentWebhookEvent is the event carried by a verified webhook of a payment gateway.
+type PaymentWebhookEvent struct {
+	ID       string
+	Type     string
+	ChargeID string
+	Amount   int64
+}
@@ -1,20 +1,37 @@
 package entity
 
 import (
+	"ecommerce/model/money"
+	"ecommerce/model/weight"
 	"time"
 )
 
 type Product struct {
-	ID          int64     `db:"id"`
-	UserID      int64     `db:"user_id"`
-	Sku         string    `db:"sku"`
-	Title       string    `db:"title"`
-	Description string    `db:"description"`
-	Category    string    `db:"category"`
-	Etalase     string    `db:"etalase"`
-	Weight      float64   `db:"weight"`
-	Price       int64     `db:"price"`
-	Rating      float64   `db:"rating"`
-	CreatedAt   time.Time `db:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at"`
+	ID          int64        `db:"id"`
+	UserID      int64        `db:"user_id"`
+	Sku         string       `db:"sku"`
+	Title       string       `db:"title"`
+	Description string       `db:"description"`
+	Category    string       `db:"category"`
+	CategoryID  int64        `db:"category_id"`
+	Etalase     string       `db:"etalase"`
+	Weight      weight.Grams `db:"weight"`
+	// Length, Width and Height are the dimensions of the package in centimeters, 0 when they are not known
+	Length float64 `db:"length"`
+	Width  float64 `db:"width"`
+	Height float64 `db:"height"`
+	// Price is the regular price in effect in the currency column, else the price column when no regular price is in
+	// effect. The currency of a product can't be changed once it is created
+	Price  money.Money `db:"price"`
+	Rating float64     `db:"rating"`
+	Stock  int64       `db:"stock"`
+	// FavouritedCount is the number of wishlists the product is on
+	FavouritedCount int64     `db:"favourited_count"`
+	CreatedAt       time.Time `db:"created_at"`
+	UpdatedAt       time.Time `db:"updated_at"`
+	// PrimaryImageUrl is not a column of products, it is the url of the primary image of the product
+	PrimaryImageUrl string `db:"primary_image_url"`
+	// EffectivePrice is not a column of products, it is the price charged now: the running sale price, else the
+	// regular price in effect, else Price
+	EffectivePrice money.Money `db:"effective_price"`
 }
@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	AttributeTypeString  = "string"
+	AttributeTypeNumber  = "number"
+	AttributeTypeBoolean = "boolean"
+)
+
+// ProductAttribute is a typed key/value of a product, Value is stored in its canonical text form.
+type ProductAttribute struct {
+	ID        int64     `db:"id"`
+	ProductID int64     `db:"product_id"`
+	Name      string    `db:"name"`
+	Type      string    `db:"type"`
+	Value     string    `db:"value"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+type CategoryFacet struct {
+	CategoryID int64  `db:"category_id"`
+	Name       string `db:"name"`
+	Count      int64  `db:"count"`
+}
+
+type AttributeFacet struct {
+	Name  string `db:"name"`
+	Value string `db:"value"`
+	Count int64  `db:"count"`
+}
+
+type PriceFacet struct {
+	Bucket int   `db:"bucket"`
+	Count  int64 `db:"count"`
+}
@@ -0,0 +1,7 @@
+package entity
+
+// ProductExport is a product with the urls of its images, separated with a pipe in their position order.
+type ProductExport struct {
+	Product
+	ImageUrls string `db:"image_urls"`
+}
@@ -10,6 +10,8 @@ type ProductImage struct {
 	ProductID        int64          `db:"product_id"`
 	ImageUrl         string         `db:"image_url"`
 	ShortDescription sql.NullString `db:"short_description"`
+	Position         int            `db:"position"`
+	IsPrimary        bool           `db:"is_primary"`
 	CreatedAt        time.Time      `db:"created_at"`
 	UpdatedAt        time.Time      `db:"updated_at"`
 }
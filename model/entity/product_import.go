@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	ProductImportFormatCSV    = "csv"
+	ProductImportFormatNDJSON = "ndjson"
+
+	ProductImportStatusProcessing = "processing"
+	ProductImportStatusCompleted  = "completed"
+	ProductImportStatusFailed     = "failed"
+
+	ProductImportRowCreated = "created"
+	ProductImportRowUpdated = "updated"
+	ProductImportRowFailed  = "failed"
+)
+
+type ProductImport struct {
+	ID          int64     `db:"id"`
+	UserID      int64     `db:"user_id"`
+	Format      string    `db:"format"`
+	Status      string    `db:"status"`
+	TotalRows   int       `db:"total_rows"`
+	CreatedRows int       `db:"created_rows"`
+	UpdatedRows int       `db:"updated_rows"`
+	FailedRows  int       `db:"failed_rows"`
+	ReportKey   string    `db:"report_key"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// UpsertedProduct is the id of an upserted product and whether it was created or updated.
+type UpsertedProduct struct {
+	ID      int64 `db:"id"`
+	Created bool  `db:"created"`
+}
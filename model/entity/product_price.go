@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+)
+
+const (
+	// ProductPriceRegular prices follow each other, every regular price is in effect until the next one starts.
+	ProductPriceRegular = "regular"
+	// ProductPriceSale prices are in effect between their start and end and take precedence over the regular price.
+	ProductPriceSale = "sale"
+)
+
+type ProductPrice struct {
+	ID            int64        `db:"id"`
+	ProductID     int64        `db:"product_id"`
+	Kind          string       `db:"kind"`
+	Price         int64        `db:"price"`
+	EffectiveFrom time.Time    `db:"effective_from"`
+	EffectiveTo   sql.NullTime `db:"effective_to"`
+	CreatedAt     time.Time    `db:"created_at"`
+}
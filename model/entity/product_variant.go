@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+)
+
+type ProductOption struct {
+	ID        int64     `db:"id"`
+	ProductID int64     `db:"product_id"`
+	Name      string    `db:"name"`
+	Position  int       `db:"position"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+type ProductOptionValue struct {
+	ID        int64     `db:"id"`
+	OptionID  int64     `db:"option_id"`
+	Value     string    `db:"value"`
+	Position  int       `db:"position"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// ProductVariant is a sellable combination of option values, Price and Weight fall back to the product when null.
+type ProductVariant struct {
+	ID        int64           `db:"id"`
+	ProductID int64           `db:"product_id"`
+	Sku       string          `db:"sku"`
+	Price     sql.NullInt64   `db:"price"`
+	Weight    sql.NullFloat64 `db:"weight"`
+	Stock     int64           `db:"stock"`
+	CreatedAt time.Time       `db:"created_at"`
+	UpdatedAt time.Time       `db:"updated_at"`
+}
+
+type ProductVariantOptionValue struct {
+	VariantID  int64  `db:"variant_id"`
+	OptionName string `db:"option_name"`
+	Value      string `db:"value"`
+}
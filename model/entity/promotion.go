@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// PromotionPercentage takes Value percent off the eligible items, capped at MaxDiscount when it is set.
+	PromotionPercentage = "percentage"
+	// PromotionFixed takes Value off the eligible items, in the minor unit of Currency.
+	PromotionFixed = "fixed"
+)
+
+const (
+	PromotionScopeAll      = "all"
+	PromotionScopeSeller   = "seller"
+	PromotionScopeCategory = "category"
+	PromotionScopeProduct  = "product"
+)
+
+// Promotion is a discount campaign. It is applied automatically when Code is empty, otherwise only to carts the code
+// is entered for. Limits and MaxDiscount are not enforced when they are 0.
+type Promotion struct {
+	ID          int64  `db:"id"`
+	Code        string `db:"code"`
+	Name        string `db:"name"`
+	Type        string `db:"type"`
+	Value       int64  `db:"value"`
+	MaxDiscount int64  `db:"max_discount"`
+	// MinSpend is compared with the subtotal of the items the promotion applies to
+	MinSpend     int64  `db:"min_spend"`
+	Currency     string `db:"currency"`
+	UsageLimit   int64  `db:"usage_limit"`
+	PerUserLimit int64  `db:"per_user_limit"`
+	UsageCount   int64  `db:"usage_count"`
+	Scope        string `db:"scope"`
+	// ScopeIDs are the ids of the sellers, categories or products the promotion applies to, a category includes its
+	// sub categories
+	ScopeIDs  pq.Int64Array `db:"scope_ids"`
+	StartsAt  time.Time     `db:"starts_at"`
+	EndsAt    sql.NullTime  `db:"ends_at"`
+	IsActive  bool          `db:"is_active"`
+	CreatedAt time.Time     `db:"created_at"`
+	UpdatedAt time.Time     `db:"updated_at"`
+}
+
+type PromotionUsage struct {
+	ID          int64     `db:"id"`
+	PromotionID int64     `db:"promotion_id"`
+	UserID      int64     `db:"user_id"`
+	OrderID     int64     `db:"order_id"`
+	Discount    int64     `db:"discount"`
+	CreatedAt   time.Time `db:"created_at"`
+}
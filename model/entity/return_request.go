@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// ReturnStatusRequested returns wait for the seller to approve or reject them.
+	ReturnStatusRequested = "requested"
+	// ReturnStatusApproved returns wait for the goods to be received by the seller.
+	ReturnStatusApproved = "approved"
+	ReturnStatusRejected = "rejected"
+	// ReturnStatusRefunded returns were received, restocked and refunded.
+	ReturnStatusRefunded = "refunded"
+)
+
+const (
+	// RefundStatusPending refunds are recorded and still have to be given back by the payment gateway.
+	RefundStatusPending   = "pending"
+	RefundStatusSucceeded = "succeeded"
+)
+
+// ReturnRequest is the return of Quantity units of an order line, RefundAmount is set when the seller approves it.
+type ReturnRequest struct {
+	ID          int64          `db:"id"`
+	OrderID     int64          `db:"order_id"`
+	OrderItemID int64          `db:"order_item_id"`
+	ProductID   int64          `db:"product_id"`
+	VariantID   int64          `db:"variant_id"`
+	SellerID    int64          `db:"seller_id"`
+	UserID      int64          `db:"user_id"`
+	Quantity    int64          `db:"quantity"`
+	Reason      string         `db:"reason"`
+	PhotoUrls   pq.StringArray `db:"photo_urls"`
+	Status      string         `db:"status"`
+	SellerNote  string         `db:"seller_note"`
+	// RefundAmount is in the minor unit of the currency of the order
+	RefundAmount int64     `db:"refund_amount"`
+	CreatedAt    time.Time `db:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at"`
+}
+
+// Refund is money of a payment given back for a return, or for the whole order when ReturnRequestID is 0. It is
+// pending until the payment gateway gave the money back.
+type Refund struct {
+	ID              int64     `db:"id"`
+	OrderID         int64     `db:"order_id"`
+	PaymentID       int64     `db:"payment_id"`
+	ReturnRequestID int64     `db:"return_request_id"`
+	Amount          int64     `db:"amount"`
+	Status          string    `db:"status"`
+	CreatedAt       time.Time `db:"created_at"`
+	UpdatedAt       time.Time `db:"updated_at"`
+}
@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+)
+
+type Seller struct {
+	UserID      int64     `db:"user_id"`
+	Name        string    `db:"name"`
+	Description string    `db:"description"`
+	City        string    `db:"city"`
+	AvatarUrl   string    `db:"avatar_url"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// SellerProductStats is aggregated from the products of a seller.
+type SellerProductStats struct {
+	ProductCount  int64   `db:"product_count"`
+	AverageRating float64 `db:"average_rating"`
+}
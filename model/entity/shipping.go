@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"ecommerce/model/weight"
+	"time"
+)
+
+// ShippingZone maps the postal codes starting with PostalCodePrefix to a zone.
+type ShippingZone struct {
+	ID               int64     `db:"id"`
+	Zone             string    `db:"zone"`
+	PostalCodePrefix string    `db:"postal_code_prefix"`
+	CreatedAt        time.Time `db:"created_at"`
+}
+
+// ShippingRate is the price of a courier service for the packages sent to Zone weighing more than MinWeight up to
+// MaxWeight, a MaxWeight of 0 has no maximum.
+type ShippingRate struct {
+	ID            int64        `db:"id"`
+	Courier       string       `db:"courier"`
+	Service       string       `db:"service"`
+	Zone          string       `db:"zone"`
+	MinWeight     weight.Grams `db:"min_weight"`
+	MaxWeight     weight.Grams `db:"max_weight"`
+	Price         int64        `db:"price"`
+	Currency      string       `db:"currency"`
+	EstimatedDays int          `db:"estimated_days"`
+	CreatedAt     time.Time    `db:"created_at"`
+	UpdatedAt     time.Time    `db:"updated_at"`
+}
@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// WebhookDeliveryStatusPending deliveries are attempted again at NextAttemptAt.
+	WebhookDeliveryStatusPending   = "pending"
+	WebhookDeliveryStatusSucceeded = "succeeded"
+	// WebhookDeliveryStatusFailed deliveries ran out of attempts, they are only sent again when redelivered by hand.
+	WebhookDeliveryStatusFailed = "failed"
+)
+
+// WebhookSubscription posts the events of EventTypes concerning a seller to Url, signed with Secret.
+type WebhookSubscription struct {
+	ID         int64          `db:"id"`
+	SellerID   int64          `db:"seller_id"`
+	Url        string         `db:"url"`
+	Secret     string         `db:"secret"`
+	EventTypes pq.StringArray `db:"event_types"`
+	Active     bool           `db:"active"`
+	CreatedAt  time.Time      `db:"created_at"`
+	UpdatedAt  time.Time      `db:"updated_at"`
+}
+
+// WebhookDelivery is the delivery of an outbox event to a subscription. ResponseCode is the status code of the last
+// attempt, 0 when it got no response.
+type WebhookDelivery struct {
+	ID             int64        `db:"id"`
+	SubscriptionID int64        `db:"subscription_id"`
+	EventID        int64        `db:"event_id"`
+	Status         string       `db:"status"`
+	Attempts       int          `db:"attempts"`
+	ResponseCode   int          `db:"response_code"`
+	LastError      string       `db:"last_error"`
+	NextAttemptAt  time.Time    `db:"next_attempt_at"`
+	DeliveredAt    sql.NullTime `db:"delivered_at"`
+	CreatedAt      time.Time    `db:"created_at"`
+	UpdatedAt      time.Time    `db:"updated_at"`
+}
+
+// WebhookDeliveryDetail is a delivery along with the subscription and the event it delivers.
+type WebhookDeliveryDetail struct {
+	WebhookDelivery
+	Url            string          `db:"url"`
+	Secret         string          `db:"secret"`
+	EventType      string          `db:"event_type"`
+	Payload        json.RawMessage `db:"payload"`
+	EventCreatedAt time.Time       `db:"event_created_at"`
+}
+
+// WebhookDeliveryAttempt is one post of a delivery, Manual is set for the attempts of a redelivery by hand.
+type WebhookDeliveryAttempt struct {
+	ID           int64     `db:"id"`
+	DeliveryID   int64     `db:"delivery_id"`
+	ResponseCode int       `db:"response_code"`
+	Error        string    `db:"error"`
+	DurationMs   int64     `db:"duration_ms"`
+	Manual       bool      `db:"manual"`
+	CreatedAt    time.Time `db:"created_at"`
+}
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+)
+
+type WishlistItem struct {
+	ID        int64     `db:"id"`
+	UserID    int64     `db:"user_id"`
+	ProductID int64     `db:"product_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// WishlistItemDetail is a wishlist item joined with the current state of its product.
+type WishlistItemDetail struct {
+	WishlistItem
+	Sku             string  `db:"sku"`
+	Title           string  `db:"title"`
+	CurrentPrice    int64   `db:"current_price"`
+	Currency        string  `db:"currency"`
+	Rating          float64 `db:"rating"`
+	Stock           int64   `db:"stock"`
+	FavouritedCount int64   `db:"favourited_count"`
+	PrimaryImageUrl string  `db:"primary_image_url"`
+	SellerID        int64   `db:"seller_id"`
+}
@@ -0,0 +1,158 @@
+// Package money holds amounts of money in the minor unit of their currency, so they are never rounded by floating
+// point arithmetic and never added up across currencies by mistake.
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnsupportedCurrency = errors.New("currency is not supported")
+	ErrMissingRate         = errors.New("exchange rate is missing")
+)
+
+// minorUnits is the number of decimals of the minor unit of the supported ISO 4217 currencies. The rupiah is kept
+// without decimals since its sen are not used anymore.
+var minorUnits = map[string]int{
+	"AUD": 2,
+	"CNY": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"IDR": 0,
+	"JPY": 0,
+	"KRW": 0,
+	"MYR": 2,
+	"PHP": 2,
+	"SGD": 2,
+	"THB": 2,
+	"USD": 2,
+	"VND": 0,
+}
+
+// Money is an amount of money in the minor unit of its currency, e.g. {Amount: 1050, Currency: "USD"} is 10.50 USD.
+// Its columns are selected as "<column>.amount" and "<column>.currency" for a Money field tagged with the column.
+type Money struct {
+	Amount int64 `json:"amount" db:"amount"`
+	// Currency is the ISO 4217 code of the currency
+	Currency string `json:"currency" db:"currency"`
+}
+
+// New returns amount minor units of currency.
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// IsSupported reports whether currency is a supported ISO 4217 code.
+func IsSupported(currency string) bool {
+	_, ok := minorUnits[currency]
+	return ok
+}
+
+// UnmarshalJSON takes a bare number as an amount without a currency, the way prices were sent before they carried
+// their currency.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		var amount int64
+		err := json.Unmarshal(data, &amount)
+		if err != nil {
+			return err
+		}
+
+		*m = Money{Amount: amount}
+		return nil
+	}
+
+	// money has the fields of Money without its methods, so decoding it doesn't call UnmarshalJSON again
+	type money Money
+	var v money
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return err
+	}
+
+	*m = Money(v)
+	return nil
+}
+
+// String formats the amount in the major unit followed by the currency, e.g. 10.50 USD.
+func (m Money) String() string {
+	digits := minorUnits[m.Currency]
+	amount := strconv.FormatInt(abs(m.Amount), 10)
+	if digits > 0 {
+		if len(amount) <= digits {
+			amount = strings.Repeat("0", digits-len(amount)+1) + amount
+		}
+		amount = amount[:len(amount)-digits] + "." + amount[len(amount)-digits:]
+	}
+
+	if m.Amount < 0 {
+		amount = "-" + amount
+	}
+
+	return amount + " " + m.Currency
+}
+
+// Rates is an exchange rate table holding the value of one major unit of each currency in a common base currency.
+type Rates map[string]float64
+
+// Convert converts m to currency, the converted amount is rounded half away from zero to the minor unit of currency.
+func (r Rates) Convert(m Money, currency string) (Money, error) {
+	if !IsSupported(m.Currency) {
+		return Money{}, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, m.Currency)
+	}
+	if !IsSupported(currency) {
+		return Money{}, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, currency)
+	}
+	if m.Currency == currency {
+		return m, nil
+	}
+
+	from, to := r[m.Currency], r[currency]
+	if from <= 0 {
+		return Money{}, fmt.Errorf("%w: %s", ErrMissingRate, m.Currency)
+	}
+	if to <= 0 {
+		return Money{}, fmt.Errorf("%w: %s", ErrMissingRate, currency)
+	}
+
+	// amount / 10^from digits * from rate / to rate * 10^to digits
+	amount := new(big.Rat).SetInt64(m.Amount)
+	amount.Mul(amount, new(big.Rat).SetFloat64(from))
+	amount.Quo(amount, new(big.Rat).SetFloat64(to))
+	amount.Mul(amount, new(big.Rat).SetFrac(pow10(minorUnits[currency]), pow10(minorUnits[m.Currency])))
+
+	return Money{Amount: round(amount), Currency: currency}, nil
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// round rounds x half away from zero.
+func round(x *big.Rat) int64 {
+	num := new(big.Int).Abs(x.Num())
+	quo, rem := new(big.Int).QuoRem(num, x.Denom(), new(big.Int))
+	if rem.Mul(rem, big.NewInt(2)).Cmp(x.Denom()) >= 0 {
+		quo.Add(quo, big.NewInt(1))
+	}
+
+	if x.Sign() < 0 {
+		quo.Neg(quo)
+	}
+
+	return quo.Int64()
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
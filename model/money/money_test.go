@@ -0,0 +1,100 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRatesConvert(t *testing.T) {
+	rates := Rates{"IDR": 1, "USD": 20000, "JPY": 100, "EUR": 21500.5}
+
+	tests := []struct {
+		name     string
+		money    Money
+		currency string
+		want     Money
+		wantErr  error
+	}{
+		{
+			name:     "same currency",
+			money:    New(1234, "USD"),
+			currency: "USD",
+			want:     New(1234, "USD"),
+		},
+		{
+			name:     "to a currency without minor unit",
+			money:    New(1050, "USD"),
+			currency: "IDR",
+			want:     New(210000, "IDR"),
+		},
+		{
+			name:     "to a currency with cents",
+			money:    New(210000, "IDR"),
+			currency: "USD",
+			want:     New(1050, "USD"),
+		},
+		{
+			name:     "half a cent is rounded up",
+			money:    New(100, "IDR"),
+			currency: "USD",
+			want:     New(1, "USD"),
+		},
+		{
+			name:     "a cent and a half is rounded up",
+			money:    New(300, "IDR"),
+			currency: "USD",
+			want:     New(2, "USD"),
+		},
+		{
+			name:     "less than half a cent is rounded down",
+			money:    New(99, "IDR"),
+			currency: "USD",
+			want:     New(0, "USD"),
+		},
+		{
+			name:     "half a negative cent is rounded away from zero",
+			money:    New(-100, "IDR"),
+			currency: "USD",
+			want:     New(-1, "USD"),
+		},
+		{
+			name:     "fractional rate",
+			money:    New(100, "EUR"),
+			currency: "JPY",
+			want:     New(215, "JPY"),
+		},
+		{
+			name:     "exact amount at a fractional rate",
+			money:    New(4300100, "IDR"),
+			currency: "EUR",
+			want:     New(20000, "EUR"),
+		},
+		{
+			name:     "unsupported currency",
+			money:    New(100, "XXX"),
+			currency: "USD",
+			wantErr:  ErrUnsupportedCurrency,
+		},
+		{
+			name:     "currency without a rate",
+			money:    New(100, "USD"),
+			currency: "SGD",
+			wantErr:  ErrMissingRate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converted, err := rates.Convert(tt.money, tt.currency)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, converted)
+		})
+	}
+}
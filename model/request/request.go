@@ -1,18 +1,108 @@
 package request
 
+import (
+	"ecommerce/model/money"
+	"ecommerce/model/weight"
+	"time"
+)
+
 type UpsertProduct struct {
-	UserID        int64   `json:"user_id"`
-	Sku           string  `json:"sku"`
-	Title         string  `json:"title"`
-	Description   string  `json:"description"`
-	Category      string  `json:"category"`
-	Etalase       string  `json:"etalase"`
-	Weight        float64 `json:"weight"`
-	Price         int64   `json:"price"`
-	ProductImages []struct {
-		ImageUrl         string `json:"image_url"`
-		ShortDescription string `json:"short_description"`
-	} `json:"product_images"`
+	UserID      int64        `json:"user_id"`
+	Sku         string       `json:"sku"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Category    string       `json:"category"`
+	CategoryID  int64        `json:"category_id"`
+	Etalase     string       `json:"etalase"`
+	Weight      weight.Grams `json:"weight"`
+	// Length, Width and Height are the dimensions of the package in centimeters, they are used for its volumetric weight
+	Length float64 `json:"length"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+	// Price takes the default currency of the store when its currency is empty
+	Price         money.Money          `json:"price"`
+	Stock         int64                `json:"stock"`
+	ProductImages []UpsertProductImage `json:"product_images"`
+	// Options and Variants replace those of the product when either is sent, both are left as is when neither is
+	Options    []UpsertProductOption    `json:"options"`
+	Variants   []UpsertProductVariant   `json:"variants"`
+	Attributes []UpsertProductAttribute `json:"attributes"`
+}
+
+type UpsertProductImage struct {
+	ImageUrl         string `json:"image_url"`
+	ShortDescription string `json:"short_description"`
+	// IsPrimary marks the image shown in the product list, the first image is used when no image is marked
+	IsPrimary bool `json:"is_primary"`
+}
+
+// ImportProducts describes an import file, Format is csv or ndjson and is taken from the extension of FileName when
+// it is empty.
+type ImportProducts struct {
+	UserID   int64
+	Format   string
+	FileName string
+}
+
+// ImportProductRow is a row of an import file. Sku and title are required, the other fields are pointers so a field
+// the row leaves out is told apart from a zero value: it is left as is on an existing product. Price is required to
+// create a product, and ImageUrls replaces the images of the product when it is not empty.
+type ImportProductRow struct {
+	Sku         string        `json:"sku"`
+	Title       string        `json:"title"`
+	Description *string       `json:"description"`
+	Category    *string       `json:"category"`
+	CategoryID  *int64        `json:"category_id"`
+	Etalase     *string       `json:"etalase"`
+	Weight      *weight.Grams `json:"weight"`
+	Length      *float64      `json:"length"`
+	Width       *float64      `json:"width"`
+	Height      *float64      `json:"height"`
+	// Price takes the default currency of the store when a product is created without one, a bare number is taken as
+	// its amount
+	Price     *money.Money `json:"price"`
+	Stock     *int64       `json:"stock"`
+	ImageUrls []string     `json:"image_urls"`
+}
+
+// ReorderProductImages lists the ids of every image of the product in their new order.
+type ReorderProductImages struct {
+	ImageIDs []int64 `json:"image_ids"`
+}
+
+// ScheduleProductPrice is a regular price starting at EffectiveFrom, or a sale price between EffectiveFrom and
+// EffectiveTo. EffectiveFrom defaults to now.
+type ScheduleProductPrice struct {
+	Kind string `json:"kind"`
+	// Price should be in the currency of the product, it is taken when the currency is empty
+	Price         money.Money `json:"price"`
+	EffectiveFrom time.Time   `json:"effective_from"`
+	// EffectiveTo is required for a sale price, a regular price is in effect until the next one starts
+	EffectiveTo time.Time `json:"effective_to"`
+}
+
+// UpsertProductAttribute is a typed key/value, Type is one of string, number or boolean.
+type UpsertProductAttribute struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type UpsertProductOption struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+// UpsertProductVariant is a variant keyed by its sku, Options maps every option name to one of its values.
+// Price and Weight fall back to the product when they are not set.
+type UpsertProductVariant struct {
+	Sku string `json:"sku"`
+	// Price is in the minor unit of the currency of the product
+	Price  *int64        `json:"price"`
+	Weight *weight.Grams `json:"weight"`
+	// Stock is the initial stock of a new variant, the stock of an existing one is changed by adjusting it
+	Stock   int64             `json:"stock"`
+	Options map[string]string `json:"options"`
 }
 
 type UpsertProductReview struct {
@@ -22,7 +112,153 @@ type UpsertProductReview struct {
 }
 
 type FilterProduct struct {
-	Search string `json:"search"`
-	Sort   string `json:"sort"`
-	IsAsc  bool   `json:"is_asc"`
+	Search string `json:"search" query:"search"`
+	// Sort is a column of products, or popularity to sort by the number of wishlists a product is on
+	Sort  string `json:"sort" query:"sort"`
+	IsAsc bool   `json:"is_asc" query:"is_asc"`
+	// CategoryID filters on the category and all of its descendants
+	CategoryID int64 `json:"category_id" query:"category_id"`
+	UserID     int64 `json:"user_id" query:"user_id"`
+	// MinPrice and MaxPrice filter on the effective price in the minor unit of PriceCurrency, they only match the
+	// products priced in it
+	MinPrice int64 `json:"min_price" query:"min_price"`
+	MaxPrice int64 `json:"max_price" query:"max_price"`
+	// Attributes filters on products having every attribute name with its value
+	Attributes map[string]string `json:"attributes" query:"-"`
+	// AttributeValues holds the value of every attribute filter in the canonical form of each attribute type it is
+	// valid for, keyed by name then by type
+	AttributeValues map[string]map[string]string `json:"-" query:"-"`
+	// IncludeFacets returns the category, attribute and price counts of the filtered products
+	IncludeFacets bool `json:"include_facets" query:"include_facets"`
+	// Currency adds the price of every product converted to this currency as its display price
+	Currency string `json:"currency" query:"currency"`
+	// PriceCurrency is the currency of the price filters, the price facets and the price sorts: Currency, else the
+	// default currency of the store
+	PriceCurrency string `json:"-" query:"-"`
+}
+
+// UpsertCartItem adds a product to the cart, VariantID is required for products with variants.
+type UpsertCartItem struct {
+	ProductID int64 `json:"product_id"`
+	VariantID int64 `json:"variant_id"`
+	Quantity  int64 `json:"quantity"`
+}
+
+type RemoveCartItem struct {
+	VariantID int64 `query:"variant_id"`
+}
+
+type AddWishlistItem struct {
+	ProductID int64 `json:"product_id"`
+}
+
+type UpsertWebhookSubscription struct {
+	Url string `json:"url"`
+	// Secret keys the signature of the deliveries, a secret is generated on create when it is empty and the current
+	// one is kept on update
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"event_types"`
+	// Active defaults to true on create and is kept on update when it is not set
+	Active *bool `json:"active"`
+}
+
+// AdjustStock changes the stock of a product, or of one of its variants when VariantID is set.
+type AdjustStock struct {
+	VariantID int64  `json:"variant_id"`
+	Quantity  int64  `json:"quantity"`
+	Note      string `json:"note"`
+}
+
+type CreateOrder struct {
+	UserID int64 `json:"user_id"`
+	// PromotionCodes are the vouchers applied to the order on top of the automatic promotions
+	PromotionCodes []string `json:"promotion_codes"`
+}
+
+// GetOrderInvoice picks the invoice of SellerID, which can be left out when the order has a single seller.
+type GetOrderInvoice struct {
+	SellerID int64 `query:"seller_id"`
+	// Format is pdf or html, pdf by default
+	Format string `query:"format"`
+}
+
+type UpdateOrderStatus struct {
+	Status string `json:"status"`
+}
+
+type UpsertCategory struct {
+	ParentID int64  `json:"parent_id"`
+	Name     string `json:"name"`
+	Slug     string `json:"slug"`
+	Position int    `json:"position"`
+}
+
+type UpsertEtalase struct {
+	Name     string `json:"name"`
+	Position int    `json:"position"`
+}
+
+type AssignEtalaseProducts struct {
+	ProductIDs []int64 `json:"product_ids"`
+}
+
+type Pagination struct {
+	Page    int64 `query:"page"`
+	PerPage int64 `query:"per_page"`
+}
+
+type UpsertSeller struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	City        string `json:"city"`
+	AvatarUrl   string `json:"avatar_url"`
+}
+
+// UpsertPromotion is a promotion campaign, it is applied automatically when Code is empty. Value is a percentage for
+// a percentage promotion and an amount in the minor unit of Currency for a fixed one. Limits and MaxDiscount are not
+// enforced when they are 0.
+type UpsertPromotion struct {
+	Code         string `json:"code"`
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	Value        int64  `json:"value"`
+	MaxDiscount  int64  `json:"max_discount"`
+	MinSpend     int64  `json:"min_spend"`
+	Currency     string `json:"currency"`
+	UsageLimit   int64  `json:"usage_limit"`
+	PerUserLimit int64  `json:"per_user_limit"`
+	// Scope is all, seller, category or product, ScopeIDs lists the ids the promotion is limited to
+	Scope    string  `json:"scope"`
+	ScopeIDs []int64 `json:"scope_ids"`
+	// StartsAt defaults to now, the promotion runs without an end when EndsAt is not set
+	StartsAt time.Time  `json:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at"`
+	// IsActive defaults to true
+	IsActive *bool `json:"is_active"`
+}
+
+type ApplyPromotions struct {
+	Codes []string `json:"codes"`
+}
+
+// ShippingQuote asks the shipping options of the cart of UserID to PostalCode.
+type ShippingQuote struct {
+	UserID     int64  `json:"user_id"`
+	PostalCode string `json:"postal_code"`
+}
+
+// CreateReturnRequest asks to return Quantity units of the order line OrderItemID of an order of UserID.
+type CreateReturnRequest struct {
+	UserID      int64    `json:"user_id"`
+	OrderItemID int64    `json:"order_item_id"`
+	Quantity    int64    `json:"quantity"`
+	Reason      string   `json:"reason"`
+	PhotoUrls   []string `json:"photo_urls"`
+}
+
+type ReviewReturnRequest struct {
+	// Note is shown to the customer, it is required to reject a return
+	Note string `json:"note"`
+	// RefundAmount defaults to what was paid for the returned units when a return is approved
+	RefundAmount *int64 `json:"refund_amount"`
 }
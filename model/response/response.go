@@ -1,20 +1,129 @@
 package response
 
-import "ecommerce/model/entity"
+import (
+	"ecommerce/model/entity"
+	"ecommerce/model/money"
+	"ecommerce/model/weight"
+	sdkSql "ecommerce/utils/sql"
+	"io"
+	"time"
+)
 
 type BaseResponse struct {
 	StatusCode int    `json:"status_code"`
 	Message    string `json:"message"`
 }
 
+// Product is a product with its prices in its currency. DisplayPrice is the effective price converted to the
+// currency requested by the client, it is only set when a currency is requested.
+type Product struct {
+	entity.Product
+	DisplayPrice *money.Money `json:"display_price,omitempty"`
+}
+
 type ProductDetail struct {
-	Product       entity.Product         `json:"product"`
-	ProductImages []entity.ProductImage  `json:"product_images"`
-	Review        []entity.ProductReview `json:"review"`
+	Product       Product                   `json:"product"`
+	ProductImages []ProductImage            `json:"product_images"`
+	Review        []entity.ProductReview    `json:"review"`
+	Options       []ProductOption           `json:"options"`
+	Variants      []ProductVariant          `json:"variants"`
+	Attributes    []entity.ProductAttribute `json:"attributes"`
+}
+
+type ProductOption struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+type ProductVariant struct {
+	ID      int64             `json:"id"`
+	Sku     string            `json:"sku"`
+	Price   money.Money       `json:"price"`
+	Weight  weight.Grams      `json:"weight"`
+	Stock   int64             `json:"stock"`
+	Options map[string]string `json:"options"`
 }
 
 type GetProductListResponse struct {
-	Data []entity.Product `json:"data"`
+	Data   []Product      `json:"data"`
+	Facets *ProductFacets `json:"facets,omitempty"`
+	BaseResponse
+}
+
+type ProductFacets struct {
+	Categories []CategoryFacet  `json:"categories"`
+	Attributes []AttributeFacet `json:"attributes"`
+	Prices     []PriceFacet     `json:"prices"`
+	// PriceCurrency is the currency of the bounds of Prices, only the products priced in it are counted in Prices
+	PriceCurrency string `json:"price_currency"`
+}
+
+type CategoryFacet struct {
+	CategoryID int64  `json:"category_id"`
+	Name       string `json:"name"`
+	Count      int64  `json:"count"`
+}
+
+type AttributeFacet struct {
+	Name   string                `json:"name"`
+	Values []AttributeValueFacet `json:"values"`
+}
+
+type AttributeValueFacet struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// PriceFacet counts the products priced from Min up to but excluding Max, Max is 0 for the last bucket.
+type PriceFacet struct {
+	Min   int64 `json:"min"`
+	Max   int64 `json:"max"`
+	Count int64 `json:"count"`
+}
+
+// ProductImage is an image of a product with its resized variants, variants are empty until they are generated.
+type ProductImage struct {
+	entity.ProductImage
+	Variants []ImageVariant `json:"variants"`
+}
+
+type ImageVariant struct {
+	// Name is the size of the variant: thumb, medium or large
+	Name   string `json:"name"`
+	Format string `json:"format"`
+	Url    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+type GetProductImportResponse struct {
+	Data entity.ProductImport `json:"data"`
+	BaseResponse
+}
+
+// ProductExport is a product export ready to be sent, Write streams the exported products to w.
+type ProductExport struct {
+	ContentType string
+	FileName    string
+	Write       func(w io.Writer) error
+}
+
+// InvoiceDocument is a rendered invoice, the caller has to close Body.
+type InvoiceDocument struct {
+	ContentType string
+	FileName    string
+	Body        io.ReadCloser
+}
+
+type UploadedImage struct {
+	// ImageUrl can be sent as the image_url of the product images when upserting a product
+	ImageUrl    string `json:"image_url"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+}
+
+type UploadImageResponse struct {
+	Data UploadedImage `json:"data"`
 	BaseResponse
 }
 
@@ -22,3 +131,330 @@ type GetProductDetailResponse struct {
 	Data ProductDetail `json:"data"`
 	BaseResponse
 }
+
+type CartSummary struct {
+	TotalQuantity int64 `json:"total_quantity"`
+	// Subtotal is in the minor unit of Currency, the currency of every product of the cart
+	Subtotal    int64        `json:"subtotal"`
+	Currency    string       `json:"currency"`
+	TotalWeight weight.Grams `json:"total_weight"`
+}
+
+type CartDetail struct {
+	Cart    entity.Cart             `json:"cart"`
+	Items   []entity.CartItemDetail `json:"items"`
+	Summary CartSummary             `json:"summary"`
+}
+
+type GetCartResponse struct {
+	Data CartDetail `json:"data"`
+	BaseResponse
+}
+
+type GetWishlistResponse struct {
+	Data       []entity.WishlistItemDetail  `json:"data"`
+	Pagination sdkSql.PaginationMetaMessage `json:"pagination"`
+	BaseResponse
+}
+
+type GetInventoryLedgerResponse struct {
+	Data []entity.InventoryLedger `json:"data"`
+	BaseResponse
+}
+
+type OrderDetail struct {
+	Order         entity.Order                `json:"order"`
+	Items         []entity.OrderItem          `json:"items"`
+	StatusHistory []entity.OrderStatusHistory `json:"status_history"`
+}
+
+type GetOrderDetailResponse struct {
+	Data OrderDetail `json:"data"`
+	BaseResponse
+}
+
+type Category struct {
+	ID       int64      `json:"id"`
+	ParentID int64      `json:"parent_id"`
+	Name     string     `json:"name"`
+	Slug     string     `json:"slug"`
+	Position int        `json:"position"`
+	Children []Category `json:"children"`
+}
+
+type GetCategoryListResponse struct {
+	Data []Category `json:"data"`
+	BaseResponse
+}
+
+type GetCategoryDetailResponse struct {
+	Data Category `json:"data"`
+	BaseResponse
+}
+
+type GetEtalaseListResponse struct {
+	Data []entity.Etalase `json:"data"`
+	BaseResponse
+}
+
+type GetEtalaseProductListResponse struct {
+	Data       []Product                    `json:"data"`
+	Pagination sdkSql.PaginationMetaMessage `json:"pagination"`
+	BaseResponse
+}
+
+type ProductPrice struct {
+	ID            int64       `json:"id"`
+	Kind          string      `json:"kind"`
+	Price         money.Money `json:"price"`
+	EffectiveFrom time.Time   `json:"effective_from"`
+	EffectiveTo   *time.Time  `json:"effective_to"`
+	// Status is expired, active or scheduled, an active regular price is not charged while a sale is active
+	Status string `json:"status"`
+}
+
+type GetProductPricesResponse struct {
+	Data []ProductPrice `json:"data"`
+	BaseResponse
+}
+
+type GetProductHistoryResponse struct {
+	Data       []entity.AuditLog            `json:"data"`
+	Pagination sdkSql.PaginationMetaMessage `json:"pagination"`
+	BaseResponse
+}
+
+type SellerStorefront struct {
+	Seller        entity.Seller `json:"seller"`
+	ProductCount  int64         `json:"product_count"`
+	AverageRating float64       `json:"average_rating"`
+	Products      []Product     `json:"products"`
+}
+
+type GetSellerStorefrontResponse struct {
+	Data SellerStorefront `json:"data"`
+	BaseResponse
+}
+
+type Promotion struct {
+	ID           int64      `json:"id"`
+	Code         string     `json:"code"`
+	Name         string     `json:"name"`
+	Type         string     `json:"type"`
+	Value        int64      `json:"value"`
+	MaxDiscount  int64      `json:"max_discount"`
+	MinSpend     int64      `json:"min_spend"`
+	Currency     string     `json:"currency"`
+	UsageLimit   int64      `json:"usage_limit"`
+	PerUserLimit int64      `json:"per_user_limit"`
+	UsageCount   int64      `json:"usage_count"`
+	Scope        string     `json:"scope"`
+	ScopeIDs     []int64    `json:"scope_ids"`
+	StartsAt     time.Time  `json:"starts_at"`
+	EndsAt       *time.Time `json:"ends_at"`
+	IsActive     bool       `json:"is_active"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+type GetPromotionListResponse struct {
+	Data       []Promotion                  `json:"data"`
+	Pagination sdkSql.PaginationMetaMessage `json:"pagination"`
+	BaseResponse
+}
+
+type GetPromotionDetailResponse struct {
+	Data Promotion `json:"data"`
+	BaseResponse
+}
+
+type ItemDiscount struct {
+	ProductID int64 `json:"product_id"`
+	VariantID int64 `json:"variant_id"`
+	Discount  int64 `json:"discount"`
+}
+
+// PromotionDiscount is the discount of a promotion applied to the cart, split over the items it applies to.
+type PromotionDiscount struct {
+	PromotionID int64          `json:"promotion_id"`
+	Code        string         `json:"code"`
+	Name        string         `json:"name"`
+	Discount    int64          `json:"discount"`
+	Items       []ItemDiscount `json:"items"`
+}
+
+type RejectedPromotion struct {
+	Code   string `json:"code"`
+	Reason string `json:"reason"`
+}
+
+// CartPromotions is the discount breakdown of a cart, amounts are in the minor unit of Currency.
+type CartPromotions struct {
+	Currency      string              `json:"currency"`
+	Subtotal      int64               `json:"subtotal"`
+	TotalDiscount int64               `json:"total_discount"`
+	Total         int64               `json:"total"`
+	Discounts     []PromotionDiscount `json:"discounts"`
+	// Rejected lists the codes which can't be applied to the cart with the reason why
+	Rejected []RejectedPromotion `json:"rejected"`
+}
+
+type GetCartPromotionsResponse struct {
+	Data CartPromotions `json:"data"`
+	BaseResponse
+}
+
+// ShippingPackage is the package of the items of a seller. It is charged by the heavier of its
+// actual and volumetric weight.
+type ShippingPackage struct {
+	SellerID         int64        `json:"seller_id"`
+	ActualWeight     weight.Grams `json:"actual_weight"`
+	VolumetricWeight weight.Grams `json:"volumetric_weight"`
+	ChargeableWeight weight.Grams `json:"chargeable_weight"`
+}
+
+// ShippingOption is a courier service able to ship every package of the cart, Price is the sum of the packages in
+// the minor unit of the currency of the quote.
+type ShippingOption struct {
+	Courier       string `json:"courier"`
+	Service       string `json:"service"`
+	Price         int64  `json:"price"`
+	EstimatedDays int    `json:"estimated_days"`
+}
+
+type ShippingQuote struct {
+	Zone     string            `json:"zone"`
+	Currency string            `json:"currency"`
+	Packages []ShippingPackage `json:"packages"`
+	// Options are sorted from the cheapest
+	Options []ShippingOption `json:"options"`
+}
+
+type GetShippingQuoteResponse struct {
+	Data ShippingQuote `json:"data"`
+	BaseResponse
+}
+
+type Payment struct {
+	ID       int64  `json:"id"`
+	OrderID  int64  `json:"order_id"`
+	Gateway  string `json:"gateway"`
+	ChargeID string `json:"charge_id"`
+	// Amount is the total price of the order when the charge was created
+	Amount money.Money `json:"amount"`
+	// Status is created until the charge exists at the gateway, then pending, authorized, captured, failed,
+	// partially_refunded or refunded
+	Status string `json:"status"`
+	// RefundedAmount is the part of Amount given back, refunds the gateway is still asked for included
+	RefundedAmount money.Money `json:"refunded_amount"`
+	// PaymentUrl is where the customer pays a pending charge
+	PaymentUrl string    `json:"payment_url"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type GetPaymentResponse struct {
+	Data Payment `json:"data"`
+	BaseResponse
+}
+
+type GetPaymentListResponse struct {
+	Data []Payment `json:"data"`
+	BaseResponse
+}
+
+type ReturnRequest struct {
+	ID          int64    `json:"id"`
+	OrderID     int64    `json:"order_id"`
+	OrderItemID int64    `json:"order_item_id"`
+	ProductID   int64    `json:"product_id"`
+	VariantID   int64    `json:"variant_id"`
+	SellerID    int64    `json:"seller_id"`
+	UserID      int64    `json:"user_id"`
+	Quantity    int64    `json:"quantity"`
+	Reason      string   `json:"reason"`
+	PhotoUrls   []string `json:"photo_urls"`
+	// Status is requested, approved, rejected or refunded
+	Status     string `json:"status"`
+	SellerNote string `json:"seller_note"`
+	// RefundAmount is given back when the seller receives the goods of an approved return
+	RefundAmount money.Money `json:"refund_amount"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+}
+
+type GetReturnRequestResponse struct {
+	Data ReturnRequest `json:"data"`
+	BaseResponse
+}
+
+type GetReturnRequestListResponse struct {
+	Data []ReturnRequest `json:"data"`
+	BaseResponse
+}
+
+type GetSellerReturnRequestListResponse struct {
+	Data       []ReturnRequest              `json:"data"`
+	Pagination sdkSql.PaginationMetaMessage `json:"pagination"`
+	BaseResponse
+}
+
+type WebhookSubscription struct {
+	ID       int64  `json:"id"`
+	SellerID int64  `json:"seller_id"`
+	Url      string `json:"url"`
+	// Secret is only returned when the subscription is created
+	Secret     string    `json:"secret,omitempty"`
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type GetWebhookSubscriptionResponse struct {
+	Data WebhookSubscription `json:"data"`
+	BaseResponse
+}
+
+type GetWebhookSubscriptionListResponse struct {
+	Data []WebhookSubscription `json:"data"`
+	BaseResponse
+}
+
+type WebhookDelivery struct {
+	ID             int64  `json:"id"`
+	SubscriptionID int64  `json:"subscription_id"`
+	EventID        int64  `json:"event_id"`
+	EventType      string `json:"event_type"`
+	// Status is pending, succeeded or failed
+	Status   string `json:"status"`
+	Attempts int    `json:"attempts"`
+	// ResponseCode is the status code of the last attempt, 0 when it got no response
+	ResponseCode int    `json:"response_code"`
+	LastError    string `json:"last_error"`
+	// NextAttemptAt is only set for pending deliveries
+	NextAttemptAt *time.Time               `json:"next_attempt_at"`
+	DeliveredAt   *time.Time               `json:"delivered_at"`
+	CreatedAt     time.Time                `json:"created_at"`
+	History       []WebhookDeliveryAttempt `json:"history"`
+}
+
+type WebhookDeliveryAttempt struct {
+	ResponseCode int    `json:"response_code"`
+	Error        string `json:"error"`
+	DurationMs   int64  `json:"duration_ms"`
+	// Manual is set for the attempts of a redelivery by hand
+	Manual    bool      `json:"manual"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type GetWebhookDeliveryResponse struct {
+	Data WebhookDelivery `json:"data"`
+	BaseResponse
+}
+
+type GetWebhookDeliveryListResponse struct {
+	Data       []WebhookDelivery            `json:"data"`
+	Pagination sdkSql.PaginationMetaMessage `json:"pagination"`
+	BaseResponse
+}
@@ -0,0 +1,5 @@
+// Package weight holds weights with their unit in their type, so a weight in grams is never taken for kilograms.
+package weight
+
+// Grams is a weight in grams.
+type Grams float64
@@ -0,0 +1,172 @@
+// Package payment holds the payment gateways orders can be charged with.
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"ecommerce/model/entity"
+	"ecommerce/repository"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrInvalidSignature = errors.New("webhook signature is not valid")
+	ErrChargeNotFound   = errors.New("charge not found")
+	ErrInvalidAmount    = errors.New("amount is not valid")
+)
+
+// FakeName is the name of the fake gateway.
+const FakeName = "fake"
+
+type fakeCharge struct {
+	amount   int64
+	captured int64
+	refunded int64
+}
+
+// fakeWebhook is the body of the webhooks of the fake gateway.
+type fakeWebhook struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	ChargeID string `json:"charge_id"`
+	Amount   int64  `json:"amount"`
+}
+
+type fakeGateway struct {
+	secret     []byte
+	paymentURL string
+	mu         sync.Mutex
+	charges    map[string]*fakeCharge
+	// applied holds the result of the calls applied so far by their idempotency key
+	applied map[string]entity.PaymentCharge
+}
+
+// NewFake is function to initialize an in-process payment gateway for local development and testing. Charges are
+// kept in memory, and webhooks are signed with the hex encoded HMAC-SHA256 of their body keyed by secret, so they can
+// be sent by hand with the event the customer would have triggered.
+func NewFake(secret string, paymentURL string) repository.PaymentGateway {
+	return &fakeGateway{
+		secret:     []byte(secret),
+		paymentURL: strings.TrimSuffix(paymentURL, "/"),
+		charges:    map[string]*fakeCharge{},
+		applied:    map[string]entity.PaymentCharge{},
+	}
+}
+
+func (f *fakeGateway) Name() string {
+	return FakeName
+}
+
+func (f *fakeGateway) CreateCharge(ctx context.Context, idempotencyKey string, orderID int64, amount int64, currency string) (charge entity.PaymentCharge, err error) {
+	if amount <= 0 {
+		return entity.PaymentCharge{}, fmt.Errorf("%w: %d", ErrInvalidAmount, amount)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if charge, ok := f.applied[idempotencyKey]; ok {
+		return charge, nil
+	}
+
+	id := make([]byte, 12)
+	_, err = rand.Read(id)
+	if err != nil {
+		return entity.PaymentCharge{}, err
+	}
+
+	chargeID := "ch_fake_" + hex.EncodeToString(id)
+	f.charges[chargeID] = &fakeCharge{amount: amount}
+
+	charge = entity.PaymentCharge{
+		ID:         chargeID,
+		Status:     entity.PaymentStatusPending,
+		PaymentUrl: f.paymentURL + "/" + chargeID,
+	}
+	f.applied[idempotencyKey] = charge
+
+	return charge, nil
+}
+
+func (f *fakeGateway) Capture(ctx context.Context, idempotencyKey string, chargeID string, amount int64) (err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.applied[idempotencyKey]; ok {
+		return nil
+	}
+
+	charge, ok := f.charges[chargeID]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrChargeNotFound, chargeID)
+	}
+
+	if amount <= 0 || charge.captured+amount > charge.amount {
+		return fmt.Errorf("%w: %d can't be captured from charge %s", ErrInvalidAmount, amount, chargeID)
+	}
+
+	charge.captured += amount
+	f.applied[idempotencyKey] = entity.PaymentCharge{ID: chargeID}
+	return nil
+}
+
+func (f *fakeGateway) Refund(ctx context.Context, idempotencyKey string, chargeID string, amount int64) (err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.applied[idempotencyKey]; ok {
+		return nil
+	}
+
+	charge, ok := f.charges[chargeID]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrChargeNotFound, chargeID)
+	}
+
+	// a charge captured through a webhook sent by hand is not known as captured, its whole amount can be refunded
+	captured := charge.captured
+	if captured == 0 {
+		captured = charge.amount
+	}
+
+	if amount <= 0 || charge.refunded+amount > captured {
+		return fmt.Errorf("%w: %d can't be refunded from charge %s", ErrInvalidAmount, amount, chargeID)
+	}
+
+	charge.refunded += amount
+	f.applied[idempotencyKey] = entity.PaymentCharge{ID: chargeID}
+	return nil
+}
+
+func (f *fakeGateway) VerifyWebhook(ctx context.Context, payload []byte, signature string) (event entity.PaymentWebhookEvent, err error) {
+	mac := hmac.New(sha256.New, f.secret)
+	mac.Write(payload)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(signature))) {
+		return entity.PaymentWebhookEvent{}, ErrInvalidSignature
+	}
+
+	var webhook fakeWebhook
+	err = json.Unmarshal(payload, &webhook)
+	if err != nil {
+		return entity.PaymentWebhookEvent{}, err
+	}
+
+	if webhook.ID == "" || webhook.ChargeID == "" {
+		return entity.PaymentWebhookEvent{}, errors.New("webhook should have an id and a charge_id")
+	}
+
+	return entity.PaymentWebhookEvent{
+		ID:       webhook.ID,
+		Type:     webhook.Type,
+		ChargeID: webhook.ChargeID,
+		Amount:   webhook.Amount,
+	}, nil
+}
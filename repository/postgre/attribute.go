@@ -0,0 +1,68 @@
+package postgre
+
+import (
+	"context"
+	"ecommerce/model/entity"
+	"ecommerce/repository"
+	sdkSql "ecommerce/utils/sql"
+)
+
+type attributeRepo struct {
+	baseRepo
+}
+
+// NewAttribute is function to initialize product attribute repository logic.
+func NewAttribute(db sdkSql.DBer) repository.AttributeProvider {
+	return &attributeRepo{
+		baseRepo: baseRepo{db: db},
+	}
+}
+
+func (a *attributeRepo) GetProductAttributesByProductID(ctx context.Context, productID int64) (response []entity.ProductAttribute, err error) {
+	var attributes []entity.ProductAttribute
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			product_attributes
+		WHERE
+			product_id = $1
+		ORDER BY
+			id ASC
+	`
+	err = a.conn(ctx).SelectContext(ctx, &attributes, selectQuery, productID)
+	if err != nil {
+		return []entity.ProductAttribute{}, err
+	}
+
+	return attributes, nil
+}
+
+func (a *attributeRepo) CreateProductAttribute(ctx context.Context, payload entity.ProductAttribute) (err error) {
+	_, err = a.conn(ctx).ExecContext(ctx,
+		`INSERT INTO
+			product_attributes (product_id, name, type, value)
+		VALUES
+			($1, $2, $3, $4)`, payload.ProductID, payload.Name, payload.Type, payload.Value)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a *attributeRepo) DeleteProductAttributesByProductID(ctx context.Context, productID int64) (err error) {
+	query := `
+	DELETE FROM
+		product_attributes
+	WHERE
+		product_id = $1`
+
+	_, err = a.conn(ctx).ExecContext(ctx, query, productID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
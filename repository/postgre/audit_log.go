@@ -0,0 +1,74 @@
+package postgre
+
+import (
+	"context"
+	"ecommerce/model/entity"
+	"ecommerce/repository"
+	sdkSql "ecommerce/utils/sql"
+)
+
+type auditLogRepo struct {
+	baseRepo
+}
+
+// NewAuditLog is function to initialize audit log repository logic.
+func NewAuditLog(db sdkSql.DBer) repository.AuditLogProvider {
+	return &auditLogRepo{
+		baseRepo: baseRepo{db: db},
+	}
+}
+
+func (a *auditLogRepo) CreateAuditLog(ctx context.Context, payload entity.AuditLog) (err error) {
+	_, err = a.conn(ctx).ExecContext(ctx,
+		`INSERT INTO
+			audit_log (entity_type, entity_id, product_id, action, actor, diff)
+		VALUES
+			($1, $2, $3, $4, $5, $6)`, payload.EntityType, payload.EntityID, payload.ProductID, payload.Action,
+		payload.Actor, string(payload.Diff))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a *auditLogRepo) CountAuditLogsByProductID(ctx context.Context, productID int64) (total int64, err error) {
+	selectQuery := `
+		SELECT
+			COUNT(*)
+		FROM
+			audit_log
+		WHERE
+			product_id = $1
+	`
+	err = a.conn(ctx).GetContext(ctx, &total, selectQuery, productID)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// GetAuditLogsByProductID returns the changes of a product and its images, the latest first.
+func (a *auditLogRepo) GetAuditLogsByProductID(ctx context.Context, productID int64, limit int64, offset int64) (response []entity.AuditLog, err error) {
+	var auditLogs []entity.AuditLog
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			audit_log
+		WHERE
+			product_id = $1
+		ORDER BY
+			id DESC
+		LIMIT $2
+		OFFSET $3
+	`
+	err = a.conn(ctx).SelectContext(ctx, &auditLogs, selectQuery, productID, limit, offset)
+	if err != nil {
+		return []entity.AuditLog{}, err
+	}
+
+	return auditLogs, nil
+}
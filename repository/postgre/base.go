@@ -1,10 +1,16 @@
 package postgre
 
 import (
+	"context"
 	"ecommerce/repository"
 	sdkSql "ecommerce/utils/sql"
+
+	"github.com/jmoiron/sqlx"
 )
 
+// txKey is the context key holding the running transaction.
+type txKey struct{}
+
 // baseRepo is base repo to store common func for repo.
 type baseRepo struct {
 	db sdkSql.DBer
@@ -13,3 +19,51 @@ type baseRepo struct {
 func (b *baseRepo) DB() repository.QueryProvider {
 	return b.db
 }
+
+// conn returns the transaction running in ctx, or the database when there is none.
+func (b *baseRepo) conn(ctx context.Context) repository.QueryProvider {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return tx
+	}
+
+	return b.db
+}
+
+type transactionRepo struct {
+	baseRepo
+}
+
+// NewTransaction is function to initialize transaction repository logic.
+func NewTransaction(db sdkSql.DBer) repository.TransactionProvider {
+	return &transactionRepo{
+		baseRepo: baseRepo{db: db},
+	}
+}
+
+// WithTransaction runs fn inside a transaction which is committed when fn returns nil and rolled back otherwise.
+// Repositories called with the ctx given to fn run their queries in that transaction.
+func (t *transactionRepo) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := t.db.BeginContext(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	err = fn(context.WithValue(ctx, txKey{}, tx))
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,177 @@
+package postgre
+
+import (
+	"context"
+	"ecommerce/model/entity"
+	"ecommerce/repository"
+	sdkSql "ecommerce/utils/sql"
+)
+
+type cartRepo struct {
+	baseRepo
+}
+
+// NewCart is function to initialize cart repository logic.
+func NewCart(db sdkSql.DBer) repository.CartProvider {
+	return &cartRepo{
+		baseRepo: baseRepo{db: db},
+	}
+}
+
+func (c *cartRepo) GetCartByUserID(ctx context.Context, userID int64) (response entity.Cart, err error) {
+	var cart entity.Cart
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			carts
+		WHERE
+			user_id = $1
+	`
+	err = c.conn(ctx).GetContext(ctx, &cart, selectQuery, userID)
+	if err != nil {
+		return entity.Cart{}, err
+	}
+
+	return cart, nil
+}
+
+func (c *cartRepo) CreateCart(ctx context.Context, userID int64) (id int64, err error) {
+	var lastInsertId int64
+	err = c.conn(ctx).GetContext(ctx, &lastInsertId,
+		`INSERT INTO
+			carts (user_id)
+		VALUES
+			($1)
+		RETURNING id`, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return lastInsertId, nil
+}
+
+// GetCartItemsByCartID returns the items of the cart, the sku, weight and price of the chosen variant of an item take
+// precedence over those of its product.
+func (c *cartRepo) GetCartItemsByCartID(ctx context.Context, cartID int64) (response []entity.CartItemDetail, err error) {
+	var cartItems []entity.CartItemDetail
+
+	selectQuery := `
+		SELECT
+			ci.*,
+			COALESCE(v.sku, products.sku) AS sku,
+			products.title,
+			COALESCE(v.weight, products.weight) AS weight,
+			products.length,
+			products.width,
+			products.height,
+			COALESCE(v.price, products.effective_price) AS current_price,
+			products.currency,
+			products.user_id AS seller_id,
+			products.category_id
+		FROM
+			cart_items ci
+		JOIN
+			` + pricedProducts + ` ON products.id = ci.product_id
+		LEFT JOIN
+			product_variants v ON v.id = ci.variant_id
+		WHERE
+			ci.cart_id = $1
+		ORDER BY
+			ci.id ASC
+	`
+	err = c.conn(ctx).SelectContext(ctx, &cartItems, selectQuery, cartID)
+	if err != nil {
+		return []entity.CartItemDetail{}, err
+	}
+
+	return cartItems, nil
+}
+
+func (c *cartRepo) GetCartItemByProductID(ctx context.Context, cartID int64, productID int64, variantID int64) (response entity.CartItem, err error) {
+	var cartItem entity.CartItem
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			cart_items
+		WHERE
+			cart_id = $1
+		AND
+			product_id = $2
+		AND
+			variant_id = $3
+	`
+	err = c.conn(ctx).GetContext(ctx, &cartItem, selectQuery, cartID, productID, variantID)
+	if err != nil {
+		return entity.CartItem{}, err
+	}
+
+	return cartItem, nil
+}
+
+func (c *cartRepo) CreateCartItem(ctx context.Context, payload entity.CartItem) (err error) {
+	_, err = c.conn(ctx).ExecContext(ctx,
+		`INSERT INTO
+			cart_items (cart_id, product_id, variant_id, quantity, price)
+		VALUES
+			($1, $2, $3, $4, $5)`, payload.CartID, payload.ProductID, payload.VariantID, payload.Quantity, payload.Price)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cartRepo) UpdateCartItem(ctx context.Context, payload entity.CartItem) (err error) {
+	_, err = c.conn(ctx).ExecContext(ctx,
+		`UPDATE
+		cart_items
+	SET
+		quantity=$1,
+		price=$2,
+		updated_at=NOW()
+	WHERE
+		id=$3`, payload.Quantity, payload.Price, payload.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cartRepo) DeleteCartItemByProductID(ctx context.Context, cartID int64, productID int64, variantID int64) (err error) {
+	query := `
+	DELETE FROM
+		cart_items
+	WHERE
+		cart_id = $1
+	AND
+		product_id = $2
+	AND
+		variant_id = $3`
+
+	_, err = c.conn(ctx).ExecContext(ctx, query, cartID, productID, variantID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *cartRepo) DeleteCartItemsByCartID(ctx context.Context, cartID int64) (err error) {
+	query := `
+	DELETE FROM
+		cart_items
+	WHERE
+		cart_id = $1`
+
+	_, err = c.conn(ctx).ExecContext(ctx, query, cartID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
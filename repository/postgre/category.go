@@ -0,0 +1,192 @@
+package postgre
+
+import (
+	"context"
+	"ecommerce/model/entity"
+	"ecommerce/repository"
+	sdkSql "ecommerce/utils/sql"
+)
+
+type categoryRepo struct {
+	baseRepo
+}
+
+// NewCategory is function to initialize category repository logic.
+func NewCategory(db sdkSql.DBer) repository.CategoryProvider {
+	return &categoryRepo{
+		baseRepo: baseRepo{db: db},
+	}
+}
+
+func (c *categoryRepo) GetCategories(ctx context.Context) (response []entity.Category, err error) {
+	var categories []entity.Category
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			categories
+		ORDER BY
+			position ASC, name ASC
+	`
+	err = c.conn(ctx).SelectContext(ctx, &categories, selectQuery)
+	if err != nil {
+		return []entity.Category{}, err
+	}
+
+	return categories, nil
+}
+
+func (c *categoryRepo) GetCategoryByID(ctx context.Context, id int64) (response entity.Category, err error) {
+	var category entity.Category
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			categories
+		WHERE
+			id = $1
+	`
+	err = c.conn(ctx).GetContext(ctx, &category, selectQuery, id)
+	if err != nil {
+		return entity.Category{}, err
+	}
+
+	return category, nil
+}
+
+func (c *categoryRepo) GetCategoryBySlug(ctx context.Context, slug string) (response entity.Category, err error) {
+	var category entity.Category
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			categories
+		WHERE
+			slug = $1
+	`
+	err = c.conn(ctx).GetContext(ctx, &category, selectQuery, slug)
+	if err != nil {
+		return entity.Category{}, err
+	}
+
+	return category, nil
+}
+
+// GetCategoryDescendantIDs returns the id of the category along with the ids of all categories below it.
+func (c *categoryRepo) GetCategoryDescendantIDs(ctx context.Context, id int64) (response []int64, err error) {
+	var ids []int64
+
+	selectQuery := `
+		WITH RECURSIVE tree AS (
+			SELECT
+				id
+			FROM
+				categories
+			WHERE
+				id = $1
+			UNION
+			SELECT
+				c.id
+			FROM
+				categories c
+			JOIN
+				tree t ON c.parent_id = t.id
+		)
+		SELECT
+			id
+		FROM
+			tree
+	`
+	err = c.conn(ctx).SelectContext(ctx, &ids, selectQuery, id)
+	if err != nil {
+		return []int64{}, err
+	}
+
+	return ids, nil
+}
+
+func (c *categoryRepo) CreateCategory(ctx context.Context, payload entity.Category) (id int64, err error) {
+	var lastInsertId int64
+	err = c.conn(ctx).GetContext(ctx, &lastInsertId,
+		`INSERT INTO
+			categories (parent_id, name, slug, position)
+		VALUES
+			($1, $2, $3, $4)
+		RETURNING id`, payload.ParentID, payload.Name, payload.Slug, payload.Position)
+	if err != nil {
+		return 0, err
+	}
+
+	return lastInsertId, nil
+}
+
+func (c *categoryRepo) UpdateCategory(ctx context.Context, payload entity.Category) (err error) {
+	_, err = c.conn(ctx).ExecContext(ctx,
+		`UPDATE
+		categories
+	SET
+		parent_id=$1,
+		name=$2,
+		slug=$3,
+		position=$4,
+		updated_at=NOW()
+	WHERE
+		id=$5`, payload.ParentID, payload.Name, payload.Slug, payload.Position, payload.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *categoryRepo) DeleteCategory(ctx context.Context, id int64) (err error) {
+	query := `
+	DELETE FROM
+		categories
+	WHERE
+		id = $1`
+
+	_, err = c.conn(ctx).ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *categoryRepo) CountChildCategories(ctx context.Context, id int64) (total int64, err error) {
+	selectQuery := `
+		SELECT
+			COUNT(*)
+		FROM
+			categories
+		WHERE
+			parent_id = $1
+	`
+	err = c.conn(ctx).GetContext(ctx, &total, selectQuery, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (c *categoryRepo) CountProductsByCategoryID(ctx context.Context, id int64) (total int64, err error) {
+	selectQuery := `
+		SELECT
+			COUNT(*)
+		FROM
+			products
+		WHERE
+			category_id = $1
+	`
+	err = c.conn(ctx).GetContext(ctx, &total, selectQuery, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
@@ -7,6 +7,11 @@ import (
 	"ecommerce/repository"
 	sdkSql "ecommerce/utils/sql"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
 )
 
 type ecommerceRepo struct {
@@ -27,23 +32,15 @@ func (e *ecommerceRepo) GetProductList(ctx context.Context, payload request.Filt
 		sort = "ASC"
 	}
 
+	where, args := productFilter(payload)
 	selectQuery := `
 		SELECT
-			*
+			` + productColumns + `
 		FROM
-			products
-		WHERE 
-			sku ilike '%' || $1 || '%'
-		OR 
-			category ilike '%' || $1 || '%'
-		OR 
-			etalase ilike '%' || $1 || '%'
-		OR 
-			title ilike '%' || $1 || '%'
-	`
-	selectQuery += fmt.Sprintf(" ORDER BY %s %s", payload.Sort, sort)
+			` + pricedProducts + `
+		WHERE ` + where + productOrder(payload.Sort, sort)
 
-	err = e.db.SelectContext(ctx, &products, selectQuery, payload.Search)
+	err = e.conn(ctx).SelectContext(ctx, &products, selectQuery, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -51,15 +48,308 @@ func (e *ecommerceRepo) GetProductList(ctx context.Context, payload request.Filt
 	return products, nil
 }
 
+// ExportProducts calls fn with every product matching payload. Rows are read from a cursor one at a time instead of
+// being loaded at once, so the whole catalog can be exported.
+func (e *ecommerceRepo) ExportProducts(ctx context.Context, payload request.FilterProduct, fn func(product entity.ProductExport) error) (err error) {
+	sort := "DESC"
+	if payload.IsAsc {
+		sort = "ASC"
+	}
+
+	where, args := productFilter(payload)
+	selectQuery := `
+		SELECT
+			` + productColumns + `,
+			COALESCE((
+				SELECT string_agg(pi.image_url, '|' ORDER BY pi.position, pi.id) FROM product_images pi WHERE pi.product_id = products.id
+			), '') AS image_urls
+		FROM
+			` + pricedProducts + `
+		WHERE ` + where + productOrder(payload.Sort, sort)
+
+	rows, err := e.db.QueryContext(ctx, selectQuery, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var product entity.ProductExport
+		err = rows.StructScan(&product)
+		if err != nil {
+			return err
+		}
+
+		err = fn(product)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (e *ecommerceRepo) GetProductCategoryFacets(ctx context.Context, payload request.FilterProduct) (response []entity.CategoryFacet, err error) {
+	var facets []entity.CategoryFacet
+
+	where, args := productFilter(payload)
+	selectQuery := `
+		WITH filtered AS (
+			SELECT
+				*
+			FROM
+				` + pricedProducts + `
+			WHERE ` + where + `
+		)
+		SELECT
+			f.category_id,
+			COALESCE(c.name, f.category) AS name,
+			COUNT(*) AS count
+		FROM
+			filtered f
+		LEFT JOIN
+			categories c ON c.id = f.category_id
+		GROUP BY
+			1, 2
+		ORDER BY
+			3 DESC, 2 ASC
+	`
+	err = e.conn(ctx).SelectContext(ctx, &facets, selectQuery, args...)
+	if err != nil {
+		return []entity.CategoryFacet{}, err
+	}
+
+	return facets, nil
+}
+
+func (e *ecommerceRepo) GetProductAttributeFacets(ctx context.Context, payload request.FilterProduct) (response []entity.AttributeFacet, err error) {
+	var facets []entity.AttributeFacet
+
+	where, args := productFilter(payload)
+	selectQuery := `
+		WITH filtered AS (
+			SELECT
+				*
+			FROM
+				` + pricedProducts + `
+			WHERE ` + where + `
+		)
+		SELECT
+			pa.name,
+			pa.value,
+			COUNT(DISTINCT f.id) AS count
+		FROM
+			filtered f
+		JOIN
+			product_attributes pa ON pa.product_id = f.id
+		GROUP BY
+			1, 2
+		ORDER BY
+			1 ASC, 3 DESC, 2 ASC
+	`
+	err = e.conn(ctx).SelectContext(ctx, &facets, selectQuery, args...)
+	if err != nil {
+		return []entity.AttributeFacet{}, err
+	}
+
+	return facets, nil
+}
+
+// GetProductPriceFacets counts the products in payload.PriceCurrency per effective price bucket, bucket i holds the
+// prices in [boundaries[i-1], boundaries[i]).
+func (e *ecommerceRepo) GetProductPriceFacets(ctx context.Context, payload request.FilterProduct, boundaries []int64) (response []entity.PriceFacet, err error) {
+	var facets []entity.PriceFacet
+
+	where, args := productFilter(payload)
+	args = append(args, pq.Array(boundaries))
+	selectQuery := `
+		WITH filtered AS (
+			SELECT
+				*
+			FROM
+				` + pricedProducts + `
+			WHERE ` + where + `
+			AND
+				currency = $6
+		)
+		SELECT
+			width_bucket(f.effective_price::bigint, $` + strconv.Itoa(len(args)) + `::bigint[]) AS bucket,
+			COUNT(*) AS count
+		FROM
+			filtered f
+		GROUP BY
+			1
+		ORDER BY
+			1 ASC
+	`
+	err = e.conn(ctx).SelectContext(ctx, &facets, selectQuery, args...)
+	if err != nil {
+		return []entity.PriceFacet{}, err
+	}
+
+	return facets, nil
+}
+
+// effectivePriceColumn selects the price charged now for each row of products: the running sale price, else the
+// regular price in effect, else the price set on the product.
+const effectivePriceColumn = `COALESCE((
+				SELECT pp.price FROM product_prices pp
+				WHERE pp.product_id = products.id AND pp.effective_from <= NOW() AND (pp.effective_to IS NULL OR pp.effective_to > NOW())
+				ORDER BY pp.kind = 'sale' DESC, pp.effective_from DESC
+				LIMIT 1
+			), products.price) AS effective_price`
+
+// regularPriceColumn selects the regular price in effect now for each row of products, else the price set on the
+// product. A regular price scheduled since the last update of the product takes over from the price of its row.
+const regularPriceColumn = `COALESCE((
+				SELECT pp.price FROM product_prices pp
+				WHERE pp.product_id = products.id AND pp.kind = 'regular' AND pp.effective_from <= NOW() AND (pp.effective_to IS NULL OR pp.effective_to > NOW())
+				ORDER BY pp.effective_from DESC
+				LIMIT 1
+			), products.price) AS regular_price`
+
+// pricedProducts is the products table with the regular and the effective price of each product. Lists, carts and
+// wishlists read the prices from it so the price charged is decided in one place.
+const pricedProducts = `(
+				SELECT
+					*,
+					` + regularPriceColumn + `,
+					` + effectivePriceColumn + `
+				FROM
+					products
+			) products`
+
+// productColumns selects the columns of entity.Product from pricedProducts. The prices are selected with the currency
+// of the product as the fields of their money.Money, the price being the regular price in effect rather than the
+// price column, which only holds the price set by the last update.
+const productColumns = `
+			products.id,
+			products.user_id,
+			products.sku,
+			products.title,
+			products.description,
+			products.category,
+			products.category_id,
+			products.etalase,
+			products.weight,
+			products.length,
+			products.width,
+			products.height,
+			products.regular_price AS "price.amount",
+			products.currency AS "price.currency",
+			products.rating,
+			products.stock,
+			products.favourited_count,
+			products.created_at,
+			products.updated_at,
+			` + primaryImageUrlColumn + `,
+			products.effective_price AS "effective_price.amount",
+			products.currency AS "effective_price.currency"`
+
+// productOrder sorts the product list by column. Prices are only compared within a currency, so sorting by a price
+// puts the products in the currency of the price filter first and groups the others by their currency. The price is
+// sorted by the regular price in effect, the one shown.
+func productOrder(column string, sort string) string {
+	if column == "price" {
+		column = "regular_price"
+	}
+
+	if column == "regular_price" || column == "effective_price" {
+		return fmt.Sprintf(" ORDER BY currency <> $6, currency ASC, %s %s", column, sort)
+	}
+
+	return fmt.Sprintf(" ORDER BY %s %s", column, sort)
+}
+
+// primaryImageUrlColumn selects the url of the primary image of each row of products.
+const primaryImageUrlColumn = `COALESCE((
+				SELECT pi.image_url FROM product_images pi WHERE pi.product_id = products.id AND pi.is_primary
+			), '') AS primary_image_url`
+
+// productFilter builds the where clause on pricedProducts shared by the list and its facets. The price filters only
+// match products in payload.PriceCurrency since prices in different currencies can't be compared.
+func productFilter(payload request.FilterProduct) (string, []interface{}) {
+	where := `
+			(
+				sku ilike '%' || $1 || '%'
+			OR 
+				category ilike '%' || $1 || '%'
+			OR 
+				etalase ilike '%' || $1 || '%'
+			OR 
+				title ilike '%' || $1 || '%'
+			)
+		AND
+			(
+				$2 = 0
+			OR
+				category_id IN (
+					WITH RECURSIVE tree AS (
+						SELECT id FROM categories WHERE id = $2
+						UNION
+						SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+					)
+					SELECT id FROM tree
+				)
+			)
+		AND
+			($3 = 0 OR user_id = $3)
+		AND
+			($4 = 0 OR (currency = $6 AND effective_price >= $4))
+		AND
+			($5 = 0 OR (currency = $6 AND effective_price <= $5))
+	`
+	args := []interface{}{payload.Search, payload.CategoryID, payload.UserID, payload.MinPrice, payload.MaxPrice,
+		payload.PriceCurrency}
+
+	names := make([]string, 0, len(payload.AttributeValues))
+	for name := range payload.AttributeValues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		args = append(args, name)
+		nameArg := len(args)
+
+		types := make([]string, 0, len(payload.AttributeValues[name]))
+		for attributeType := range payload.AttributeValues[name] {
+			types = append(types, attributeType)
+		}
+		sort.Strings(types)
+
+		// the value is compared in the canonical form of the type of each attribute, a value valid for no type
+		// matches nothing
+		var values []string
+		for _, attributeType := range types {
+			args = append(args, attributeType, payload.AttributeValues[name][attributeType])
+			values = append(values, fmt.Sprintf("(pa.type = $%d AND pa.value = $%d)", len(args)-1, len(args)))
+		}
+		if len(values) == 0 {
+			values = append(values, "FALSE")
+		}
+
+		where += fmt.Sprintf(`
+		AND
+			EXISTS (
+				SELECT 1 FROM product_attributes pa WHERE pa.product_id = products.id AND pa.name = $%d AND (%s)
+			)
+		`, nameArg, strings.Join(values, " OR "))
+	}
+
+	return where, args
+}
+
 func (e *ecommerceRepo) CreateProduct(ctx context.Context, payload entity.Product) (id int64, err error) {
 	var lastInsertId int64
-	err = e.db.GetContext(ctx, &lastInsertId,
+	err = e.conn(ctx).GetContext(ctx, &lastInsertId,
 		`INSERT INTO 
-		products ( sku, title, description, category, etalase, weight, price, user_id) 
+		products ( sku, title, description, category, etalase, weight, length, width, height, price, user_id, stock, category_id, currency) 
 		VALUES 
-			($1, $2, $3, $4, $5, $6, $7, $8)
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 		RETURNING id`, payload.Sku, payload.Title, payload.Description, payload.Category, payload.Etalase,
-		payload.Weight, payload.Price, payload.UserID)
+		payload.Weight, payload.Length, payload.Width, payload.Height, payload.Price.Amount, payload.UserID, payload.Stock,
+		payload.CategoryID, payload.Price.Currency)
 
 	if err != nil {
 		return 0, err
@@ -68,8 +358,41 @@ func (e *ecommerceRepo) CreateProduct(ctx context.Context, payload entity.Produc
 	return lastInsertId, nil
 }
 
+// UpsertProduct creates the product or updates the product of the seller with the same sku. The other columns of an
+// existing product are overwritten with payload, so payload has to carry the values kept from the product. The stock
+// of an existing product is left as is, it is only changed through the inventory ledger, and so is its currency.
+func (e *ecommerceRepo) UpsertProduct(ctx context.Context, payload entity.Product) (response entity.UpsertedProduct, err error) {
+	var upserted entity.UpsertedProduct
+	err = e.conn(ctx).GetContext(ctx, &upserted,
+		`INSERT INTO
+			products ( sku, title, description, category, etalase, weight, length, width, height, price, user_id, stock, category_id, currency)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		ON CONFLICT (user_id, sku) DO UPDATE
+		SET
+			title=EXCLUDED.title,
+			description=EXCLUDED.description,
+			category=EXCLUDED.category,
+			etalase=EXCLUDED.etalase,
+			weight=EXCLUDED.weight,
+			length=EXCLUDED.length,
+			width=EXCLUDED.width,
+			height=EXCLUDED.height,
+			price=EXCLUDED.price,
+			category_id=EXCLUDED.category_id,
+			updated_at=NOW()
+		RETURNING id, (xmax = 0) AS created`, payload.Sku, payload.Title, payload.Description, payload.Category, payload.Etalase,
+		payload.Weight, payload.Length, payload.Width, payload.Height, payload.Price.Amount, payload.UserID, payload.Stock,
+		payload.CategoryID, payload.Price.Currency)
+	if err != nil {
+		return entity.UpsertedProduct{}, err
+	}
+
+	return upserted, nil
+}
+
 func (e *ecommerceRepo) UpdateProduct(ctx context.Context, payload entity.Product) (err error) {
-	_, err = e.db.ExecContext(ctx,
+	_, err = e.conn(ctx).ExecContext(ctx,
 		`UPDATE
 		products
 	SET
@@ -79,11 +402,16 @@ func (e *ecommerceRepo) UpdateProduct(ctx context.Context, payload entity.Produc
 		category=$4,
 		etalase=$5,
 		weight=$6,
-		price=$7,
-		rating=$8
+		length=$7,
+		width=$8,
+		height=$9,
+		price=$10,
+		rating=$11,
+		category_id=$12
 	WHERE
-		id=$9`, payload.Sku, payload.Title, payload.Description, payload.Category, payload.Etalase,
-		payload.Weight, payload.Price, payload.Rating, payload.ID)
+		id=$13`, payload.Sku, payload.Title, payload.Description, payload.Category, payload.Etalase,
+		payload.Weight, payload.Length, payload.Width, payload.Height, payload.Price.Amount, payload.Rating, payload.CategoryID,
+		payload.ID)
 
 	if err != nil {
 		return err
@@ -92,18 +420,55 @@ func (e *ecommerceRepo) UpdateProduct(ctx context.Context, payload entity.Produc
 	return nil
 }
 
+// LockProduct locks the product row until the running transaction ends, so the changes made to a product, its prices
+// and its images are applied one after the other. It returns sql.ErrNoRows when the product doesn't exist.
+func (e *ecommerceRepo) LockProduct(ctx context.Context, id int64) (err error) {
+	selectQuery := `
+		SELECT
+			id
+		FROM
+			products
+		WHERE
+			id = $1
+		FOR UPDATE
+	`
+	return e.conn(ctx).GetContext(ctx, &id, selectQuery, id)
+}
+
 func (e *ecommerceRepo) GetProductByID(ctx context.Context, id int64) (response entity.Product, err error) {
 	var products entity.Product
 
 	selectQuery := `
 		SELECT
-			*
+			` + productColumns + `
 		FROM
-			products
+			` + pricedProducts + `
 		WHERE
 			id = $1
 	`
-	err = e.db.GetContext(ctx, &products, selectQuery, id)
+	err = e.conn(ctx).GetContext(ctx, &products, selectQuery, id)
+	if err != nil {
+		return entity.Product{}, err
+	}
+
+	return products, nil
+}
+
+// GetProductBySku returns the product of a seller with the sku.
+func (e *ecommerceRepo) GetProductBySku(ctx context.Context, userID int64, sku string) (response entity.Product, err error) {
+	var products entity.Product
+
+	selectQuery := `
+		SELECT
+			` + productColumns + `
+		FROM
+			` + pricedProducts + `
+		WHERE
+			user_id = $1
+		AND
+			sku = $2
+	`
+	err = e.conn(ctx).GetContext(ctx, &products, selectQuery, userID, sku)
 	if err != nil {
 		return entity.Product{}, err
 	}
@@ -121,8 +486,10 @@ func (e *ecommerceRepo) GetProductImagesByProductID(ctx context.Context, id int6
 			product_images
 		WHERE
 			product_id = $1
+		ORDER BY
+			position ASC, id ASC
 	`
-	err = e.db.SelectContext(ctx, &productImage, selectQuery, id)
+	err = e.conn(ctx).SelectContext(ctx, &productImage, selectQuery, id)
 	if err != nil {
 		return []entity.ProductImage{}, err
 	}
@@ -141,7 +508,7 @@ func (e *ecommerceRepo) GetProductReviewByProductID(ctx context.Context, id int6
 		WHERE
 			product_id = $1
 	`
-	err = e.db.SelectContext(ctx, &productReviews, selectQuery, id)
+	err = e.conn(ctx).SelectContext(ctx, &productReviews, selectQuery, id)
 	if err != nil {
 		return []entity.ProductReview{}, err
 	}
@@ -149,25 +516,27 @@ func (e *ecommerceRepo) GetProductReviewByProductID(ctx context.Context, id int6
 	return productReviews, nil
 }
 
-func (e *ecommerceRepo) CreateProductReview(ctx context.Context, payload entity.ProductReview) (err error) {
-	_, err = e.db.ExecContext(ctx,
+func (e *ecommerceRepo) CreateProductReview(ctx context.Context, payload entity.ProductReview) (id int64, err error) {
+	var lastInsertId int64
+	err = e.conn(ctx).GetContext(ctx, &lastInsertId,
 		`INSERT INTO 
 			product_reviews ( product_id, comment, rating) 
 		VALUES 
-			($1, $2, $3)`, payload.ProductID, payload.Comment, payload.Rating)
+			($1, $2, $3)
+		RETURNING id`, payload.ProductID, payload.Comment, payload.Rating)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	return nil
+	return lastInsertId, nil
 }
 
 func (e *ecommerceRepo) CreateProductImages(ctx context.Context, payload entity.ProductImage) (err error) {
-	_, err = e.db.ExecContext(ctx,
+	_, err = e.conn(ctx).ExecContext(ctx,
 		`INSERT INTO 
-			product_images ( product_id, image_url, short_description) 
+			product_images ( product_id, image_url, short_description, position, is_primary) 
 		VALUES 
-			($1, $2, $3)`, payload.ProductID, payload.ImageUrl, payload.ShortDescription)
+			($1, $2, $3, $4, $5)`, payload.ProductID, payload.ImageUrl, payload.ShortDescription, payload.Position, payload.IsPrimary)
 	if err != nil {
 		return err
 	}
@@ -182,7 +551,61 @@ func (e *ecommerceRepo) DeleteProductImagesByID(ctx context.Context, productID i
 	WHERE
 		product_id = $1`
 
-	_, err = e.DB().ExecContext(ctx, query, productID)
+	_, err = e.conn(ctx).ExecContext(ctx, query, productID)
+
+	return nil
+}
+
+func (e *ecommerceRepo) UpdateProductImagePosition(ctx context.Context, productID int64, imageID int64, position int) (err error) {
+	_, err = e.conn(ctx).ExecContext(ctx,
+		`UPDATE
+			product_images
+		SET
+			position=$1,
+			updated_at=NOW()
+		WHERE
+			product_id=$2
+		AND
+			id=$3`, position, productID, imageID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SetPrimaryProductImage makes imageID the only primary image of the product, it must run inside a transaction.
+func (e *ecommerceRepo) SetPrimaryProductImage(ctx context.Context, productID int64, imageID int64) (err error) {
+	// the previous primary image is unset first since a product can't have two primary images at any time
+	_, err = e.conn(ctx).ExecContext(ctx,
+		`UPDATE
+			product_images
+		SET
+			is_primary=false,
+			updated_at=NOW()
+		WHERE
+			product_id=$1
+		AND
+			is_primary
+		AND
+			id<>$2`, productID, imageID)
+	if err != nil {
+		return err
+	}
+
+	_, err = e.conn(ctx).ExecContext(ctx,
+		`UPDATE
+			product_images
+		SET
+			is_primary=true,
+			updated_at=NOW()
+		WHERE
+			product_id=$1
+		AND
+			id=$2`, productID, imageID)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
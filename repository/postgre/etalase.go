@@ -0,0 +1,191 @@
+package postgre
+
+import (
+	"context"
+	"ecommerce/model/entity"
+	"ecommerce/repository"
+	sdkSql "ecommerce/utils/sql"
+)
+
+type etalaseRepo struct {
+	baseRepo
+}
+
+// NewEtalase is function to initialize etalase repository logic.
+func NewEtalase(db sdkSql.DBer) repository.EtalaseProvider {
+	return &etalaseRepo{
+		baseRepo: baseRepo{db: db},
+	}
+}
+
+func (e *etalaseRepo) GetEtalasesByUserID(ctx context.Context, userID int64) (response []entity.Etalase, err error) {
+	var etalases []entity.Etalase
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			etalases
+		WHERE
+			user_id = $1
+		ORDER BY
+			position ASC, id ASC
+	`
+	err = e.conn(ctx).SelectContext(ctx, &etalases, selectQuery, userID)
+	if err != nil {
+		return []entity.Etalase{}, err
+	}
+
+	return etalases, nil
+}
+
+func (e *etalaseRepo) GetEtalaseByID(ctx context.Context, id int64) (response entity.Etalase, err error) {
+	var etalase entity.Etalase
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			etalases
+		WHERE
+			id = $1
+	`
+	err = e.conn(ctx).GetContext(ctx, &etalase, selectQuery, id)
+	if err != nil {
+		return entity.Etalase{}, err
+	}
+
+	return etalase, nil
+}
+
+func (e *etalaseRepo) CreateEtalase(ctx context.Context, payload entity.Etalase) (id int64, err error) {
+	var lastInsertId int64
+	err = e.conn(ctx).GetContext(ctx, &lastInsertId,
+		`INSERT INTO
+			etalases (user_id, name, position)
+		VALUES
+			($1, $2, $3)
+		RETURNING id`, payload.UserID, payload.Name, payload.Position)
+	if err != nil {
+		return 0, err
+	}
+
+	return lastInsertId, nil
+}
+
+func (e *etalaseRepo) UpdateEtalase(ctx context.Context, payload entity.Etalase) (err error) {
+	_, err = e.conn(ctx).ExecContext(ctx,
+		`UPDATE
+		etalases
+	SET
+		name=$1,
+		position=$2,
+		updated_at=NOW()
+	WHERE
+		id=$3`, payload.Name, payload.Position, payload.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteEtalase deletes the etalase along with its product assignments.
+func (e *etalaseRepo) DeleteEtalase(ctx context.Context, id int64) (err error) {
+	query := `
+	DELETE FROM
+		product_etalases
+	WHERE
+		etalase_id = $1`
+
+	_, err = e.conn(ctx).ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	query = `
+	DELETE FROM
+		etalases
+	WHERE
+		id = $1`
+
+	_, err = e.conn(ctx).ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (e *etalaseRepo) AddProductToEtalase(ctx context.Context, etalaseID int64, productID int64) (err error) {
+	_, err = e.conn(ctx).ExecContext(ctx,
+		`INSERT INTO
+			product_etalases (etalase_id, product_id)
+		VALUES
+			($1, $2)
+		ON CONFLICT DO NOTHING`, etalaseID, productID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (e *etalaseRepo) RemoveProductFromEtalase(ctx context.Context, etalaseID int64, productID int64) (err error) {
+	query := `
+	DELETE FROM
+		product_etalases
+	WHERE
+		etalase_id = $1
+	AND
+		product_id = $2`
+
+	_, err = e.conn(ctx).ExecContext(ctx, query, etalaseID, productID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (e *etalaseRepo) CountProductsByEtalaseID(ctx context.Context, etalaseID int64) (total int64, err error) {
+	selectQuery := `
+		SELECT
+			COUNT(*)
+		FROM
+			product_etalases
+		WHERE
+			etalase_id = $1
+	`
+	err = e.conn(ctx).GetContext(ctx, &total, selectQuery, etalaseID)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (e *etalaseRepo) GetProductsByEtalaseID(ctx context.Context, etalaseID int64, limit int64, offset int64) (response []entity.Product, err error) {
+	var products []entity.Product
+
+	selectQuery := `
+		SELECT
+			` + productColumns + `
+		FROM
+			` + pricedProducts + `
+		JOIN
+			product_etalases pe ON pe.product_id = products.id
+		WHERE
+			pe.etalase_id = $1
+		ORDER BY
+			products.id ASC
+		LIMIT $2
+		OFFSET $3
+	`
+	err = e.conn(ctx).SelectContext(ctx, &products, selectQuery, etalaseID, limit, offset)
+	if err != nil {
+		return []entity.Product{}, err
+	}
+
+	return products, nil
+}
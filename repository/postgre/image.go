@@ -0,0 +1,141 @@
+package postgre
+
+import (
+	"context"
+	"ecommerce/model/entity"
+	"ecommerce/repository"
+	sdkSql "ecommerce/utils/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type imageRepo struct {
+	baseRepo
+}
+
+// NewImage is function to initialize image repository logic.
+func NewImage(db sdkSql.DBer) repository.ImageProvider {
+	return &imageRepo{
+		baseRepo: baseRepo{db: db},
+	}
+}
+
+// UpsertImage queues the image for processing, an image that failed before is queued again.
+func (i *imageRepo) UpsertImage(ctx context.Context, payload entity.Image) (err error) {
+	_, err = i.conn(ctx).ExecContext(ctx,
+		`INSERT INTO
+			images (storage_key, image_url, content_type, size, status)
+		VALUES
+			($1, $2, $3, $4, $5)
+		ON CONFLICT (storage_key) DO UPDATE
+		SET
+			status=EXCLUDED.status,
+			attempts=0,
+			last_error='',
+			updated_at=NOW()
+		WHERE
+			images.status = 'failed'`, payload.StorageKey, payload.ImageUrl, payload.ContentType, payload.Size, payload.Status)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ClaimPendingImages marks up to limit pending images as processing and returns them. Images left processing since
+// staleBefore are claimed again since the worker processing them is gone.
+func (i *imageRepo) ClaimPendingImages(ctx context.Context, staleBefore time.Time, limit int) (response []entity.Image, err error) {
+	var images []entity.Image
+
+	query := `
+		UPDATE
+			images
+		SET
+			status='processing',
+			updated_at=NOW()
+		WHERE
+			id IN (
+				SELECT
+					id
+				FROM
+					images
+				WHERE
+					status = 'pending'
+				OR
+					(status = 'processing' AND updated_at < $1)
+				ORDER BY
+					id ASC
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			)
+		RETURNING *
+	`
+	err = i.conn(ctx).SelectContext(ctx, &images, query, staleBefore, limit)
+	if err != nil {
+		return []entity.Image{}, err
+	}
+
+	return images, nil
+}
+
+func (i *imageRepo) UpdateImageStatus(ctx context.Context, payload entity.Image) (err error) {
+	_, err = i.conn(ctx).ExecContext(ctx,
+		`UPDATE
+			images
+		SET
+			status=$1,
+			attempts=$2,
+			last_error=$3,
+			updated_at=NOW()
+		WHERE
+			id=$4`, payload.Status, payload.Attempts, payload.LastError, payload.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (i *imageRepo) UpsertImageVariant(ctx context.Context, payload entity.ImageVariant) (err error) {
+	_, err = i.conn(ctx).ExecContext(ctx,
+		`INSERT INTO
+			image_variants (image_id, name, format, url, width, height)
+		VALUES
+			($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (image_id, name, format) DO UPDATE
+		SET
+			url=EXCLUDED.url,
+			width=EXCLUDED.width,
+			height=EXCLUDED.height,
+			updated_at=NOW()`, payload.ImageID, payload.Name, payload.Format, payload.Url, payload.Width, payload.Height)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (i *imageRepo) GetImageVariantsByImageURLs(ctx context.Context, urls []string) (response []entity.ImageVariantDetail, err error) {
+	var variants []entity.ImageVariantDetail
+
+	selectQuery := `
+		SELECT
+			iv.*,
+			i.image_url AS original_url
+		FROM
+			image_variants iv
+		JOIN
+			images i ON i.id = iv.image_id
+		WHERE
+			i.image_url = ANY($1)
+		ORDER BY
+			iv.width ASC, iv.format ASC
+	`
+	err = i.conn(ctx).SelectContext(ctx, &variants, selectQuery, pq.Array(urls))
+	if err != nil {
+		return []entity.ImageVariantDetail{}, err
+	}
+
+	return variants, nil
+}
@@ -0,0 +1,281 @@
+package postgre
+
+import (
+	"context"
+	"ecommerce/model/entity"
+	"ecommerce/repository"
+	sdkSql "ecommerce/utils/sql"
+	"time"
+)
+
+type inventoryRepo struct {
+	baseRepo
+}
+
+// NewInventory is function to initialize inventory repository logic.
+func NewInventory(db sdkSql.DBer) repository.InventoryProvider {
+	return &inventoryRepo{
+		baseRepo: baseRepo{db: db},
+	}
+}
+
+// GetProductStockForUpdate locks the product row until the running transaction ends.
+func (i *inventoryRepo) GetProductStockForUpdate(ctx context.Context, productID int64) (stock int64, err error) {
+	selectQuery := `
+		SELECT
+			stock
+		FROM
+			products
+		WHERE
+			id = $1
+		FOR UPDATE
+	`
+	err = i.conn(ctx).GetContext(ctx, &stock, selectQuery, productID)
+	if err != nil {
+		return 0, err
+	}
+
+	return stock, nil
+}
+
+func (i *inventoryRepo) UpdateProductStock(ctx context.Context, productID int64, stock int64) (err error) {
+	_, err = i.conn(ctx).ExecContext(ctx,
+		`UPDATE
+		products
+	SET
+		stock=$1,
+		updated_at=NOW()
+	WHERE
+		id=$2`, stock, productID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DecreaseProductStock only decreases the stock when enough is left, otherwise sql.ErrNoRows is returned.
+func (i *inventoryRepo) DecreaseProductStock(ctx context.Context, productID int64, quantity int64) (stock int64, err error) {
+	err = i.conn(ctx).GetContext(ctx, &stock,
+		`UPDATE
+		products
+	SET
+		stock=stock - $1,
+		updated_at=NOW()
+	WHERE
+		id=$2
+	AND
+		stock >= $1
+	RETURNING stock`, quantity, productID)
+	if err != nil {
+		return 0, err
+	}
+
+	return stock, nil
+}
+
+func (i *inventoryRepo) IncreaseProductStock(ctx context.Context, productID int64, quantity int64) (stock int64, err error) {
+	err = i.conn(ctx).GetContext(ctx, &stock,
+		`UPDATE
+		products
+	SET
+		stock=stock + $1,
+		updated_at=NOW()
+	WHERE
+		id=$2
+	RETURNING stock`, quantity, productID)
+	if err != nil {
+		return 0, err
+	}
+
+	return stock, nil
+}
+
+// GetVariantStockForUpdate locks the variant row until the running transaction ends.
+func (i *inventoryRepo) GetVariantStockForUpdate(ctx context.Context, productID int64, variantID int64) (stock int64, err error) {
+	selectQuery := `
+		SELECT
+			stock
+		FROM
+			product_variants
+		WHERE
+			id = $1 AND product_id = $2
+		FOR UPDATE
+	`
+	err = i.conn(ctx).GetContext(ctx, &stock, selectQuery, variantID, productID)
+	if err != nil {
+		return 0, err
+	}
+
+	return stock, nil
+}
+
+func (i *inventoryRepo) UpdateVariantStock(ctx context.Context, variantID int64, stock int64) (err error) {
+	_, err = i.conn(ctx).ExecContext(ctx,
+		`UPDATE
+		product_variants
+	SET
+		stock=$1,
+		updated_at=NOW()
+	WHERE
+		id=$2`, stock, variantID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DecreaseVariantStock only decreases the stock when enough is left, otherwise sql.ErrNoRows is returned.
+func (i *inventoryRepo) DecreaseVariantStock(ctx context.Context, variantID int64, quantity int64) (stock int64, err error) {
+	err = i.conn(ctx).GetContext(ctx, &stock,
+		`UPDATE
+		product_variants
+	SET
+		stock=stock - $1,
+		updated_at=NOW()
+	WHERE
+		id=$2
+	AND
+		stock >= $1
+	RETURNING stock`, quantity, variantID)
+	if err != nil {
+		return 0, err
+	}
+
+	return stock, nil
+}
+
+// IncreaseVariantStock returns sql.ErrNoRows when the variant has been deleted.
+func (i *inventoryRepo) IncreaseVariantStock(ctx context.Context, variantID int64, quantity int64) (stock int64, err error) {
+	err = i.conn(ctx).GetContext(ctx, &stock,
+		`UPDATE
+		product_variants
+	SET
+		stock=stock + $1,
+		updated_at=NOW()
+	WHERE
+		id=$2
+	RETURNING stock`, quantity, variantID)
+	if err != nil {
+		return 0, err
+	}
+
+	return stock, nil
+}
+
+func (i *inventoryRepo) CreateInventoryLedger(ctx context.Context, payload entity.InventoryLedger) (err error) {
+	_, err = i.conn(ctx).ExecContext(ctx,
+		`INSERT INTO
+			inventory_ledger (product_id, variant_id, quantity_change, stock_after, reason, reference)
+		VALUES
+			($1, $2, $3, $4, $5, $6)`, payload.ProductID, payload.VariantID, payload.QuantityChange, payload.StockAfter,
+		payload.Reason, payload.Reference)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (i *inventoryRepo) GetInventoryLedgerByProductID(ctx context.Context, productID int64) (response []entity.InventoryLedger, err error) {
+	var ledger []entity.InventoryLedger
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			inventory_ledger
+		WHERE
+			product_id = $1
+		ORDER BY
+			id DESC
+	`
+	err = i.conn(ctx).SelectContext(ctx, &ledger, selectQuery, productID)
+	if err != nil {
+		return []entity.InventoryLedger{}, err
+	}
+
+	return ledger, nil
+}
+
+func (i *inventoryRepo) CreateStockReservation(ctx context.Context, payload entity.StockReservation) (err error) {
+	_, err = i.conn(ctx).ExecContext(ctx,
+		`INSERT INTO
+			stock_reservations (product_id, variant_id, order_id, quantity, status, expires_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6)`, payload.ProductID, payload.VariantID, payload.OrderID, payload.Quantity,
+		payload.Status, payload.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetActiveStockReservationsByOrderID locks the active reservations of the order until the running transaction ends.
+func (i *inventoryRepo) GetActiveStockReservationsByOrderID(ctx context.Context, orderID int64) (response []entity.StockReservation, err error) {
+	var reservations []entity.StockReservation
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			stock_reservations
+		WHERE
+			order_id = $1
+		AND
+			status = $2
+		ORDER BY
+			product_id ASC, variant_id ASC
+		FOR UPDATE
+	`
+	err = i.conn(ctx).SelectContext(ctx, &reservations, selectQuery, orderID, entity.StockReservationStatusActive)
+	if err != nil {
+		return []entity.StockReservation{}, err
+	}
+
+	return reservations, nil
+}
+
+func (i *inventoryRepo) GetExpiredStockReservationOrderIDs(ctx context.Context, now time.Time, limit int) (response []int64, err error) {
+	var orderIDs []int64
+
+	selectQuery := `
+		SELECT
+			order_id
+		FROM
+			stock_reservations
+		WHERE
+			status = $1
+		AND
+			expires_at <= $2
+		GROUP BY
+			order_id
+		ORDER BY
+			MIN(expires_at) ASC
+		LIMIT $3
+	`
+	err = i.conn(ctx).SelectContext(ctx, &orderIDs, selectQuery, entity.StockReservationStatusActive, now, limit)
+	if err != nil {
+		return []int64{}, err
+	}
+
+	return orderIDs, nil
+}
+
+func (i *inventoryRepo) UpdateStockReservationStatus(ctx context.Context, id int64, status string) (err error) {
+	_, err = i.conn(ctx).ExecContext(ctx,
+		`UPDATE
+		stock_reservations
+	SET
+		status=$1,
+		updated_at=NOW()
+	WHERE
+		id=$2`, status, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package postgre
+
+import (
+	"context"
+	"ecommerce/model/entity"
+	"ecommerce/repository"
+	sdkSql "ecommerce/utils/sql"
+)
+
+type invoiceRepo struct {
+	baseRepo
+}
+
+// NewInvoice is function to initialize invoice repository logic.
+func NewInvoice(db sdkSql.DBer) repository.InvoiceProvider {
+	return &invoiceRepo{
+		baseRepo: baseRepo{db: db},
+	}
+}
+
+// NextInvoiceSequence takes the next invoice sequence of a seller. The sequence row stays locked until the running
+// transaction ends and the sequence is given back when it rolls back, so the invoices of a seller have no gaps.
+func (i *invoiceRepo) NextInvoiceSequence(ctx context.Context, sellerID int64) (sequence int64, err error) {
+	err = i.conn(ctx).GetContext(ctx, &sequence,
+		`INSERT INTO
+			invoice_sequences (seller_id, last_sequence)
+		VALUES
+			($1, 1)
+		ON CONFLICT (seller_id) DO UPDATE SET last_sequence = invoice_sequences.last_sequence + 1
+		RETURNING last_sequence`, sellerID)
+	if err != nil {
+		return 0, err
+	}
+
+	return sequence, nil
+}
+
+func (i *invoiceRepo) CreateInvoice(ctx context.Context, payload entity.Invoice) (id int64, err error) {
+	var lastInsertId int64
+	err = i.conn(ctx).GetContext(ctx, &lastInsertId,
+		`INSERT INTO
+			invoices (order_id, seller_id, sequence, number, subtotal, discount, total, currency, issued_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id`, payload.OrderID, payload.SellerID, payload.Sequence, payload.Number, payload.Subtotal,
+		payload.Discount, payload.Total, payload.Currency, payload.IssuedAt)
+	if err != nil {
+		return 0, err
+	}
+
+	return lastInsertId, nil
+}
+
+func (i *invoiceRepo) GetInvoicesByOrderID(ctx context.Context, orderID int64) (response []entity.Invoice, err error) {
+	var invoices []entity.Invoice
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			invoices
+		WHERE
+			order_id = $1
+		ORDER BY
+			seller_id ASC
+	`
+	err = i.conn(ctx).SelectContext(ctx, &invoices, selectQuery, orderID)
+	if err != nil {
+		return []entity.Invoice{}, err
+	}
+
+	return invoices, nil
+}
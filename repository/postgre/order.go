@@ -0,0 +1,160 @@
+package postgre
+
+import (
+	"context"
+	"ecommerce/model/entity"
+	"ecommerce/repository"
+	sdkSql "ecommerce/utils/sql"
+)
+
+type orderRepo struct {
+	baseRepo
+}
+
+// NewOrder is function to initialize order repository logic.
+func NewOrder(db sdkSql.DBer) repository.OrderProvider {
+	return &orderRepo{
+		baseRepo: baseRepo{db: db},
+	}
+}
+
+func (o *orderRepo) CreateOrder(ctx context.Context, payload entity.Order) (id int64, err error) {
+	var lastInsertId int64
+	err = o.conn(ctx).GetContext(ctx, &lastInsertId,
+		`INSERT INTO
+			orders (user_id, status, total_price, discount, currency, total_weight)
+		VALUES
+			($1, $2, $3, $4, $5, $6)
+		RETURNING id`, payload.UserID, payload.Status, payload.TotalPrice, payload.Discount, payload.Currency,
+		payload.TotalWeight)
+	if err != nil {
+		return 0, err
+	}
+
+	return lastInsertId, nil
+}
+
+func (o *orderRepo) CreateOrderItem(ctx context.Context, payload entity.OrderItem) (err error) {
+	_, err = o.conn(ctx).ExecContext(ctx,
+		`INSERT INTO
+			order_items (order_id, product_id, variant_id, seller_id, sku, title, price, quantity, discount, weight)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`, payload.OrderID, payload.ProductID, payload.VariantID,
+		payload.SellerID, payload.Sku, payload.Title, payload.Price, payload.Quantity, payload.Discount, payload.Weight)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *orderRepo) GetOrderByID(ctx context.Context, id int64) (response entity.Order, err error) {
+	var order entity.Order
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			orders
+		WHERE
+			id = $1
+	`
+	err = o.conn(ctx).GetContext(ctx, &order, selectQuery, id)
+	if err != nil {
+		return entity.Order{}, err
+	}
+
+	return order, nil
+}
+
+// GetOrderByIDForUpdate locks the order row until the running transaction ends.
+func (o *orderRepo) GetOrderByIDForUpdate(ctx context.Context, id int64) (response entity.Order, err error) {
+	var order entity.Order
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			orders
+		WHERE
+			id = $1
+		FOR UPDATE
+	`
+	err = o.conn(ctx).GetContext(ctx, &order, selectQuery, id)
+	if err != nil {
+		return entity.Order{}, err
+	}
+
+	return order, nil
+}
+
+func (o *orderRepo) GetOrderItemsByOrderID(ctx context.Context, orderID int64) (response []entity.OrderItem, err error) {
+	var orderItems []entity.OrderItem
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			order_items
+		WHERE
+			order_id = $1
+		ORDER BY
+			id ASC
+	`
+	err = o.conn(ctx).SelectContext(ctx, &orderItems, selectQuery, orderID)
+	if err != nil {
+		return []entity.OrderItem{}, err
+	}
+
+	return orderItems, nil
+}
+
+func (o *orderRepo) UpdateOrderStatus(ctx context.Context, id int64, status string) (err error) {
+	_, err = o.conn(ctx).ExecContext(ctx,
+		`UPDATE
+		orders
+	SET
+		status=$1,
+		updated_at=NOW()
+	WHERE
+		id=$2`, status, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *orderRepo) CreateOrderStatusHistory(ctx context.Context, payload entity.OrderStatusHistory) (err error) {
+	_, err = o.conn(ctx).ExecContext(ctx,
+		`INSERT INTO
+			order_status_history (order_id, from_status, to_status)
+		VALUES
+			($1, $2, $3)`, payload.OrderID, payload.FromStatus, payload.ToStatus)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *orderRepo) GetOrderStatusHistoryByOrderID(ctx context.Context, orderID int64) (response []entity.OrderStatusHistory, err error) {
+	var histories []entity.OrderStatusHistory
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			order_status_history
+		WHERE
+			order_id = $1
+		ORDER BY
+			id ASC
+	`
+	err = o.conn(ctx).SelectContext(ctx, &histories, selectQuery, orderID)
+	if err != nil {
+		return []entity.OrderStatusHistory{}, err
+	}
+
+	return histories, nil
+}
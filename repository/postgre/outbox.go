@@ -0,0 +1,90 @@
+package postgre
+
+import (
+	"context"
+	"ecommerce/model/entity"
+	"ecommerce/repository"
+	sdkSql "ecommerce/utils/sql"
+)
+
+type outboxRepo struct {
+	baseRepo
+}
+
+// NewOutbox is function to initialize outbox repository logic.
+func NewOutbox(db sdkSql.DBer) repository.OutboxProvider {
+	return &outboxRepo{
+		baseRepo: baseRepo{db: db},
+	}
+}
+
+func (o *outboxRepo) CreateOutboxEvent(ctx context.Context, payload entity.OutboxEvent) (id int64, err error) {
+	var lastInsertId int64
+	err = o.conn(ctx).GetContext(ctx, &lastInsertId,
+		`INSERT INTO
+			outbox_events (aggregate_type, aggregate_id, type, payload)
+		VALUES
+			($1, $2, $3, $4)
+		RETURNING id`, payload.AggregateType, payload.AggregateID, payload.Type, string(payload.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	return lastInsertId, nil
+}
+
+// ClaimOutboxEvents locks up to limit unpublished events, the oldest first, until the running transaction ends.
+// Events locked by another relay are skipped.
+func (o *outboxRepo) ClaimOutboxEvents(ctx context.Context, limit int) (response []entity.OutboxEvent, err error) {
+	var events []entity.OutboxEvent
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			outbox_events
+		WHERE
+			published_at IS NULL
+		ORDER BY
+			id ASC
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`
+	err = o.conn(ctx).SelectContext(ctx, &events, selectQuery, limit)
+	if err != nil {
+		return []entity.OutboxEvent{}, err
+	}
+
+	return events, nil
+}
+
+func (o *outboxRepo) MarkOutboxEventPublished(ctx context.Context, id int64) (err error) {
+	_, err = o.conn(ctx).ExecContext(ctx,
+		`UPDATE
+		outbox_events
+	SET
+		published_at=NOW()
+	WHERE
+		id=$1`, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *outboxRepo) MarkOutboxEventFailed(ctx context.Context, id int64, lastError string) (err error) {
+	_, err = o.conn(ctx).ExecContext(ctx,
+		`UPDATE
+		outbox_events
+	SET
+		attempts=attempts + 1,
+		last_error=$1
+	WHERE
+		id=$2`, lastError, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,201 @@
+package postgre
+
+import (
+	"context"
+	"database/sql"
+	"ecommerce/model/entity"
+	"ecommerce/repository"
+	sdkSql "ecommerce/utils/sql"
+	"errors"
+)
+
+type paymentRepo struct {
+	baseRepo
+}
+
+// NewPayment is function to initialize payment repository logic.
+func NewPayment(db sdkSql.DBer) repository.PaymentProvider {
+	return &paymentRepo{
+		baseRepo: baseRepo{db: db},
+	}
+}
+
+func (p *paymentRepo) CreatePayment(ctx context.Context, payload entity.Payment) (id int64, err error) {
+	var lastInsertId int64
+	err = p.conn(ctx).GetContext(ctx, &lastInsertId,
+		`INSERT INTO
+			payments (order_id, gateway, charge_id, amount, currency, status, payment_url)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`, payload.OrderID, payload.Gateway, payload.ChargeID, payload.Amount, payload.Currency,
+		payload.Status, payload.PaymentUrl)
+	if err != nil {
+		return 0, err
+	}
+
+	return lastInsertId, nil
+}
+
+func (p *paymentRepo) GetPaymentsByOrderID(ctx context.Context, orderID int64) (response []entity.Payment, err error) {
+	var payments []entity.Payment
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			payments
+		WHERE
+			order_id = $1
+		ORDER BY
+			id ASC
+	`
+	err = p.conn(ctx).SelectContext(ctx, &payments, selectQuery, orderID)
+	if err != nil {
+		return []entity.Payment{}, err
+	}
+
+	return payments, nil
+}
+
+// GetPaymentByChargeIDForUpdate locks the payment row until the running transaction ends.
+func (p *paymentRepo) GetPaymentByChargeIDForUpdate(ctx context.Context, gateway string, chargeID string) (response entity.Payment, err error) {
+	var payment entity.Payment
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			payments
+		WHERE
+			gateway = $1 AND charge_id = $2
+		FOR UPDATE
+	`
+	err = p.conn(ctx).GetContext(ctx, &payment, selectQuery, gateway, chargeID)
+	if err != nil {
+		return entity.Payment{}, err
+	}
+
+	return payment, nil
+}
+
+func (p *paymentRepo) UpdatePaymentStatus(ctx context.Context, id int64, status string) (err error) {
+	_, err = p.conn(ctx).ExecContext(ctx,
+		`UPDATE
+		payments
+	SET
+		status=$1,
+		updated_at=NOW()
+	WHERE
+		id=$2`, status, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdatePaymentCharge sets the charge created at the gateway for a payment which was only recorded so far.
+func (p *paymentRepo) UpdatePaymentCharge(ctx context.Context, id int64, charge entity.PaymentCharge) (err error) {
+	_, err = p.conn(ctx).ExecContext(ctx,
+		`UPDATE
+		payments
+	SET
+		charge_id=$1,
+		status=$2,
+		payment_url=$3,
+		updated_at=NOW()
+	WHERE
+		id=$4 AND status=$5`, charge.ID, charge.Status, charge.PaymentUrl, id, entity.PaymentStatusCreated)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *paymentRepo) UpdatePaymentRefundedAmount(ctx context.Context, id int64, refundedAmount int64, status string) (err error) {
+	_, err = p.conn(ctx).ExecContext(ctx,
+		`UPDATE
+		payments
+	SET
+		refunded_amount=$1,
+		status=$2,
+		updated_at=NOW()
+	WHERE
+		id=$3`, refundedAmount, status, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *paymentRepo) CreateRefund(ctx context.Context, payload entity.Refund) (err error) {
+	_, err = p.conn(ctx).ExecContext(ctx,
+		`INSERT INTO
+			refunds (order_id, payment_id, return_request_id, amount, status)
+		VALUES
+			($1, $2, $3, $4, $5)`, payload.OrderID, payload.PaymentID, payload.ReturnRequestID, payload.Amount, payload.Status)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *paymentRepo) GetPendingRefundsByOrderID(ctx context.Context, orderID int64) (response []entity.Refund, err error) {
+	var refunds []entity.Refund
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			refunds
+		WHERE
+			order_id = $1 AND status = $2
+		ORDER BY
+			id ASC
+	`
+	err = p.conn(ctx).SelectContext(ctx, &refunds, selectQuery, orderID, entity.RefundStatusPending)
+	if err != nil {
+		return []entity.Refund{}, err
+	}
+
+	return refunds, nil
+}
+
+func (p *paymentRepo) UpdateRefundStatus(ctx context.Context, id int64, status string) (err error) {
+	_, err = p.conn(ctx).ExecContext(ctx,
+		`UPDATE
+		refunds
+	SET
+		status=$1,
+		updated_at=NOW()
+	WHERE
+		id=$2`, status, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CreatePaymentEvent records a webhook event, created is false when the event was already recorded.
+func (p *paymentRepo) CreatePaymentEvent(ctx context.Context, payload entity.PaymentEvent) (created bool, err error) {
+	var id int64
+	err = p.conn(ctx).GetContext(ctx, &id,
+		`INSERT INTO
+			payment_events (gateway, event_id, payment_id, type, payload)
+		VALUES
+			($1, $2, $3, $4, $5)
+		ON CONFLICT (gateway, event_id) DO NOTHING
+		RETURNING id`, payload.Gateway, payload.EventID, payload.PaymentID, payload.Type, string(payload.Payload))
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,75 @@
+package postgre
+
+import (
+	"context"
+	"ecommerce/model/entity"
+	"ecommerce/repository"
+	sdkSql "ecommerce/utils/sql"
+)
+
+type productImportRepo struct {
+	baseRepo
+}
+
+// NewProductImport is function to initialize product import repository logic.
+func NewProductImport(db sdkSql.DBer) repository.ProductImportProvider {
+	return &productImportRepo{
+		baseRepo: baseRepo{db: db},
+	}
+}
+
+func (p *productImportRepo) CreateProductImport(ctx context.Context, payload entity.ProductImport) (id int64, err error) {
+	var lastInsertId int64
+	err = p.conn(ctx).GetContext(ctx, &lastInsertId,
+		`INSERT INTO
+			product_imports (user_id, format, status)
+		VALUES
+			($1, $2, $3)
+		RETURNING id`, payload.UserID, payload.Format, payload.Status)
+	if err != nil {
+		return 0, err
+	}
+
+	return lastInsertId, nil
+}
+
+func (p *productImportRepo) UpdateProductImport(ctx context.Context, payload entity.ProductImport) (err error) {
+	_, err = p.conn(ctx).ExecContext(ctx,
+		`UPDATE
+			product_imports
+		SET
+			status=$1,
+			total_rows=$2,
+			created_rows=$3,
+			updated_rows=$4,
+			failed_rows=$5,
+			report_key=$6,
+			updated_at=NOW()
+		WHERE
+			id=$7`, payload.Status, payload.TotalRows, payload.CreatedRows, payload.UpdatedRows, payload.FailedRows,
+		payload.ReportKey, payload.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *productImportRepo) GetProductImportByID(ctx context.Context, id int64) (response entity.ProductImport, err error) {
+	var productImport entity.ProductImport
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			product_imports
+		WHERE
+			id = $1
+	`
+	err = p.conn(ctx).GetContext(ctx, &productImport, selectQuery, id)
+	if err != nil {
+		return entity.ProductImport{}, err
+	}
+
+	return productImport, nil
+}
@@ -0,0 +1,85 @@
+package postgre
+
+import (
+	"context"
+	"database/sql"
+	"ecommerce/model/entity"
+	"ecommerce/repository"
+	sdkSql "ecommerce/utils/sql"
+)
+
+type productPriceRepo struct {
+	baseRepo
+}
+
+// NewProductPrice is function to initialize product price repository logic.
+func NewProductPrice(db sdkSql.DBer) repository.ProductPriceProvider {
+	return &productPriceRepo{
+		baseRepo: baseRepo{db: db},
+	}
+}
+
+// GetProductPricesByProductID returns the prices of a product, the latest starting first.
+func (p *productPriceRepo) GetProductPricesByProductID(ctx context.Context, productID int64) (response []entity.ProductPrice, err error) {
+	var prices []entity.ProductPrice
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			product_prices
+		WHERE
+			product_id = $1
+		ORDER BY
+			effective_from DESC, id DESC
+	`
+	err = p.conn(ctx).SelectContext(ctx, &prices, selectQuery, productID)
+	if err != nil {
+		return []entity.ProductPrice{}, err
+	}
+
+	return prices, nil
+}
+
+func (p *productPriceRepo) CreateProductPrice(ctx context.Context, payload entity.ProductPrice) (id int64, err error) {
+	var lastInsertId int64
+	err = p.conn(ctx).GetContext(ctx, &lastInsertId,
+		`INSERT INTO
+			product_prices (product_id, kind, price, effective_from, effective_to)
+		VALUES
+			($1, $2, $3, $4, $5)
+		RETURNING id`, payload.ProductID, payload.Kind, payload.Price, payload.EffectiveFrom, payload.EffectiveTo)
+	if err != nil {
+		return 0, err
+	}
+
+	return lastInsertId, nil
+}
+
+func (p *productPriceRepo) UpdateProductPriceEffectiveTo(ctx context.Context, id int64, effectiveTo sql.NullTime) (err error) {
+	_, err = p.conn(ctx).ExecContext(ctx,
+		`UPDATE
+			product_prices
+		SET
+			effective_to=$1
+		WHERE
+			id=$2`, effectiveTo, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *productPriceRepo) DeleteProductPrice(ctx context.Context, id int64) (err error) {
+	_, err = p.conn(ctx).ExecContext(ctx,
+		`DELETE FROM
+			product_prices
+		WHERE
+			id=$1`, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
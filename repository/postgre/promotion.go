@@ -0,0 +1,269 @@
+package postgre
+
+import (
+	"context"
+	"ecommerce/model/entity"
+	"ecommerce/repository"
+	sdkSql "ecommerce/utils/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type promotionRepo struct {
+	baseRepo
+}
+
+// NewPromotion is function to initialize promotion repository logic.
+func NewPromotion(db sdkSql.DBer) repository.PromotionProvider {
+	return &promotionRepo{
+		baseRepo: baseRepo{db: db},
+	}
+}
+
+func (p *promotionRepo) CountPromotions(ctx context.Context) (total int64, err error) {
+	selectQuery := `
+		SELECT
+			COUNT(*)
+		FROM
+			promotions
+	`
+	err = p.conn(ctx).GetContext(ctx, &total, selectQuery)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// GetPromotions returns the promotions, the latest first.
+func (p *promotionRepo) GetPromotions(ctx context.Context, limit int64, offset int64) (response []entity.Promotion, err error) {
+	var promotions []entity.Promotion
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			promotions
+		ORDER BY
+			id DESC
+		LIMIT $1
+		OFFSET $2
+	`
+	err = p.conn(ctx).SelectContext(ctx, &promotions, selectQuery, limit, offset)
+	if err != nil {
+		return []entity.Promotion{}, err
+	}
+
+	return promotions, nil
+}
+
+func (p *promotionRepo) GetPromotionByID(ctx context.Context, id int64) (response entity.Promotion, err error) {
+	var promotion entity.Promotion
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			promotions
+		WHERE
+			id = $1
+	`
+	err = p.conn(ctx).GetContext(ctx, &promotion, selectQuery, id)
+	if err != nil {
+		return entity.Promotion{}, err
+	}
+
+	return promotion, nil
+}
+
+// GetApplicablePromotions returns the active automatic promotions running at now, and the promotions of codes
+// whatever their state so the caller can tell why a code can't be used. Codes are matched case insensitively and
+// should be upper case.
+func (p *promotionRepo) GetApplicablePromotions(ctx context.Context, codes []string, now time.Time) (response []entity.Promotion, err error) {
+	var promotions []entity.Promotion
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			promotions
+		WHERE
+			(code = '' AND is_active AND starts_at <= $2 AND (ends_at IS NULL OR ends_at > $2))
+			OR upper(code) = ANY($1)
+		ORDER BY
+			id ASC
+	`
+	err = p.conn(ctx).SelectContext(ctx, &promotions, selectQuery, pq.Array(codes), now)
+	if err != nil {
+		return []entity.Promotion{}, err
+	}
+
+	return promotions, nil
+}
+
+func (p *promotionRepo) CreatePromotion(ctx context.Context, payload entity.Promotion) (id int64, err error) {
+	var lastInsertId int64
+	err = p.conn(ctx).GetContext(ctx, &lastInsertId,
+		`INSERT INTO
+			promotions (code, name, type, value, max_discount, min_spend, currency, usage_limit, per_user_limit, scope,
+				scope_ids, starts_at, ends_at, is_active)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING id`, payload.Code, payload.Name, payload.Type, payload.Value, payload.MaxDiscount, payload.MinSpend,
+		payload.Currency, payload.UsageLimit, payload.PerUserLimit, payload.Scope, payload.ScopeIDs, payload.StartsAt,
+		payload.EndsAt, payload.IsActive)
+	if err != nil {
+		return 0, err
+	}
+
+	return lastInsertId, nil
+}
+
+func (p *promotionRepo) UpdatePromotion(ctx context.Context, payload entity.Promotion) (err error) {
+	_, err = p.conn(ctx).ExecContext(ctx,
+		`UPDATE
+		promotions
+	SET
+		code=$1,
+		name=$2,
+		type=$3,
+		value=$4,
+		max_discount=$5,
+		min_spend=$6,
+		currency=$7,
+		usage_limit=$8,
+		per_user_limit=$9,
+		scope=$10,
+		scope_ids=$11,
+		starts_at=$12,
+		ends_at=$13,
+		is_active=$14,
+		updated_at=NOW()
+	WHERE
+		id=$15`, payload.Code, payload.Name, payload.Type, payload.Value, payload.MaxDiscount, payload.MinSpend,
+		payload.Currency, payload.UsageLimit, payload.PerUserLimit, payload.Scope, payload.ScopeIDs, payload.StartsAt,
+		payload.EndsAt, payload.IsActive, payload.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *promotionRepo) DeletePromotion(ctx context.Context, id int64) (err error) {
+	query := `
+	DELETE FROM
+		promotions
+	WHERE
+		id = $1`
+
+	_, err = p.conn(ctx).ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// IncrementPromotionUsage counts a use of the promotion, the conditional update keeps it within its usage limit. It
+// returns sql.ErrNoRows when the limit is already reached.
+func (p *promotionRepo) IncrementPromotionUsage(ctx context.Context, id int64) (err error) {
+	var usageCount int64
+	err = p.conn(ctx).GetContext(ctx, &usageCount,
+		`UPDATE
+		promotions
+	SET
+		usage_count=usage_count + 1,
+		updated_at=NOW()
+	WHERE
+		id=$1 AND (usage_limit = 0 OR usage_count < usage_limit)
+	RETURNING usage_count`, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *promotionRepo) DecrementPromotionUsage(ctx context.Context, id int64) (err error) {
+	_, err = p.conn(ctx).ExecContext(ctx,
+		`UPDATE
+		promotions
+	SET
+		usage_count=GREATEST(usage_count - 1, 0),
+		updated_at=NOW()
+	WHERE
+		id=$1`, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *promotionRepo) CountPromotionUsagesByUserID(ctx context.Context, promotionID int64, userID int64) (total int64, err error) {
+	selectQuery := `
+		SELECT
+			COUNT(*)
+		FROM
+			promotion_usages
+		WHERE
+			promotion_id = $1 AND user_id = $2
+	`
+	err = p.conn(ctx).GetContext(ctx, &total, selectQuery, promotionID, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (p *promotionRepo) CreatePromotionUsage(ctx context.Context, payload entity.PromotionUsage) (err error) {
+	_, err = p.conn(ctx).ExecContext(ctx,
+		`INSERT INTO
+			promotion_usages (promotion_id, user_id, order_id, discount)
+		VALUES
+			($1, $2, $3, $4)`, payload.PromotionID, payload.UserID, payload.OrderID, payload.Discount)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *promotionRepo) GetPromotionUsagesByOrderID(ctx context.Context, orderID int64) (response []entity.PromotionUsage, err error) {
+	var usages []entity.PromotionUsage
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			promotion_usages
+		WHERE
+			order_id = $1
+		ORDER BY
+			id ASC
+	`
+	err = p.conn(ctx).SelectContext(ctx, &usages, selectQuery, orderID)
+	if err != nil {
+		return []entity.PromotionUsage{}, err
+	}
+
+	return usages, nil
+}
+
+func (p *promotionRepo) DeletePromotionUsagesByOrderID(ctx context.Context, orderID int64) (err error) {
+	query := `
+	DELETE FROM
+		promotion_usages
+	WHERE
+		order_id = $1`
+
+	_, err = p.conn(ctx).ExecContext(ctx, query, orderID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
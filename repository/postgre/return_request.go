@@ -0,0 +1,154 @@
+package postgre
+
+import (
+	"context"
+	"ecommerce/model/entity"
+	"ecommerce/repository"
+	sdkSql "ecommerce/utils/sql"
+)
+
+type returnRequestRepo struct {
+	baseRepo
+}
+
+// NewReturnRequest is function to initialize return request repository logic.
+func NewReturnRequest(db sdkSql.DBer) repository.ReturnRequestProvider {
+	return &returnRequestRepo{
+		baseRepo: baseRepo{db: db},
+	}
+}
+
+func (r *returnRequestRepo) CreateReturnRequest(ctx context.Context, payload entity.ReturnRequest) (id int64, err error) {
+	var lastInsertId int64
+	err = r.conn(ctx).GetContext(ctx, &lastInsertId,
+		`INSERT INTO
+			return_requests (order_id, order_item_id, product_id, variant_id, seller_id, user_id, quantity, reason, photo_urls, status)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id`, payload.OrderID, payload.OrderItemID, payload.ProductID, payload.VariantID, payload.SellerID,
+		payload.UserID, payload.Quantity, payload.Reason, payload.PhotoUrls, payload.Status)
+	if err != nil {
+		return 0, err
+	}
+
+	return lastInsertId, nil
+}
+
+// GetReturnRequestByIDForUpdate locks the return request row until the running transaction ends.
+func (r *returnRequestRepo) GetReturnRequestByIDForUpdate(ctx context.Context, id int64) (response entity.ReturnRequest, err error) {
+	var returnRequest entity.ReturnRequest
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			return_requests
+		WHERE
+			id = $1
+		FOR UPDATE
+	`
+	err = r.conn(ctx).GetContext(ctx, &returnRequest, selectQuery, id)
+	if err != nil {
+		return entity.ReturnRequest{}, err
+	}
+
+	return returnRequest, nil
+}
+
+func (r *returnRequestRepo) GetReturnRequestsByOrderID(ctx context.Context, orderID int64) (response []entity.ReturnRequest, err error) {
+	var returnRequests []entity.ReturnRequest
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			return_requests
+		WHERE
+			order_id = $1
+		ORDER BY
+			id ASC
+	`
+	err = r.conn(ctx).SelectContext(ctx, &returnRequests, selectQuery, orderID)
+	if err != nil {
+		return []entity.ReturnRequest{}, err
+	}
+
+	return returnRequests, nil
+}
+
+func (r *returnRequestRepo) CountReturnRequestsBySellerID(ctx context.Context, sellerID int64) (total int64, err error) {
+	selectQuery := `
+		SELECT
+			COUNT(*)
+		FROM
+			return_requests
+		WHERE
+			seller_id = $1
+	`
+	err = r.conn(ctx).GetContext(ctx, &total, selectQuery, sellerID)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// GetReturnRequestsBySellerID returns the return requests of the products of a seller, the latest first.
+func (r *returnRequestRepo) GetReturnRequestsBySellerID(ctx context.Context, sellerID int64, limit int64, offset int64) (response []entity.ReturnRequest, err error) {
+	var returnRequests []entity.ReturnRequest
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			return_requests
+		WHERE
+			seller_id = $1
+		ORDER BY
+			id DESC
+		LIMIT $2
+		OFFSET $3
+	`
+	err = r.conn(ctx).SelectContext(ctx, &returnRequests, selectQuery, sellerID, limit, offset)
+	if err != nil {
+		return []entity.ReturnRequest{}, err
+	}
+
+	return returnRequests, nil
+}
+
+// SumReturnedQuantity returns the quantity of an order line requested for return, rejected returns are left out.
+func (r *returnRequestRepo) SumReturnedQuantity(ctx context.Context, orderItemID int64) (quantity int64, err error) {
+	selectQuery := `
+		SELECT
+			COALESCE(SUM(quantity), 0)
+		FROM
+			return_requests
+		WHERE
+			order_item_id = $1 AND status <> 'rejected'
+	`
+	err = r.conn(ctx).GetContext(ctx, &quantity, selectQuery, orderItemID)
+	if err != nil {
+		return 0, err
+	}
+
+	return quantity, nil
+}
+
+func (r *returnRequestRepo) UpdateReturnRequest(ctx context.Context, payload entity.ReturnRequest) (err error) {
+	_, err = r.conn(ctx).ExecContext(ctx,
+		`UPDATE
+		return_requests
+	SET
+		status=$1,
+		seller_note=$2,
+		refund_amount=$3,
+		updated_at=NOW()
+	WHERE
+		id=$4`, payload.Status, payload.SellerNote, payload.RefundAmount, payload.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
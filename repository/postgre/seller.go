@@ -0,0 +1,77 @@
+package postgre
+
+import (
+	"context"
+	"ecommerce/model/entity"
+	"ecommerce/repository"
+	sdkSql "ecommerce/utils/sql"
+)
+
+type sellerRepo struct {
+	baseRepo
+}
+
+// NewSeller is function to initialize seller repository logic.
+func NewSeller(db sdkSql.DBer) repository.SellerProvider {
+	return &sellerRepo{
+		baseRepo: baseRepo{db: db},
+	}
+}
+
+func (s *sellerRepo) GetSellerByUserID(ctx context.Context, userID int64) (response entity.Seller, err error) {
+	var seller entity.Seller
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			sellers
+		WHERE
+			user_id = $1
+	`
+	err = s.conn(ctx).GetContext(ctx, &seller, selectQuery, userID)
+	if err != nil {
+		return entity.Seller{}, err
+	}
+
+	return seller, nil
+}
+
+func (s *sellerRepo) UpsertSeller(ctx context.Context, payload entity.Seller) (err error) {
+	_, err = s.conn(ctx).ExecContext(ctx,
+		`INSERT INTO
+			sellers (user_id, name, description, city, avatar_url)
+		VALUES
+			($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id) DO UPDATE SET
+			name=EXCLUDED.name,
+			description=EXCLUDED.description,
+			city=EXCLUDED.city,
+			avatar_url=EXCLUDED.avatar_url,
+			updated_at=NOW()`, payload.UserID, payload.Name, payload.Description, payload.City, payload.AvatarUrl)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *sellerRepo) GetSellerProductStats(ctx context.Context, userID int64) (response entity.SellerProductStats, err error) {
+	var stats entity.SellerProductStats
+
+	selectQuery := `
+		SELECT
+			COUNT(*) AS product_count,
+			COALESCE(AVG(rating), 0) AS average_rating
+		FROM
+			products
+		WHERE
+			user_id = $1
+	`
+	err = s.conn(ctx).GetContext(ctx, &stats, selectQuery, userID)
+	if err != nil {
+		return entity.SellerProductStats{}, err
+	}
+
+	return stats, nil
+}
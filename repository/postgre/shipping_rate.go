@@ -0,0 +1,57 @@
+package postgre
+
+import (
+	"context"
+	"ecommerce/model/entity"
+	"ecommerce/repository"
+	sdkSql "ecommerce/utils/sql"
+)
+
+type shippingRateRepo struct {
+	baseRepo
+}
+
+// NewShippingRate is function to initialize shipping rate repository logic.
+func NewShippingRate(db sdkSql.DBer) repository.ShippingRateProvider {
+	return &shippingRateRepo{
+		baseRepo: baseRepo{db: db},
+	}
+}
+
+func (s *shippingRateRepo) GetShippingZones(ctx context.Context) (response []entity.ShippingZone, err error) {
+	var zones []entity.ShippingZone
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			shipping_zones
+		ORDER BY
+			id ASC
+	`
+	err = s.conn(ctx).SelectContext(ctx, &zones, selectQuery)
+	if err != nil {
+		return []entity.ShippingZone{}, err
+	}
+
+	return zones, nil
+}
+
+func (s *shippingRateRepo) GetShippingRates(ctx context.Context) (response []entity.ShippingRate, err error) {
+	var rates []entity.ShippingRate
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			shipping_rates
+		ORDER BY
+			zone ASC, courier ASC, service ASC, min_weight ASC
+	`
+	err = s.conn(ctx).SelectContext(ctx, &rates, selectQuery)
+	if err != nil {
+		return []entity.ShippingRate{}, err
+	}
+
+	return rates, nil
+}
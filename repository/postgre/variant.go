@@ -0,0 +1,251 @@
+package postgre
+
+import (
+	"context"
+	"ecommerce/model/entity"
+	"ecommerce/repository"
+	sdkSql "ecommerce/utils/sql"
+
+	"github.com/lib/pq"
+)
+
+type variantRepo struct {
+	baseRepo
+}
+
+// NewVariant is function to initialize product variant repository logic.
+func NewVariant(db sdkSql.DBer) repository.VariantProvider {
+	return &variantRepo{
+		baseRepo: baseRepo{db: db},
+	}
+}
+
+func (v *variantRepo) GetProductOptionsByProductID(ctx context.Context, productID int64) (response []entity.ProductOption, err error) {
+	var options []entity.ProductOption
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			product_options
+		WHERE
+			product_id = $1
+		ORDER BY
+			position ASC
+	`
+	err = v.conn(ctx).SelectContext(ctx, &options, selectQuery, productID)
+	if err != nil {
+		return []entity.ProductOption{}, err
+	}
+
+	return options, nil
+}
+
+func (v *variantRepo) GetProductOptionValuesByProductID(ctx context.Context, productID int64) (response []entity.ProductOptionValue, err error) {
+	var optionValues []entity.ProductOptionValue
+
+	selectQuery := `
+		SELECT
+			ov.*
+		FROM
+			product_option_values ov
+		JOIN
+			product_options o ON o.id = ov.option_id
+		WHERE
+			o.product_id = $1
+		ORDER BY
+			o.position ASC, ov.position ASC
+	`
+	err = v.conn(ctx).SelectContext(ctx, &optionValues, selectQuery, productID)
+	if err != nil {
+		return []entity.ProductOptionValue{}, err
+	}
+
+	return optionValues, nil
+}
+
+func (v *variantRepo) GetProductVariantsByProductID(ctx context.Context, productID int64) (response []entity.ProductVariant, err error) {
+	var variants []entity.ProductVariant
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			product_variants
+		WHERE
+			product_id = $1
+		ORDER BY
+			id ASC
+	`
+	err = v.conn(ctx).SelectContext(ctx, &variants, selectQuery, productID)
+	if err != nil {
+		return []entity.ProductVariant{}, err
+	}
+
+	return variants, nil
+}
+
+func (v *variantRepo) GetProductVariantByID(ctx context.Context, productID int64, id int64) (response entity.ProductVariant, err error) {
+	var variant entity.ProductVariant
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			product_variants
+		WHERE
+			id = $1 AND product_id = $2
+	`
+	err = v.conn(ctx).GetContext(ctx, &variant, selectQuery, id, productID)
+	if err != nil {
+		return entity.ProductVariant{}, err
+	}
+
+	return variant, nil
+}
+
+func (v *variantRepo) GetProductVariantOptionValuesByProductID(ctx context.Context, productID int64) (response []entity.ProductVariantOptionValue, err error) {
+	var variantOptionValues []entity.ProductVariantOptionValue
+
+	selectQuery := `
+		SELECT
+			vov.variant_id,
+			o.name AS option_name,
+			ov.value
+		FROM
+			product_variant_option_values vov
+		JOIN
+			product_option_values ov ON ov.id = vov.option_value_id
+		JOIN
+			product_options o ON o.id = ov.option_id
+		WHERE
+			o.product_id = $1
+		ORDER BY
+			o.position ASC
+	`
+	err = v.conn(ctx).SelectContext(ctx, &variantOptionValues, selectQuery, productID)
+	if err != nil {
+		return []entity.ProductVariantOptionValue{}, err
+	}
+
+	return variantOptionValues, nil
+}
+
+func (v *variantRepo) CreateProductOption(ctx context.Context, payload entity.ProductOption) (id int64, err error) {
+	var lastInsertId int64
+	err = v.conn(ctx).GetContext(ctx, &lastInsertId,
+		`INSERT INTO
+			product_options (product_id, name, position)
+		VALUES
+			($1, $2, $3)
+		RETURNING id`, payload.ProductID, payload.Name, payload.Position)
+	if err != nil {
+		return 0, err
+	}
+
+	return lastInsertId, nil
+}
+
+func (v *variantRepo) CreateProductOptionValue(ctx context.Context, payload entity.ProductOptionValue) (id int64, err error) {
+	var lastInsertId int64
+	err = v.conn(ctx).GetContext(ctx, &lastInsertId,
+		`INSERT INTO
+			product_option_values (option_id, value, position)
+		VALUES
+			($1, $2, $3)
+		RETURNING id`, payload.OptionID, payload.Value, payload.Position)
+	if err != nil {
+		return 0, err
+	}
+
+	return lastInsertId, nil
+}
+
+// DeleteProductOptionsByProductID deletes the options of a product along with their values and variant links.
+func (v *variantRepo) DeleteProductOptionsByProductID(ctx context.Context, productID int64) (err error) {
+	query := `
+	DELETE FROM
+		product_variant_option_values
+	WHERE
+		variant_id IN (SELECT id FROM product_variants WHERE product_id = $1)`
+
+	_, err = v.conn(ctx).ExecContext(ctx, query, productID)
+	if err != nil {
+		return err
+	}
+
+	query = `
+	DELETE FROM
+		product_option_values
+	WHERE
+		option_id IN (SELECT id FROM product_options WHERE product_id = $1)`
+
+	_, err = v.conn(ctx).ExecContext(ctx, query, productID)
+	if err != nil {
+		return err
+	}
+
+	query = `
+	DELETE FROM
+		product_options
+	WHERE
+		product_id = $1`
+
+	_, err = v.conn(ctx).ExecContext(ctx, query, productID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpsertProductVariant creates the variant or updates the one having the same sku within the product. Stock is only
+// set on a new variant, the stock of an existing one moves through the inventory ledger.
+func (v *variantRepo) UpsertProductVariant(ctx context.Context, payload entity.ProductVariant) (id int64, err error) {
+	var lastInsertId int64
+	err = v.conn(ctx).GetContext(ctx, &lastInsertId,
+		`INSERT INTO
+			product_variants (product_id, sku, price, weight, stock)
+		VALUES
+			($1, $2, $3, $4, $5)
+		ON CONFLICT (product_id, sku) DO UPDATE SET
+			price=EXCLUDED.price,
+			weight=EXCLUDED.weight,
+			updated_at=NOW()
+		RETURNING id`, payload.ProductID, payload.Sku, payload.Price, payload.Weight, payload.Stock)
+	if err != nil {
+		return 0, err
+	}
+
+	return lastInsertId, nil
+}
+
+func (v *variantRepo) CreateProductVariantOptionValue(ctx context.Context, variantID int64, optionValueID int64) (err error) {
+	_, err = v.conn(ctx).ExecContext(ctx,
+		`INSERT INTO
+			product_variant_option_values (variant_id, option_value_id)
+		VALUES
+			($1, $2)`, variantID, optionValueID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *variantRepo) DeleteProductVariantsExceptSkus(ctx context.Context, productID int64, skus []string) (err error) {
+	query := `
+	DELETE FROM
+		product_variants
+	WHERE
+		product_id = $1
+	AND
+		sku <> ALL($2)`
+
+	_, err = v.conn(ctx).ExecContext(ctx, query, productID, pq.Array(skus))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
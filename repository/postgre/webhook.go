@@ -0,0 +1,312 @@
+package postgre
+
+import (
+	"context"
+	"ecommerce/model/entity"
+	"ecommerce/repository"
+	sdkSql "ecommerce/utils/sql"
+	"time"
+)
+
+// webhookDeliveryDetailColumns are the columns of a delivery joined with its subscription and event.
+const webhookDeliveryDetailColumns = `
+	d.*,
+	s.url,
+	s.secret,
+	e.type AS event_type,
+	e.payload,
+	e.created_at AS event_created_at
+`
+
+type webhookRepo struct {
+	baseRepo
+}
+
+// NewWebhook is function to initialize webhook repository logic.
+func NewWebhook(db sdkSql.DBer) repository.WebhookProvider {
+	return &webhookRepo{
+		baseRepo: baseRepo{db: db},
+	}
+}
+
+func (w *webhookRepo) CreateWebhookSubscription(ctx context.Context, payload entity.WebhookSubscription) (id int64, err error) {
+	var lastInsertId int64
+	err = w.conn(ctx).GetContext(ctx, &lastInsertId,
+		`INSERT INTO
+			webhook_subscriptions (seller_id, url, secret, event_types, active)
+		VALUES
+			($1, $2, $3, $4, $5)
+		RETURNING id`, payload.SellerID, payload.Url, payload.Secret, payload.EventTypes, payload.Active)
+	if err != nil {
+		return 0, err
+	}
+
+	return lastInsertId, nil
+}
+
+func (w *webhookRepo) GetWebhookSubscriptionByID(ctx context.Context, id int64) (response entity.WebhookSubscription, err error) {
+	var subscription entity.WebhookSubscription
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			webhook_subscriptions
+		WHERE
+			id = $1
+	`
+	err = w.conn(ctx).GetContext(ctx, &subscription, selectQuery, id)
+	if err != nil {
+		return entity.WebhookSubscription{}, err
+	}
+
+	return subscription, nil
+}
+
+func (w *webhookRepo) GetWebhookSubscriptionsBySellerID(ctx context.Context, sellerID int64) (response []entity.WebhookSubscription, err error) {
+	var subscriptions []entity.WebhookSubscription
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			webhook_subscriptions
+		WHERE
+			seller_id = $1
+		ORDER BY
+			id ASC
+	`
+	err = w.conn(ctx).SelectContext(ctx, &subscriptions, selectQuery, sellerID)
+	if err != nil {
+		return []entity.WebhookSubscription{}, err
+	}
+
+	return subscriptions, nil
+}
+
+func (w *webhookRepo) UpdateWebhookSubscription(ctx context.Context, payload entity.WebhookSubscription) (err error) {
+	_, err = w.conn(ctx).ExecContext(ctx,
+		`UPDATE
+		webhook_subscriptions
+	SET
+		url=$1,
+		secret=$2,
+		event_types=$3,
+		active=$4,
+		updated_at=NOW()
+	WHERE
+		id=$5`, payload.Url, payload.Secret, payload.EventTypes, payload.Active, payload.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (w *webhookRepo) DeleteWebhookSubscription(ctx context.Context, id int64) (err error) {
+	_, err = w.conn(ctx).ExecContext(ctx,
+		`DELETE FROM
+		webhook_subscriptions
+	WHERE
+		id=$1`, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CreateWebhookDeliveries queues the delivery of event to every active subscription of the seller to its type.
+func (w *webhookRepo) CreateWebhookDeliveries(ctx context.Context, sellerID int64, event entity.OutboxEvent) (err error) {
+	_, err = w.conn(ctx).ExecContext(ctx,
+		`INSERT INTO
+			webhook_deliveries (subscription_id, event_id)
+		SELECT
+			id, $1
+		FROM
+			webhook_subscriptions
+		WHERE
+			seller_id = $2
+		AND
+			active
+		AND
+			$3 = ANY(event_types)`, event.ID, sellerID, event.Type)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ClaimDueWebhookDeliveries returns up to limit pending deliveries of active subscriptions due at now and moves their
+// next attempt to leaseUntil, so a delivery whose worker is gone before recording its attempt is claimed again then.
+func (w *webhookRepo) ClaimDueWebhookDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) (response []entity.WebhookDeliveryDetail, err error) {
+	var deliveries []entity.WebhookDeliveryDetail
+
+	query := `
+		WITH d AS (
+			UPDATE
+				webhook_deliveries
+			SET
+				next_attempt_at=$2,
+				updated_at=NOW()
+			WHERE
+				id IN (
+					SELECT
+						id
+					FROM
+						webhook_deliveries
+					WHERE
+						status = 'pending'
+					AND
+						next_attempt_at <= $1
+					AND
+						subscription_id IN (SELECT id FROM webhook_subscriptions WHERE active)
+					ORDER BY
+						next_attempt_at ASC, id ASC
+					LIMIT $3
+					FOR UPDATE SKIP LOCKED
+				)
+			RETURNING *
+		)
+		SELECT
+			` + webhookDeliveryDetailColumns + `
+		FROM
+			d
+		JOIN
+			webhook_subscriptions s ON s.id = d.subscription_id
+		JOIN
+			outbox_events e ON e.id = d.event_id
+		ORDER BY
+			d.id ASC
+	`
+	err = w.conn(ctx).SelectContext(ctx, &deliveries, query, now, leaseUntil, limit)
+	if err != nil {
+		return []entity.WebhookDeliveryDetail{}, err
+	}
+
+	return deliveries, nil
+}
+
+func (w *webhookRepo) GetWebhookDeliveryByID(ctx context.Context, id int64) (response entity.WebhookDeliveryDetail, err error) {
+	var delivery entity.WebhookDeliveryDetail
+
+	selectQuery := `
+		SELECT
+			` + webhookDeliveryDetailColumns + `
+		FROM
+			webhook_deliveries d
+		JOIN
+			webhook_subscriptions s ON s.id = d.subscription_id
+		JOIN
+			outbox_events e ON e.id = d.event_id
+		WHERE
+			d.id = $1
+	`
+	err = w.conn(ctx).GetContext(ctx, &delivery, selectQuery, id)
+	if err != nil {
+		return entity.WebhookDeliveryDetail{}, err
+	}
+
+	return delivery, nil
+}
+
+func (w *webhookRepo) CountWebhookDeliveriesBySubscriptionID(ctx context.Context, subscriptionID int64) (total int64, err error) {
+	selectQuery := `
+		SELECT
+			COUNT(*)
+		FROM
+			webhook_deliveries
+		WHERE
+			subscription_id = $1
+	`
+	err = w.conn(ctx).GetContext(ctx, &total, selectQuery, subscriptionID)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (w *webhookRepo) GetWebhookDeliveriesBySubscriptionID(ctx context.Context, subscriptionID int64, limit int64, offset int64) (response []entity.WebhookDeliveryDetail, err error) {
+	var deliveries []entity.WebhookDeliveryDetail
+
+	selectQuery := `
+		SELECT
+			` + webhookDeliveryDetailColumns + `
+		FROM
+			webhook_deliveries d
+		JOIN
+			webhook_subscriptions s ON s.id = d.subscription_id
+		JOIN
+			outbox_events e ON e.id = d.event_id
+		WHERE
+			d.subscription_id = $1
+		ORDER BY
+			d.id DESC
+		LIMIT $2
+		OFFSET $3
+	`
+	err = w.conn(ctx).SelectContext(ctx, &deliveries, selectQuery, subscriptionID, limit, offset)
+	if err != nil {
+		return []entity.WebhookDeliveryDetail{}, err
+	}
+
+	return deliveries, nil
+}
+
+func (w *webhookRepo) UpdateWebhookDelivery(ctx context.Context, payload entity.WebhookDelivery) (err error) {
+	_, err = w.conn(ctx).ExecContext(ctx,
+		`UPDATE
+		webhook_deliveries
+	SET
+		status=$1,
+		attempts=$2,
+		response_code=$3,
+		last_error=$4,
+		next_attempt_at=$5,
+		delivered_at=$6,
+		updated_at=NOW()
+	WHERE
+		id=$7`, payload.Status, payload.Attempts, payload.ResponseCode, payload.LastError, payload.NextAttemptAt,
+		payload.DeliveredAt, payload.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (w *webhookRepo) CreateWebhookDeliveryAttempt(ctx context.Context, payload entity.WebhookDeliveryAttempt) (err error) {
+	_, err = w.conn(ctx).ExecContext(ctx,
+		`INSERT INTO
+			webhook_delivery_attempts (delivery_id, response_code, error, duration_ms, manual)
+		VALUES
+			($1, $2, $3, $4, $5)`, payload.DeliveryID, payload.ResponseCode, payload.Error, payload.DurationMs, payload.Manual)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (w *webhookRepo) GetWebhookDeliveryAttemptsByDeliveryID(ctx context.Context, deliveryID int64) (response []entity.WebhookDeliveryAttempt, err error) {
+	var attempts []entity.WebhookDeliveryAttempt
+
+	selectQuery := `
+		SELECT
+			*
+		FROM
+			webhook_delivery_attempts
+		WHERE
+			delivery_id = $1
+		ORDER BY
+			id ASC
+	`
+	err = w.conn(ctx).SelectContext(ctx, &attempts, selectQuery, deliveryID)
+	if err != nil {
+		return []entity.WebhookDeliveryAttempt{}, err
+	}
+
+	return attempts, nil
+}
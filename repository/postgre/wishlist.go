@@ -0,0 +1,135 @@
+package postgre
+
+import (
+	"context"
+	"database/sql"
+	"ecommerce/model/entity"
+	"ecommerce/repository"
+	sdkSql "ecommerce/utils/sql"
+	"errors"
+)
+
+type wishlistRepo struct {
+	baseRepo
+}
+
+// NewWishlist is function to initialize wishlist repository logic.
+func NewWishlist(db sdkSql.DBer) repository.WishlistProvider {
+	return &wishlistRepo{
+		baseRepo: baseRepo{db: db},
+	}
+}
+
+// CreateWishlistItem adds a product to the wishlist of a user, created is false when it was already on it.
+func (w *wishlistRepo) CreateWishlistItem(ctx context.Context, payload entity.WishlistItem) (created bool, err error) {
+	var id int64
+	err = w.conn(ctx).GetContext(ctx, &id,
+		`INSERT INTO
+			wishlist_items (user_id, product_id)
+		VALUES
+			($1, $2)
+		ON CONFLICT (user_id, product_id) DO NOTHING
+		RETURNING id`, payload.UserID, payload.ProductID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// DeleteWishlistItem removes a product from the wishlist of a user, deleted is false when it was not on it.
+func (w *wishlistRepo) DeleteWishlistItem(ctx context.Context, userID int64, productID int64) (deleted bool, err error) {
+	var id int64
+	err = w.conn(ctx).GetContext(ctx, &id,
+		`DELETE FROM
+			wishlist_items
+		WHERE
+			user_id = $1
+		AND
+			product_id = $2
+		RETURNING id`, userID, productID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (w *wishlistRepo) CountWishlistItemsByUserID(ctx context.Context, userID int64) (total int64, err error) {
+	selectQuery := `
+		SELECT
+			COUNT(*)
+		FROM
+			wishlist_items wi
+		JOIN
+			products p ON p.id = wi.product_id
+		WHERE
+			wi.user_id = $1
+	`
+	err = w.conn(ctx).GetContext(ctx, &total, selectQuery, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// GetWishlistItemsByUserID returns the wishlist of a user with the current state of its products, the latest added
+// first.
+func (w *wishlistRepo) GetWishlistItemsByUserID(ctx context.Context, userID int64, limit int64, offset int64) (response []entity.WishlistItemDetail, err error) {
+	var wishlistItems []entity.WishlistItemDetail
+
+	selectQuery := `
+		SELECT
+			wi.*,
+			products.sku,
+			products.title,
+			products.effective_price AS current_price,
+			products.currency,
+			products.rating,
+			products.stock,
+			products.favourited_count,
+			COALESCE((
+				SELECT pi.image_url FROM product_images pi WHERE pi.product_id = products.id AND pi.is_primary
+			), '') AS primary_image_url,
+			products.user_id AS seller_id
+		FROM
+			wishlist_items wi
+		JOIN
+			` + pricedProducts + ` ON products.id = wi.product_id
+		WHERE
+			wi.user_id = $1
+		ORDER BY
+			wi.id DESC
+		LIMIT $2
+		OFFSET $3
+	`
+	err = w.conn(ctx).SelectContext(ctx, &wishlistItems, selectQuery, userID, limit, offset)
+	if err != nil {
+		return []entity.WishlistItemDetail{}, err
+	}
+
+	return wishlistItems, nil
+}
+
+// UpdateProductFavouritedCount adds change to the number of wishlists a product is on.
+func (w *wishlistRepo) UpdateProductFavouritedCount(ctx context.Context, productID int64, change int64) (err error) {
+	_, err = w.conn(ctx).ExecContext(ctx,
+		`UPDATE
+		products
+	SET
+		favourited_count=GREATEST(favourited_count + $1, 0)
+	WHERE
+		id=$2`, change, productID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
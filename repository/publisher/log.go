@@ -0,0 +1,58 @@
+package publisher
+
+import (
+	"context"
+	"ecommerce/model/entity"
+	"ecommerce/repository"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// LogName is the name of the publisher writing events to a log.
+const LogName = "log"
+
+// message is how an event is encoded when it is published.
+type message struct {
+	ID            int64           `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   int64           `json:"aggregate_id"`
+	Payload       json.RawMessage `json:"payload"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+}
+
+type logPublisher struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewLog is function to initialize the publisher writing every event to w as a line of json.
+func NewLog(w io.Writer) repository.EventPublisher {
+	return &logPublisher{w: w}
+}
+
+func (l *logPublisher) Publish(ctx context.Context, event entity.OutboxEvent) (err error) {
+	encoded, err := json.Marshal(toMessage(event))
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, err = l.w.Write(append(encoded, '\n'))
+	return err
+}
+
+func toMessage(event entity.OutboxEvent) message {
+	return message{
+		ID:            event.ID,
+		Type:          event.Type,
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID,
+		Payload:       event.Payload,
+		OccurredAt:    event.CreatedAt,
+	}
+}
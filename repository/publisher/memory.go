@@ -0,0 +1,38 @@
+package publisher
+
+import (
+	"context"
+	"ecommerce/model/entity"
+	"sync"
+)
+
+// MemoryName is the name of the publisher keeping events in memory.
+const MemoryName = "memory"
+
+// Memory keeps the published events in the process, for running locally and for consumers living in the same
+// process.
+type Memory struct {
+	mu     sync.Mutex
+	events []entity.OutboxEvent
+}
+
+// NewMemory is function to initialize the publisher keeping events in memory.
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+func (m *Memory) Publish(ctx context.Context, event entity.OutboxEvent) (err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.events = append(m.events, event)
+	return nil
+}
+
+// Events returns the events published so far in the order they were published.
+func (m *Memory) Events() []entity.OutboxEvent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]entity.OutboxEvent(nil), m.events...)
+}
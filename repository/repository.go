@@ -5,22 +5,276 @@ import (
 	"database/sql"
 	"ecommerce/model/entity"
 	"ecommerce/model/request"
+	"io"
+	"time"
 )
 
 type QueryProvider interface {
 	Exec(query string, args ...interface{}) (sql.Result, error)
 	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
 	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+}
+
+type TransactionProvider interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) (err error)
 }
 
 type EcommerceProvider interface {
 	GetProductList(ctx context.Context, payload request.FilterProduct) (response []entity.Product, err error)
+	GetProductCategoryFacets(ctx context.Context, payload request.FilterProduct) (response []entity.CategoryFacet, err error)
+	GetProductAttributeFacets(ctx context.Context, payload request.FilterProduct) (response []entity.AttributeFacet, err error)
+	GetProductPriceFacets(ctx context.Context, payload request.FilterProduct, boundaries []int64) (response []entity.PriceFacet, err error)
 	CreateProduct(ctx context.Context, request entity.Product) (id int64, err error)
 	UpdateProduct(ctx context.Context, request entity.Product) (err error)
+	LockProduct(ctx context.Context, id int64) (err error)
 	GetProductByID(ctx context.Context, id int64) (response entity.Product, err error)
+	GetProductBySku(ctx context.Context, userID int64, sku string) (response entity.Product, err error)
 	GetProductImagesByProductID(ctx context.Context, id int64) (response []entity.ProductImage, err error)
 	GetProductReviewByProductID(ctx context.Context, id int64) (response []entity.ProductReview, err error)
-	CreateProductReview(ctx context.Context, payload entity.ProductReview) (err error)
+	CreateProductReview(ctx context.Context, payload entity.ProductReview) (id int64, err error)
 	CreateProductImages(ctx context.Context, payload entity.ProductImage) (err error)
 	DeleteProductImagesByID(ctx context.Context, productID int64) (err error)
+	UpdateProductImagePosition(ctx context.Context, productID int64, imageID int64, position int) (err error)
+	SetPrimaryProductImage(ctx context.Context, productID int64, imageID int64) (err error)
+	UpsertProduct(ctx context.Context, payload entity.Product) (response entity.UpsertedProduct, err error)
+	ExportProducts(ctx context.Context, payload request.FilterProduct, fn func(product entity.ProductExport) error) (err error)
+}
+
+type CartProvider interface {
+	GetCartByUserID(ctx context.Context, userID int64) (response entity.Cart, err error)
+	CreateCart(ctx context.Context, userID int64) (id int64, err error)
+	GetCartItemsByCartID(ctx context.Context, cartID int64) (response []entity.CartItemDetail, err error)
+	GetCartItemByProductID(ctx context.Context, cartID int64, productID int64, variantID int64) (response entity.CartItem, err error)
+	CreateCartItem(ctx context.Context, payload entity.CartItem) (err error)
+	UpdateCartItem(ctx context.Context, payload entity.CartItem) (err error)
+	DeleteCartItemByProductID(ctx context.Context, cartID int64, productID int64, variantID int64) (err error)
+	DeleteCartItemsByCartID(ctx context.Context, cartID int64) (err error)
+}
+
+type OrderProvider interface {
+	CreateOrder(ctx context.Context, payload entity.Order) (id int64, err error)
+	CreateOrderItem(ctx context.Context, payload entity.OrderItem) (err error)
+	GetOrderByID(ctx context.Context, id int64) (response entity.Order, err error)
+	GetOrderByIDForUpdate(ctx context.Context, id int64) (response entity.Order, err error)
+	GetOrderItemsByOrderID(ctx context.Context, orderID int64) (response []entity.OrderItem, err error)
+	UpdateOrderStatus(ctx context.Context, id int64, status string) (err error)
+	CreateOrderStatusHistory(ctx context.Context, payload entity.OrderStatusHistory) (err error)
+	GetOrderStatusHistoryByOrderID(ctx context.Context, orderID int64) (response []entity.OrderStatusHistory, err error)
+}
+
+type InventoryProvider interface {
+	GetProductStockForUpdate(ctx context.Context, productID int64) (stock int64, err error)
+	UpdateProductStock(ctx context.Context, productID int64, stock int64) (err error)
+	DecreaseProductStock(ctx context.Context, productID int64, quantity int64) (stock int64, err error)
+	IncreaseProductStock(ctx context.Context, productID int64, quantity int64) (stock int64, err error)
+	GetVariantStockForUpdate(ctx context.Context, productID int64, variantID int64) (stock int64, err error)
+	UpdateVariantStock(ctx context.Context, variantID int64, stock int64) (err error)
+	DecreaseVariantStock(ctx context.Context, variantID int64, quantity int64) (stock int64, err error)
+	IncreaseVariantStock(ctx context.Context, variantID int64, quantity int64) (stock int64, err error)
+	CreateInventoryLedger(ctx context.Context, payload entity.InventoryLedger) (err error)
+	GetInventoryLedgerByProductID(ctx context.Context, productID int64) (response []entity.InventoryLedger, err error)
+	CreateStockReservation(ctx context.Context, payload entity.StockReservation) (err error)
+	GetActiveStockReservationsByOrderID(ctx context.Context, orderID int64) (response []entity.StockReservation, err error)
+	GetExpiredStockReservationOrderIDs(ctx context.Context, now time.Time, limit int) (response []int64, err error)
+	UpdateStockReservationStatus(ctx context.Context, id int64, status string) (err error)
+}
+
+type VariantProvider interface {
+	GetProductOptionsByProductID(ctx context.Context, productID int64) (response []entity.ProductOption, err error)
+	GetProductOptionValuesByProductID(ctx context.Context, productID int64) (response []entity.ProductOptionValue, err error)
+	GetProductVariantsByProductID(ctx context.Context, productID int64) (response []entity.ProductVariant, err error)
+	GetProductVariantByID(ctx context.Context, productID int64, id int64) (response entity.ProductVariant, err error)
+	GetProductVariantOptionValuesByProductID(ctx context.Context, productID int64) (response []entity.ProductVariantOptionValue, err error)
+	CreateProductOption(ctx context.Context, payload entity.ProductOption) (id int64, err error)
+	CreateProductOptionValue(ctx context.Context, payload entity.ProductOptionValue) (id int64, err error)
+	DeleteProductOptionsByProductID(ctx context.Context, productID int64) (err error)
+	UpsertProductVariant(ctx context.Context, payload entity.ProductVariant) (id int64, err error)
+	CreateProductVariantOptionValue(ctx context.Context, variantID int64, optionValueID int64) (err error)
+	DeleteProductVariantsExceptSkus(ctx context.Context, productID int64, skus []string) (err error)
+}
+
+type CategoryProvider interface {
+	GetCategories(ctx context.Context) (response []entity.Category, err error)
+	GetCategoryByID(ctx context.Context, id int64) (response entity.Category, err error)
+	GetCategoryBySlug(ctx context.Context, slug string) (response entity.Category, err error)
+	GetCategoryDescendantIDs(ctx context.Context, id int64) (response []int64, err error)
+	CreateCategory(ctx context.Context, payload entity.Category) (id int64, err error)
+	UpdateCategory(ctx context.Context, payload entity.Category) (err error)
+	DeleteCategory(ctx context.Context, id int64) (err error)
+	CountChildCategories(ctx context.Context, id int64) (total int64, err error)
+	CountProductsByCategoryID(ctx context.Context, id int64) (total int64, err error)
+}
+
+type EtalaseProvider interface {
+	GetEtalasesByUserID(ctx context.Context, userID int64) (response []entity.Etalase, err error)
+	GetEtalaseByID(ctx context.Context, id int64) (response entity.Etalase, err error)
+	CreateEtalase(ctx context.Context, payload entity.Etalase) (id int64, err error)
+	UpdateEtalase(ctx context.Context, payload entity.Etalase) (err error)
+	DeleteEtalase(ctx context.Context, id int64) (err error)
+	AddProductToEtalase(ctx context.Context, etalaseID int64, productID int64) (err error)
+	RemoveProductFromEtalase(ctx context.Context, etalaseID int64, productID int64) (err error)
+	CountProductsByEtalaseID(ctx context.Context, etalaseID int64) (total int64, err error)
+	GetProductsByEtalaseID(ctx context.Context, etalaseID int64, limit int64, offset int64) (response []entity.Product, err error)
+}
+
+type SellerProvider interface {
+	GetSellerByUserID(ctx context.Context, userID int64) (response entity.Seller, err error)
+	UpsertSeller(ctx context.Context, payload entity.Seller) (err error)
+	GetSellerProductStats(ctx context.Context, userID int64) (response entity.SellerProductStats, err error)
+}
+
+type AttributeProvider interface {
+	GetProductAttributesByProductID(ctx context.Context, productID int64) (response []entity.ProductAttribute, err error)
+	CreateProductAttribute(ctx context.Context, payload entity.ProductAttribute) (err error)
+	DeleteProductAttributesByProductID(ctx context.Context, productID int64) (err error)
+}
+
+// StorageProvider stores uploaded objects such as product images.
+type StorageProvider interface {
+	// Put stores body under key, replacing the object already stored under it.
+	Put(ctx context.Context, key string, contentType string, body io.Reader) (err error)
+	// Get opens the object stored under key, the caller has to close it. It returns fs.ErrNotExist when nothing is
+	// stored under key.
+	Get(ctx context.Context, key string) (body io.ReadCloser, err error)
+	// URL returns the public url of the object stored under key.
+	URL(key string) string
+}
+
+type ImageProvider interface {
+	UpsertImage(ctx context.Context, payload entity.Image) (err error)
+	ClaimPendingImages(ctx context.Context, staleBefore time.Time, limit int) (response []entity.Image, err error)
+	UpdateImageStatus(ctx context.Context, payload entity.Image) (err error)
+	UpsertImageVariant(ctx context.Context, payload entity.ImageVariant) (err error)
+	GetImageVariantsByImageURLs(ctx context.Context, urls []string) (response []entity.ImageVariantDetail, err error)
+}
+
+type ProductImportProvider interface {
+	CreateProductImport(ctx context.Context, payload entity.ProductImport) (id int64, err error)
+	UpdateProductImport(ctx context.Context, payload entity.ProductImport) (err error)
+	GetProductImportByID(ctx context.Context, id int64) (response entity.ProductImport, err error)
+}
+
+type AuditLogProvider interface {
+	CreateAuditLog(ctx context.Context, payload entity.AuditLog) (err error)
+	CountAuditLogsByProductID(ctx context.Context, productID int64) (total int64, err error)
+	GetAuditLogsByProductID(ctx context.Context, productID int64, limit int64, offset int64) (response []entity.AuditLog, err error)
+}
+
+type ProductPriceProvider interface {
+	GetProductPricesByProductID(ctx context.Context, productID int64) (response []entity.ProductPrice, err error)
+	CreateProductPrice(ctx context.Context, payload entity.ProductPrice) (id int64, err error)
+	UpdateProductPriceEffectiveTo(ctx context.Context, id int64, effectiveTo sql.NullTime) (err error)
+	DeleteProductPrice(ctx context.Context, id int64) (err error)
+}
+
+type PromotionProvider interface {
+	CountPromotions(ctx context.Context) (total int64, err error)
+	GetPromotions(ctx context.Context, limit int64, offset int64) (response []entity.Promotion, err error)
+	GetPromotionByID(ctx context.Context, id int64) (response entity.Promotion, err error)
+	GetApplicablePromotions(ctx context.Context, codes []string, now time.Time) (response []entity.Promotion, err error)
+	CreatePromotion(ctx context.Context, payload entity.Promotion) (id int64, err error)
+	UpdatePromotion(ctx context.Context, payload entity.Promotion) (err error)
+	DeletePromotion(ctx context.Context, id int64) (err error)
+	IncrementPromotionUsage(ctx context.Context, id int64) (err error)
+	DecrementPromotionUsage(ctx context.Context, id int64) (err error)
+	CountPromotionUsagesByUserID(ctx context.Context, promotionID int64, userID int64) (total int64, err error)
+	CreatePromotionUsage(ctx context.Context, payload entity.PromotionUsage) (err error)
+	GetPromotionUsagesByOrderID(ctx context.Context, orderID int64) (response []entity.PromotionUsage, err error)
+	DeletePromotionUsagesByOrderID(ctx context.Context, orderID int64) (err error)
+}
+
+// ShippingRateProvider reads the shipping zones and rate tables, from the database or from the configuration.
+type ShippingRateProvider interface {
+	GetShippingZones(ctx context.Context) (response []entity.ShippingZone, err error)
+	GetShippingRates(ctx context.Context) (response []entity.ShippingRate, err error)
+}
+
+type PaymentProvider interface {
+	CreatePayment(ctx context.Context, payload entity.Payment) (id int64, err error)
+	GetPaymentsByOrderID(ctx context.Context, orderID int64) (response []entity.Payment, err error)
+	GetPaymentByChargeIDForUpdate(ctx context.Context, gateway string, chargeID string) (response entity.Payment, err error)
+	UpdatePaymentStatus(ctx context.Context, id int64, status string) (err error)
+	UpdatePaymentCharge(ctx context.Context, id int64, charge entity.PaymentCharge) (err error)
+	UpdatePaymentRefundedAmount(ctx context.Context, id int64, refundedAmount int64, status string) (err error)
+	CreateRefund(ctx context.Context, payload entity.Refund) (err error)
+	GetPendingRefundsByOrderID(ctx context.Context, orderID int64) (response []entity.Refund, err error)
+	UpdateRefundStatus(ctx context.Context, id int64, status string) (err error)
+	CreatePaymentEvent(ctx context.Context, payload entity.PaymentEvent) (created bool, err error)
+}
+
+// PaymentGateway charges orders at a payment provider. Calls which move money carry an idempotency key, a call
+// repeated with the same key is applied once and returns the result of the first one, so it can be retried safely.
+type PaymentGateway interface {
+	// Name identifies the gateway in the stored payments.
+	Name() string
+	// CreateCharge starts a charge of amount for the order, the customer pays it on the payment url of the charge.
+	CreateCharge(ctx context.Context, idempotencyKey string, orderID int64, amount int64, currency string) (charge entity.PaymentCharge, err error)
+	// Capture takes amount of an authorized charge.
+	Capture(ctx context.Context, idempotencyKey string, chargeID string, amount int64) (err error)
+	// Refund gives amount of a captured charge back to the customer.
+	Refund(ctx context.Context, idempotencyKey string, chargeID string, amount int64) (err error)
+	// VerifyWebhook checks the signature of a webhook payload and returns the event it carries.
+	VerifyWebhook(ctx context.Context, payload []byte, signature string) (event entity.PaymentWebhookEvent, err error)
+}
+
+type ReturnRequestProvider interface {
+	CreateReturnRequest(ctx context.Context, payload entity.ReturnRequest) (id int64, err error)
+	GetReturnRequestByIDForUpdate(ctx context.Context, id int64) (response entity.ReturnRequest, err error)
+	GetReturnRequestsByOrderID(ctx context.Context, orderID int64) (response []entity.ReturnRequest, err error)
+	CountReturnRequestsBySellerID(ctx context.Context, sellerID int64) (total int64, err error)
+	GetReturnRequestsBySellerID(ctx context.Context, sellerID int64, limit int64, offset int64) (response []entity.ReturnRequest, err error)
+	SumReturnedQuantity(ctx context.Context, orderItemID int64) (quantity int64, err error)
+	UpdateReturnRequest(ctx context.Context, payload entity.ReturnRequest) (err error)
+}
+
+type WishlistProvider interface {
+	CreateWishlistItem(ctx context.Context, payload entity.WishlistItem) (created bool, err error)
+	DeleteWishlistItem(ctx context.Context, userID int64, productID int64) (deleted bool, err error)
+	CountWishlistItemsByUserID(ctx context.Context, userID int64) (total int64, err error)
+	GetWishlistItemsByUserID(ctx context.Context, userID int64, limit int64, offset int64) (response []entity.WishlistItemDetail, err error)
+	UpdateProductFavouritedCount(ctx context.Context, productID int64, change int64) (err error)
+}
+
+type InvoiceProvider interface {
+	NextInvoiceSequence(ctx context.Context, sellerID int64) (sequence int64, err error)
+	CreateInvoice(ctx context.Context, payload entity.Invoice) (id int64, err error)
+	GetInvoicesByOrderID(ctx context.Context, orderID int64) (response []entity.Invoice, err error)
+}
+
+type OutboxProvider interface {
+	CreateOutboxEvent(ctx context.Context, payload entity.OutboxEvent) (id int64, err error)
+	ClaimOutboxEvents(ctx context.Context, limit int) (response []entity.OutboxEvent, err error)
+	MarkOutboxEventPublished(ctx context.Context, id int64) (err error)
+	MarkOutboxEventFailed(ctx context.Context, id int64, lastError string) (err error)
+}
+
+// EventPublisher delivers domain events to the services downstream. An event may be delivered more than once, so
+// consumers have to deduplicate on its id.
+type EventPublisher interface {
+	Publish(ctx context.Context, event entity.OutboxEvent) (err error)
+}
+
+type WebhookProvider interface {
+	CreateWebhookSubscription(ctx context.Context, payload entity.WebhookSubscription) (id int64, err error)
+	GetWebhookSubscriptionByID(ctx context.Context, id int64) (response entity.WebhookSubscription, err error)
+	GetWebhookSubscriptionsBySellerID(ctx context.Context, sellerID int64) (response []entity.WebhookSubscription, err error)
+	UpdateWebhookSubscription(ctx context.Context, payload entity.WebhookSubscription) (err error)
+	DeleteWebhookSubscription(ctx context.Context, id int64) (err error)
+	CreateWebhookDeliveries(ctx context.Context, sellerID int64, event entity.OutboxEvent) (err error)
+	ClaimDueWebhookDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) (response []entity.WebhookDeliveryDetail, err error)
+	GetWebhookDeliveryByID(ctx context.Context, id int64) (response entity.WebhookDeliveryDetail, err error)
+	CountWebhookDeliveriesBySubscriptionID(ctx context.Context, subscriptionID int64) (total int64, err error)
+	GetWebhookDeliveriesBySubscriptionID(ctx context.Context, subscriptionID int64, limit int64, offset int64) (response []entity.WebhookDeliveryDetail, err error)
+	UpdateWebhookDelivery(ctx context.Context, payload entity.WebhookDelivery) (err error)
+	CreateWebhookDeliveryAttempt(ctx context.Context, payload entity.WebhookDeliveryAttempt) (err error)
+	GetWebhookDeliveryAttemptsByDeliveryID(ctx context.Context, deliveryID int64) (response []entity.WebhookDeliveryAttempt, err error)
+}
+
+// WebhookClient posts webhook deliveries to the subscribers. A response, whatever its status code, is not an error.
+type WebhookClient interface {
+	Post(ctx context.Context, url string, header map[string]string, body []byte) (statusCode int, err error)
+	// CheckUrl returns an error when the host of url doesn't resolve or resolves to an address webhooks can't be
+	// posted to.
+	CheckUrl(ctx context.Context, url string) (err error)
 }
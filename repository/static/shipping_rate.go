@@ -0,0 +1,29 @@
+// Package static holds repositories serving fixed data, such as the tables of the configuration file.
+package static
+
+import (
+	"context"
+	"ecommerce/model/entity"
+	"ecommerce/repository"
+)
+
+type shippingRateRepo struct {
+	zones []entity.ShippingZone
+	rates []entity.ShippingRate
+}
+
+// NewShippingRate is function to initialize shipping rate repository logic serving zones and rates.
+func NewShippingRate(zones []entity.ShippingZone, rates []entity.ShippingRate) repository.ShippingRateProvider {
+	return &shippingRateRepo{
+		zones: zones,
+		rates: rates,
+	}
+}
+
+func (s *shippingRateRepo) GetShippingZones(ctx context.Context) (response []entity.ShippingZone, err error) {
+	return s.zones, nil
+}
+
+func (s *shippingRateRepo) GetShippingRates(ctx context.Context) (response []entity.ShippingRate, err error) {
+	return s.rates, nil
+}
@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"context"
+	"ecommerce/repository"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+type localStorage struct {
+	dir     string
+	baseURL string
+}
+
+// NewLocal is function to initialize local filesystem storage logic.
+// Objects are written below dir and served from baseURL.
+func NewLocal(dir string, baseURL string) repository.StorageProvider {
+	return &localStorage{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+func (l *localStorage) Put(ctx context.Context, key string, contentType string, body io.Reader) (err error) {
+	name, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(name), 0o755)
+	if err != nil {
+		return err
+	}
+
+	// the object is written to a temporary file first so readers never see a partial object
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, body)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(tmp.Name(), 0o644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), name)
+}
+
+func (l *localStorage) Get(ctx context.Context, key string) (body io.ReadCloser, err error) {
+	name, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.Open(name)
+}
+
+func (l *localStorage) URL(key string) string {
+	return l.baseURL + "/" + path.Clean("/" + key)[1:]
+}
+
+// path returns the file of key, keys escaping dir are rejected.
+func (l *localStorage) path(key string) (string, error) {
+	cleaned := path.Clean("/" + key)[1:]
+	if cleaned == "" || cleaned != key {
+		return "", fmt.Errorf("invalid storage key %q", key)
+	}
+
+	return filepath.Join(l.dir, filepath.FromSlash(cleaned)), nil
+}
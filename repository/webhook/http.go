@@ -0,0 +1,119 @@
+// Package webhook holds the client webhook deliveries are posted with.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"ecommerce/repository"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// maxResponseSize is how much of a response is read, the body is only read so the connection can be reused.
+const maxResponseSize = 64 << 10
+
+// ErrForbiddenAddress is returned for a url resolving to an address of the network the store runs in.
+var ErrForbiddenAddress = errors.New("webhooks can't be posted to loopback, link-local or private addresses")
+
+type httpClient struct {
+	client *http.Client
+	// allowPrivateNetworks lets webhooks be posted to any address, for local development
+	allowPrivateNetworks bool
+}
+
+// NewHTTP is function to initialize the client posting webhooks over http, a post not answered within timeout fails.
+// Unless allowPrivateNetworks is set, the client only connects to public addresses and doesn't follow redirects, so a
+// subscriber can't have the store post to its own network.
+func NewHTTP(timeout time.Duration, allowPrivateNetworks bool) repository.WebhookClient {
+	h := &httpClient{allowPrivateNetworks: allowPrivateNetworks}
+
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		// the address is checked once it is resolved for the connection, a host resolving to a public address when
+		// the subscription was saved may resolve to another one since
+		Control: func(network string, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			return h.checkIP(net.ParseIP(host))
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	h.client = &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		// a redirect is answered as is instead of being followed to an address that wasn't checked
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	return h
+}
+
+func (h *httpClient) Post(ctx context.Context, url string, header map[string]string, body []byte) (statusCode int, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseSize))
+
+	return resp.StatusCode, nil
+}
+
+func (h *httpClient) CheckUrl(ctx context.Context, rawUrl string) error {
+	target, err := url.Parse(rawUrl)
+	if err != nil {
+		return err
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, target.Hostname())
+	if err != nil {
+		return err
+	}
+
+	for _, v := range addrs {
+		err = h.checkIP(v.IP)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkIP returns ErrForbiddenAddress for an address that isn't a public unicast address.
+func (h *httpClient) checkIP(ip net.IP) error {
+	if h.allowPrivateNetworks {
+		return nil
+	}
+
+	if ip == nil || !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, ip)
+	}
+
+	return nil
+}
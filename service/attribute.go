@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"ecommerce/model/entity"
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// priceFacetBoundaries splits the product prices into the buckets counted by the price facet.
+var priceFacetBoundaries = []int64{50000, 100000, 250000, 500000, 1000000}
+
+var attributeTypes = []string{entity.AttributeTypeString, entity.AttributeTypeNumber, entity.AttributeTypeBoolean}
+
+// toProductAttributes validates the attributes against their type and converts their value to its canonical form.
+func toProductAttributes(attributes []request.UpsertProductAttribute) ([]entity.ProductAttribute, error) {
+	names := make(map[string]bool, len(attributes))
+	productAttributes := make([]entity.ProductAttribute, 0, len(attributes))
+	for _, v := range attributes {
+		name := strings.TrimSpace(v.Name)
+		if name == "" || names[name] {
+			return nil, fmt.Errorf("%w: name %q should be filled and unique", ErrInvalidAttribute, v.Name)
+		}
+		names[name] = true
+
+		value, err := canonicalAttributeValue(name, v.Type, v.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		productAttributes = append(productAttributes, entity.ProductAttribute{
+			Name:  name,
+			Type:  v.Type,
+			Value: value,
+		})
+	}
+
+	return productAttributes, nil
+}
+
+// canonicalAttributeValue checks value against attributeType and returns it in its canonical form, the form it is
+// stored and filtered in.
+func canonicalAttributeValue(name string, attributeType string, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	switch attributeType {
+	case entity.AttributeTypeString:
+		if value == "" {
+			return "", fmt.Errorf("%w: %s should have a value", ErrInvalidAttribute, name)
+		}
+	case entity.AttributeTypeNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s should be a number", ErrInvalidAttribute, name)
+		}
+		value = strconv.FormatFloat(number, 'f', -1, 64)
+	case entity.AttributeTypeBoolean:
+		boolean, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s should be a boolean", ErrInvalidAttribute, name)
+		}
+		value = strconv.FormatBool(boolean)
+	default:
+		return "", fmt.Errorf("%w: %s has unknown type %q", ErrInvalidAttribute, name, attributeType)
+	}
+
+	return value, nil
+}
+
+// attributeFilterValues converts the value of every attribute filter to the canonical form of each type it is valid
+// for, since the type of an attribute isn't known from its name. A value matches no attribute of a type it isn't
+// valid for.
+func attributeFilterValues(attributes map[string]string) map[string]map[string]string {
+	values := make(map[string]map[string]string, len(attributes))
+	for name, value := range attributes {
+		values[name] = map[string]string{}
+		for _, attributeType := range attributeTypes {
+			canonical, err := canonicalAttributeValue(name, attributeType, value)
+			if err == nil {
+				values[name][attributeType] = canonical
+			}
+		}
+	}
+
+	return values
+}
+
+// saveProductAttributes replaces the attributes of the product.
+func (e *ecommerceService) saveProductAttributes(ctx context.Context, productID int64, attributes []entity.ProductAttribute) error {
+	err := e.attributeRepo.DeleteProductAttributesByProductID(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range attributes {
+		v.ProductID = productID
+		err = e.attributeRepo.CreateProductAttribute(ctx, v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getProductFacets counts the products matching payload per category, attribute value and price bucket.
+func (e *ecommerceService) getProductFacets(ctx context.Context, payload request.FilterProduct) (*response.ProductFacets, error) {
+	categoryFacets, err := e.ecommerceRepo.GetProductCategoryFacets(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	attributeFacets, err := e.ecommerceRepo.GetProductAttributeFacets(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	priceFacets, err := e.ecommerceRepo.GetProductPriceFacets(ctx, payload, priceFacetBoundaries)
+	if err != nil {
+		return nil, err
+	}
+
+	facets := response.ProductFacets{
+		Categories:    make([]response.CategoryFacet, 0, len(categoryFacets)),
+		Attributes:    []response.AttributeFacet{},
+		Prices:        make([]response.PriceFacet, 0, len(priceFacets)),
+		PriceCurrency: payload.PriceCurrency,
+	}
+
+	for _, v := range categoryFacets {
+		facets.Categories = append(facets.Categories, response.CategoryFacet{
+			CategoryID: v.CategoryID,
+			Name:       v.Name,
+			Count:      v.Count,
+		})
+	}
+
+	// attribute facets are sorted by name so the values of an attribute are next to each other
+	for _, v := range attributeFacets {
+		last := len(facets.Attributes) - 1
+		if last < 0 || facets.Attributes[last].Name != v.Name {
+			facets.Attributes = append(facets.Attributes, response.AttributeFacet{Name: v.Name})
+			last++
+		}
+
+		facets.Attributes[last].Values = append(facets.Attributes[last].Values, response.AttributeValueFacet{
+			Value: v.Value,
+			Count: v.Count,
+		})
+	}
+
+	for _, v := range priceFacets {
+		priceFacet := response.PriceFacet{Count: v.Count}
+		if v.Bucket > 0 {
+			priceFacet.Min = priceFacetBoundaries[v.Bucket-1]
+		}
+		if v.Bucket < len(priceFacetBoundaries) {
+			priceFacet.Max = priceFacetBoundaries[v.Bucket]
+		}
+
+		facets.Prices = append(facets.Prices, priceFacet)
+	}
+
+	return &facets, nil
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"ecommerce/model/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttributeFilterValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  map[string]string
+	}{
+		{
+			name:  "number written with a fraction",
+			value: "1.0",
+			want:  map[string]string{entity.AttributeTypeString: "1.0", entity.AttributeTypeNumber: "1"},
+		},
+		{
+			name:  "capitalized boolean",
+			value: " True ",
+			want:  map[string]string{entity.AttributeTypeString: "True", entity.AttributeTypeBoolean: "true"},
+		},
+		{
+			name:  "one is a number and a boolean",
+			value: "1",
+			want: map[string]string{
+				entity.AttributeTypeString:  "1",
+				entity.AttributeTypeNumber:  "1",
+				entity.AttributeTypeBoolean: "true",
+			},
+		},
+		{
+			name:  "text",
+			value: "red",
+			want:  map[string]string{entity.AttributeTypeString: "red"},
+		},
+		{
+			name:  "empty",
+			value: "",
+			want:  map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := attributeFilterValues(map[string]string{"attribute": tt.value})
+			assert.Equal(t, map[string]map[string]string{"attribute": tt.want}, values)
+		})
+	}
+}
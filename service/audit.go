@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"database/sql/driver"
+	"ecommerce/model/entity"
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"encoding/json"
+	"reflect"
+)
+
+// actorKey is the context key holding the actor changes are recorded for.
+type actorKey struct{}
+
+// auditIgnoredColumns are not recorded in the diff of a change since they change along with every other column.
+var auditIgnoredColumns = map[string]bool{
+	"id":                true,
+	"created_at":        true,
+	"updated_at":        true,
+	"primary_image_url": true,
+	"effective_price":   true,
+}
+
+// auditChange is the old and new value of a column, the old value of a created row and the new value of a deleted
+// row are null.
+type auditChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// WithActor returns a copy of ctx whose changes to products and images are recorded as made by actor.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func actorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// recordAudit records the change of a row from old to new, old is nil for a created row and new is nil for a deleted
+// one. Nothing is recorded when no column changed.
+func (e *ecommerceService) recordAudit(ctx context.Context, entityType string, entityID int64, productID int64, old interface{}, new interface{}) error {
+	action := entity.AuditActionUpdate
+	switch {
+	case old == nil:
+		action = entity.AuditActionCreate
+	case new == nil:
+		action = entity.AuditActionDelete
+	}
+
+	diff := auditDiff(auditColumns(old), auditColumns(new))
+	if len(diff) == 0 {
+		return nil
+	}
+
+	encoded, err := json.Marshal(diff)
+	if err != nil {
+		return err
+	}
+
+	return e.auditLogRepo.CreateAuditLog(ctx, entity.AuditLog{
+		EntityType: entityType,
+		EntityID:   entityID,
+		ProductID:  productID,
+		Action:     action,
+		Actor:      actorFromContext(ctx),
+		Diff:       encoded,
+	})
+}
+
+// recordProductImageAudits records the changes between the images of a product before and after they were saved.
+// Images are saved by replacing all of them, so they are matched by url rather than by id.
+func (e *ecommerceService) recordProductImageAudits(ctx context.Context, productID int64, old []entity.ProductImage, new []entity.ProductImage) error {
+	oldByUrl := make(map[string][]entity.ProductImage, len(old))
+	for _, v := range old {
+		oldByUrl[v.ImageUrl] = append(oldByUrl[v.ImageUrl], v)
+	}
+
+	for _, v := range new {
+		var err error
+		if matches := oldByUrl[v.ImageUrl]; len(matches) > 0 {
+			oldByUrl[v.ImageUrl] = matches[1:]
+			err = e.recordAudit(ctx, entity.AuditEntityProductImage, v.ID, productID, matches[0], v)
+		} else {
+			err = e.recordAudit(ctx, entity.AuditEntityProductImage, v.ID, productID, nil, v)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, v := range old {
+		if matches := oldByUrl[v.ImageUrl]; len(matches) > 0 {
+			oldByUrl[v.ImageUrl] = matches[1:]
+
+			err := e.recordAudit(ctx, entity.AuditEntityProductImage, matches[0].ID, productID, matches[0], nil)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// auditColumns returns the values of the db columns of a row, nil rows have no columns.
+func auditColumns(row interface{}) map[string]interface{} {
+	if row == nil {
+		return nil
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(row))
+	columns := make(map[string]interface{}, value.NumField())
+	for i := 0; i < value.NumField(); i++ {
+		column := value.Type().Field(i).Tag.Get("db")
+		if column == "" || column == "-" || auditIgnoredColumns[column] {
+			continue
+		}
+
+		field := value.Field(i).Interface()
+		if valuer, ok := field.(driver.Valuer); ok {
+			field, _ = valuer.Value()
+		}
+
+		columns[column] = field
+	}
+
+	return columns
+}
+
+func auditDiff(old map[string]interface{}, new map[string]interface{}) map[string]auditChange {
+	diff := make(map[string]auditChange)
+	for column, value := range new {
+		if !reflect.DeepEqual(old[column], value) {
+			diff[column] = auditChange{Old: old[column], New: value}
+		}
+	}
+
+	if new == nil {
+		for column, value := range old {
+			if value != nil {
+				diff[column] = auditChange{Old: value}
+			}
+		}
+	}
+
+	return diff
+}
+
+// GetProductHistory returns the changes made to a product and its images, the latest first.
+func (e *ecommerceService) GetProductHistory(ctx context.Context, productID int64, request request.Pagination, path string) (response.GetProductHistoryResponse, error) {
+	var resp response.GetProductHistoryResponse
+
+	total, err := e.auditLogRepo.CountAuditLogsByProductID(ctx, productID)
+	if err != nil {
+		return resp, err
+	}
+
+	pagination, limit, offset := paginate(request, total, path)
+	auditLogs, err := e.auditLogRepo.GetAuditLogsByProductID(ctx, productID, limit, offset)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Data = auditLogs
+	resp.Pagination = pagination
+
+	return resp, nil
+}
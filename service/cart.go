@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ecommerce/model/entity"
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"ecommerce/model/weight"
+	"ecommerce/repository"
+	"errors"
+	"fmt"
+)
+
+type cartService struct {
+	cartRepo      repository.CartProvider
+	ecommerceRepo repository.EcommerceProvider
+	variantRepo   repository.VariantProvider
+}
+
+type CartConfig struct {
+	CartRepo      repository.CartProvider
+	EcommerceRepo repository.EcommerceProvider
+	VariantRepo   repository.VariantProvider
+}
+
+func NewCartService(config CartConfig) cartService {
+	cartProvider := cartService{
+		cartRepo:      config.CartRepo,
+		ecommerceRepo: config.EcommerceRepo,
+		variantRepo:   config.VariantRepo,
+	}
+
+	return cartProvider
+}
+
+func (c *cartService) GetCart(ctx context.Context, userID int64) (response.GetCartResponse, error) {
+	var resp response.GetCartResponse
+
+	cart, err := c.cartRepo.GetCartByUserID(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		resp.Data.Cart.UserID = userID
+		resp.Data.Items = []entity.CartItemDetail{}
+		return resp, nil
+	}
+	if err != nil {
+		return resp, err
+	}
+
+	cartItems, err := c.cartRepo.GetCartItemsByCartID(ctx, cart.ID)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Data.Cart = cart
+	resp.Data.Items = cartItems
+	resp.Data.Summary = summarizeCart(cartItems)
+
+	return resp, nil
+}
+
+// AddCartItem adds a product to the cart, a product with variants is added by one of its variants and each variant is
+// an item of its own. The price of the variant takes precedence over the price of the product.
+func (c *cartService) AddCartItem(ctx context.Context, userID int64, request request.UpsertCartItem) (err error) {
+	if request.Quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+
+	product, err := c.ecommerceRepo.GetProductByID(ctx, request.ProductID)
+	if err != nil {
+		return err
+	}
+
+	price, err := c.cartItemPrice(ctx, product, request.VariantID)
+	if err != nil {
+		return err
+	}
+
+	cartID, err := c.getOrCreateCartID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// the subtotal of the cart is only meaningful when every product is priced in the same currency
+	cartItems, err := c.cartRepo.GetCartItemsByCartID(ctx, cartID)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range cartItems {
+		if v.Currency != product.Price.Currency {
+			return fmt.Errorf("%w: the cart holds products priced in %s, product %d is priced in %s", ErrInvalidCurrency,
+				v.Currency, product.ID, product.Price.Currency)
+		}
+	}
+
+	cartItem, err := c.cartRepo.GetCartItemByProductID(ctx, cartID, product.ID, request.VariantID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.cartRepo.CreateCartItem(ctx, entity.CartItem{
+			CartID:    cartID,
+			ProductID: product.ID,
+			VariantID: request.VariantID,
+			Quantity:  request.Quantity,
+			Price:     price,
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	// adding the same product again refreshes the snapshot to the current price
+	cartItem.Quantity += request.Quantity
+	cartItem.Price = price
+
+	return c.cartRepo.UpdateCartItem(ctx, cartItem)
+}
+
+func (c *cartService) UpdateCartItem(ctx context.Context, userID int64, productID int64, request request.UpsertCartItem) (err error) {
+	if request.Quantity < 0 {
+		return ErrInvalidQuantity
+	}
+
+	if request.Quantity == 0 {
+		return c.RemoveCartItem(ctx, userID, productID, request.VariantID)
+	}
+
+	cartItem, err := c.getCartItem(ctx, userID, productID, request.VariantID)
+	if err != nil {
+		return err
+	}
+
+	cartItem.Quantity = request.Quantity
+
+	return c.cartRepo.UpdateCartItem(ctx, cartItem)
+}
+
+func (c *cartService) RemoveCartItem(ctx context.Context, userID int64, productID int64, variantID int64) (err error) {
+	cartItem, err := c.getCartItem(ctx, userID, productID, variantID)
+	if err != nil {
+		return err
+	}
+
+	return c.cartRepo.DeleteCartItemByProductID(ctx, cartItem.CartID, productID, variantID)
+}
+
+// cartItemPrice returns the price of the product, or of its variant when it has its own. The variant is required for
+// products with variants and must not be set for the others.
+func (c *cartService) cartItemPrice(ctx context.Context, product entity.Product, variantID int64) (int64, error) {
+	if variantID == 0 {
+		variants, err := c.variantRepo.GetProductVariantsByProductID(ctx, product.ID)
+		if err != nil {
+			return 0, err
+		}
+
+		if len(variants) > 0 {
+			return 0, fmt.Errorf("%w: a variant of product %d should be chosen", ErrInvalidVariant, product.ID)
+		}
+
+		return product.EffectivePrice.Amount, nil
+	}
+
+	variant, err := c.variantRepo.GetProductVariantByID(ctx, product.ID, variantID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("%w: product %d has no variant %d", ErrInvalidVariant, product.ID, variantID)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if variant.Price.Valid {
+		return variant.Price.Int64, nil
+	}
+
+	return product.EffectivePrice.Amount, nil
+}
+
+func (c *cartService) getOrCreateCartID(ctx context.Context, userID int64) (int64, error) {
+	cart, err := c.cartRepo.GetCartByUserID(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.cartRepo.CreateCart(ctx, userID)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return cart.ID, nil
+}
+
+func (c *cartService) getCartItem(ctx context.Context, userID int64, productID int64, variantID int64) (entity.CartItem, error) {
+	cart, err := c.cartRepo.GetCartByUserID(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.CartItem{}, ErrCartItemNotFound
+	}
+	if err != nil {
+		return entity.CartItem{}, err
+	}
+
+	cartItem, err := c.cartRepo.GetCartItemByProductID(ctx, cart.ID, productID, variantID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.CartItem{}, ErrCartItemNotFound
+	}
+	if err != nil {
+		return entity.CartItem{}, err
+	}
+
+	return cartItem, nil
+}
+
+// summarizeCart computes the subtotal from the snapshotted prices and the weight from the current products.
+func summarizeCart(cartItems []entity.CartItemDetail) response.CartSummary {
+	var summary response.CartSummary
+	for _, v := range cartItems {
+		summary.TotalQuantity += v.Quantity
+		summary.Currency = v.Currency
+		summary.Subtotal += v.Price * v.Quantity
+		summary.TotalWeight += v.Weight * weight.Grams(v.Quantity)
+	}
+
+	return summary
+}
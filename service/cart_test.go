@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ecommerce/model/entity"
+	"ecommerce/model/money"
+	"ecommerce/repository"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cartVariantRepo struct {
+	repository.VariantProvider
+	variants []entity.ProductVariant
+}
+
+func (c cartVariantRepo) GetProductVariantsByProductID(ctx context.Context, productID int64) ([]entity.ProductVariant, error) {
+	var variants []entity.ProductVariant
+	for _, v := range c.variants {
+		if v.ProductID == productID {
+			variants = append(variants, v)
+		}
+	}
+
+	return variants, nil
+}
+
+func (c cartVariantRepo) GetProductVariantByID(ctx context.Context, productID int64, id int64) (entity.ProductVariant, error) {
+	for _, v := range c.variants {
+		if v.ProductID == productID && v.ID == id {
+			return v, nil
+		}
+	}
+
+	return entity.ProductVariant{}, sql.ErrNoRows
+}
+
+func TestCartItemPrice(t *testing.T) {
+	c := &cartService{variantRepo: cartVariantRepo{variants: []entity.ProductVariant{
+		{ID: 10, ProductID: 1, Sku: "shirt-s"},
+		{ID: 11, ProductID: 1, Sku: "shirt-xl", Price: sql.NullInt64{Valid: true, Int64: 12000}},
+		{ID: 20, ProductID: 2, Sku: "mug-red"},
+	}}}
+	shirt := entity.Product{ID: 1, EffectivePrice: money.New(10000, "IDR")}
+	poster := entity.Product{ID: 3, EffectivePrice: money.New(5000, "IDR")}
+
+	tests := []struct {
+		name      string
+		product   entity.Product
+		variantID int64
+		price     int64
+		err       error
+	}{
+		{name: "variant without a price of its own", product: shirt, variantID: 10, price: 10000},
+		{name: "variant with a price of its own", product: shirt, variantID: 11, price: 12000},
+		{name: "product with variants needs one", product: shirt, err: ErrInvalidVariant},
+		{name: "variant of another product", product: shirt, variantID: 20, err: ErrInvalidVariant},
+		{name: "product without variants", product: poster, price: 5000},
+		{name: "product without variants takes no variant", product: poster, variantID: 10, err: ErrInvalidVariant},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, err := c.cartItemPrice(context.Background(), tt.product, tt.variantID)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.price, price)
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ecommerce/model/entity"
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"ecommerce/repository"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// slugSeparator matches what the category backfill migration replaces with a dash.
+var slugSeparator = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+type categoryService struct {
+	categoryRepo    repository.CategoryProvider
+	transactionRepo repository.TransactionProvider
+}
+
+type CategoryConfig struct {
+	CategoryRepo    repository.CategoryProvider
+	TransactionRepo repository.TransactionProvider
+}
+
+func NewCategoryService(config CategoryConfig) categoryService {
+	categoryProvider := categoryService{
+		categoryRepo:    config.CategoryRepo,
+		transactionRepo: config.TransactionRepo,
+	}
+
+	return categoryProvider
+}
+
+func (c *categoryService) GetCategoryTree(ctx context.Context) (response.GetCategoryListResponse, error) {
+	var resp response.GetCategoryListResponse
+
+	categories, err := c.categoryRepo.GetCategories(ctx)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Data = buildCategoryTree(categories, 0)
+	return resp, nil
+}
+
+func (c *categoryService) GetCategoryByID(ctx context.Context, id int64) (response.GetCategoryDetailResponse, error) {
+	var resp response.GetCategoryDetailResponse
+
+	category, err := c.categoryRepo.GetCategoryByID(ctx, id)
+	if err != nil {
+		return resp, err
+	}
+
+	categories, err := c.categoryRepo.GetCategories(ctx)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Data = toCategoryResponse(category)
+	resp.Data.Children = buildCategoryTree(categories, category.ID)
+
+	return resp, nil
+}
+
+func (c *categoryService) CreateCategory(ctx context.Context, request request.UpsertCategory) (err error) {
+	category, err := c.toCategory(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.categoryRepo.CreateCategory(ctx, category)
+	return err
+}
+
+func (c *categoryService) UpdateCategory(ctx context.Context, id int64, request request.UpsertCategory) (err error) {
+	return c.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		_, err := c.categoryRepo.GetCategoryByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		category, err := c.toCategory(ctx, request)
+		if err != nil {
+			return err
+		}
+		category.ID = id
+
+		// a category can't be moved below itself or one of its descendants
+		descendantIDs, err := c.categoryRepo.GetCategoryDescendantIDs(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range descendantIDs {
+			if v == request.ParentID {
+				return fmt.Errorf("%w: parent can't be the category itself or one of its sub categories", ErrInvalidCategory)
+			}
+		}
+
+		return c.categoryRepo.UpdateCategory(ctx, category)
+	})
+}
+
+func (c *categoryService) DeleteCategory(ctx context.Context, id int64) (err error) {
+	return c.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		_, err := c.categoryRepo.GetCategoryByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		totalChildren, err := c.categoryRepo.CountChildCategories(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		totalProducts, err := c.categoryRepo.CountProductsByCategoryID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if totalChildren > 0 || totalProducts > 0 {
+			return ErrCategoryInUse
+		}
+
+		return c.categoryRepo.DeleteCategory(ctx, id)
+	})
+}
+
+// toCategory validates the request and fills the slug from the name when it is empty.
+func (c *categoryService) toCategory(ctx context.Context, request request.UpsertCategory) (entity.Category, error) {
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		return entity.Category{}, fmt.Errorf("%w: name is required", ErrInvalidCategory)
+	}
+
+	slug := slugify(request.Slug)
+	if slug == "" {
+		slug = slugify(name)
+	}
+
+	if slug == "" {
+		return entity.Category{}, fmt.Errorf("%w: slug is required", ErrInvalidCategory)
+	}
+
+	category := entity.Category{
+		Name:     name,
+		Slug:     slug,
+		Position: request.Position,
+	}
+
+	if request.ParentID != 0 {
+		_, err := c.categoryRepo.GetCategoryByID(ctx, request.ParentID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.Category{}, fmt.Errorf("%w: parent category not found", ErrInvalidCategory)
+		}
+		if err != nil {
+			return entity.Category{}, err
+		}
+
+		category.ParentID = sql.NullInt64{Valid: true, Int64: request.ParentID}
+	}
+
+	return category, nil
+}
+
+// slugify lowercases s and joins its alphanumeric words with a dash.
+func slugify(s string) string {
+	return strings.Trim(strings.ToLower(slugSeparator.ReplaceAllString(strings.TrimSpace(s), "-")), "-")
+}
+
+// buildCategoryTree returns the categories below parentID, nested with their own children.
+// Root categories are returned when parentID is 0.
+func buildCategoryTree(categories []entity.Category, parentID int64) []response.Category {
+	children := make(map[int64][]entity.Category, len(categories))
+	for _, v := range categories {
+		children[v.ParentID.Int64] = append(children[v.ParentID.Int64], v)
+	}
+
+	var build func(parentID int64, visited map[int64]bool) []response.Category
+	build = func(parentID int64, visited map[int64]bool) []response.Category {
+		tree := []response.Category{}
+		for _, v := range children[parentID] {
+			if visited[v.ID] {
+				continue
+			}
+			visited[v.ID] = true
+
+			category := toCategoryResponse(v)
+			category.Children = build(v.ID, visited)
+			tree = append(tree, category)
+		}
+
+		return tree
+	}
+
+	return build(parentID, map[int64]bool{parentID: true})
+}
+
+func toCategoryResponse(category entity.Category) response.Category {
+	return response.Category{
+		ID:       category.ID,
+		ParentID: category.ParentID.Int64,
+		Name:     category.Name,
+		Slug:     category.Slug,
+		Position: category.Position,
+		Children: []response.Category{},
+	}
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"ecommerce/model/entity"
+	"ecommerce/model/money"
+	"ecommerce/model/response"
+	"fmt"
+)
+
+// defaultCurrency is the currency of the products created without one when no default currency is configured, it
+// matches the currency the existing prices were migrated to.
+const defaultCurrency = "IDR"
+
+// resolveCurrency returns currency, or fallback when it is empty.
+func resolveCurrency(currency string, fallback string) (string, error) {
+	if currency == "" {
+		currency = fallback
+	}
+
+	if !money.IsSupported(currency) {
+		return "", fmt.Errorf("%w: %q is not a supported currency", ErrInvalidCurrency, currency)
+	}
+
+	return currency, nil
+}
+
+// toProductResponses converts the products to responses with their prices in their currency. When displayCurrency is
+// not empty the effective prices are also converted to it with rates.
+func toProductResponses(products []entity.Product, rates money.Rates, displayCurrency string) ([]response.Product, error) {
+	resp := make([]response.Product, 0, len(products))
+	for _, v := range products {
+		product, err := toProductResponse(v, rates, displayCurrency)
+		if err != nil {
+			return nil, err
+		}
+
+		resp = append(resp, product)
+	}
+
+	return resp, nil
+}
+
+func toProductResponse(product entity.Product, rates money.Rates, displayCurrency string) (response.Product, error) {
+	resp := response.Product{Product: product}
+
+	if displayCurrency != "" {
+		displayPrice, err := rates.Convert(resp.EffectivePrice, displayCurrency)
+		if err != nil {
+			return response.Product{}, fmt.Errorf("%w: %v", ErrInvalidCurrency, err)
+		}
+
+		resp.DisplayPrice = &displayPrice
+	}
+
+	return resp, nil
+}
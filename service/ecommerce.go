@@ -4,23 +4,92 @@ import (
 	"context"
 	"database/sql"
 	"ecommerce/model/entity"
+	"ecommerce/model/money"
 	"ecommerce/model/request"
 	"ecommerce/model/response"
 	"ecommerce/repository"
+	"errors"
+	"fmt"
 	"math"
 )
 
+// ProductSortPopularity sorts the product list by the number of wishlists a product is on.
+const ProductSortPopularity = "popularity"
+
+// productSortColumns are the columns the product list can be sorted by.
+var productSortColumns = map[string]bool{
+	"id":               true,
+	"sku":              true,
+	"title":            true,
+	"price":            true,
+	"effective_price":  true,
+	"weight":           true,
+	"rating":           true,
+	"favourited_count": true,
+	"stock":            true,
+	"created_at":       true,
+	"updated_at":       true,
+}
+
 type ecommerceService struct {
-	ecommerceRepo repository.EcommerceProvider
+	ecommerceRepo     repository.EcommerceProvider
+	inventoryRepo     repository.InventoryProvider
+	variantRepo       repository.VariantProvider
+	categoryRepo      repository.CategoryProvider
+	attributeRepo     repository.AttributeProvider
+	imageRepo         repository.ImageProvider
+	productImportRepo repository.ProductImportProvider
+	storageRepo       repository.StorageProvider
+	auditLogRepo      repository.AuditLogProvider
+	productPriceRepo  repository.ProductPriceProvider
+	outboxRepo        repository.OutboxProvider
+	webhookRepo       repository.WebhookProvider
+	transactionRepo   repository.TransactionProvider
+	currency          string
+	exchangeRates     money.Rates
 }
 
 type EcommerceConfig struct {
-	EcommerceRepo repository.EcommerceProvider
+	EcommerceRepo     repository.EcommerceProvider
+	InventoryRepo     repository.InventoryProvider
+	VariantRepo       repository.VariantProvider
+	CategoryRepo      repository.CategoryProvider
+	AttributeRepo     repository.AttributeProvider
+	ImageRepo         repository.ImageProvider
+	ProductImportRepo repository.ProductImportProvider
+	StorageRepo       repository.StorageProvider
+	AuditLogRepo      repository.AuditLogProvider
+	ProductPriceRepo  repository.ProductPriceProvider
+	OutboxRepo        repository.OutboxProvider
+	WebhookRepo       repository.WebhookProvider
+	TransactionRepo   repository.TransactionProvider
+	// Currency is the currency of the products created without one
+	Currency string
+	// ExchangeRates converts the prices of the product list to the display currency requested by the client
+	ExchangeRates money.Rates
 }
 
 func NewEcommerceService(config EcommerceConfig) ecommerceService {
 	ecommerceProvider := ecommerceService{
-		ecommerceRepo: config.EcommerceRepo,
+		ecommerceRepo:     config.EcommerceRepo,
+		inventoryRepo:     config.InventoryRepo,
+		variantRepo:       config.VariantRepo,
+		categoryRepo:      config.CategoryRepo,
+		attributeRepo:     config.AttributeRepo,
+		imageRepo:         config.ImageRepo,
+		productImportRepo: config.ProductImportRepo,
+		storageRepo:       config.StorageRepo,
+		auditLogRepo:      config.AuditLogRepo,
+		productPriceRepo:  config.ProductPriceRepo,
+		outboxRepo:        config.OutboxRepo,
+		webhookRepo:       config.WebhookRepo,
+		transactionRepo:   config.TransactionRepo,
+		currency:          config.Currency,
+		exchangeRates:     config.ExchangeRates,
+	}
+
+	if ecommerceProvider.currency == "" {
+		ecommerceProvider.currency = defaultCurrency
 	}
 
 	return ecommerceProvider
@@ -29,87 +98,310 @@ func NewEcommerceService(config EcommerceConfig) ecommerceService {
 func (e *ecommerceService) GetProductList(ctx context.Context, payload request.FilterProduct) (response.GetProductListResponse, error) {
 	var resp response.GetProductListResponse
 
+	payload = normalizeProductFilter(payload, e.currency)
+	if payload.Currency != "" && !money.IsSupported(payload.Currency) {
+		return resp, fmt.Errorf("%w: %q is not a supported currency", ErrInvalidCurrency, payload.Currency)
+	}
+
 	products, err := e.ecommerceRepo.GetProductList(ctx, payload)
 	if err != nil {
 		return resp, err
 	}
 
-	resp.Data = products
+	if payload.IncludeFacets {
+		resp.Facets, err = e.getProductFacets(ctx, payload)
+		if err != nil {
+			return resp, err
+		}
+	}
+
+	resp.Data, err = toProductResponses(products, e.exchangeRates, payload.Currency)
+	if err != nil {
+		return resp, err
+	}
+
 	return resp, nil
 }
 
+// normalizeProductFilter falls back to sorting by id since the sort column ends up in the query as is. Sorting by
+// popularity sorts by the number of wishlists a product is on. The prices are filtered in the display currency, else
+// in currency, the default currency of the store.
+func normalizeProductFilter(payload request.FilterProduct, currency string) request.FilterProduct {
+	payload.PriceCurrency = payload.Currency
+	if payload.PriceCurrency == "" {
+		payload.PriceCurrency = currency
+	}
+
+	payload.AttributeValues = attributeFilterValues(payload.Attributes)
+
+	if payload.Sort == ProductSortPopularity {
+		payload.Sort = "favourited_count"
+	}
+
+	if !productSortColumns[payload.Sort] {
+		payload.Sort = "id"
+	}
+
+	return payload
+}
+
 func (e *ecommerceService) CreateProduct(ctx context.Context, request request.UpsertProduct) (err error) {
+	if request.Stock < 0 {
+		return ErrInvalidStock
+	}
+
+	err = validateProductVariants(request.Options, request.Variants)
+	if err != nil {
+		return err
+	}
+
+	attributes, err := toProductAttributes(request.Attributes)
+	if err != nil {
+		return err
+	}
+
+	categoryID, category, err := e.resolveProductCategory(ctx, request.CategoryID, request.Category)
+	if err != nil {
+		return err
+	}
+
+	currency, err := resolveCurrency(request.Price.Currency, e.currency)
+	if err != nil {
+		return err
+	}
+
 	product := entity.Product{
 		UserID:      request.UserID,
 		Sku:         request.Sku,
 		Title:       request.Title,
-		Category:    request.Category,
+		Category:    category,
+		CategoryID:  categoryID,
 		Description: request.Description,
 		Etalase:     request.Etalase,
-		Price:       request.Price,
+		Price:       money.New(request.Price.Amount, currency),
 		Weight:      request.Weight,
+		Length:      request.Length,
+		Width:       request.Width,
+		Height:      request.Height,
+		Stock:       request.Stock,
+	}
+
+	return e.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		productID, err := e.ecommerceRepo.CreateProduct(ctx, product)
+		if err != nil {
+			return err
+		}
+
+		product.ID = productID
+		err = e.recordAudit(ctx, entity.AuditEntityProduct, productID, productID, nil, product)
+		if err != nil {
+			return err
+		}
+
+		err = e.recordProductEvent(ctx, productID, entity.EventProductCreated, toProductEvent(product))
+		if err != nil {
+			return err
+		}
+
+		err = e.scheduleRegularPrice(ctx, productID, product.Price.Amount, priceNow())
+		if err != nil {
+			return err
+		}
+
+		if product.Stock > 0 {
+			err = e.inventoryRepo.CreateInventoryLedger(ctx, entity.InventoryLedger{
+				ProductID:      productID,
+				QuantityChange: product.Stock,
+				StockAfter:     product.Stock,
+				Reason:         entity.InventoryReasonInitialStock,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		err = e.saveProductImages(ctx, productID, request)
+		if err != nil {
+			return err
+		}
+
+		err = e.saveProductVariants(ctx, productID, request.Options, request.Variants)
+		if err != nil {
+			return err
+		}
+
+		return e.saveProductAttributes(ctx, productID, attributes)
+	})
+}
+
+func (e *ecommerceService) UpdateProduct(ctx context.Context, id int64, request request.UpsertProduct) (err error) {
+	err = validateProductVariants(request.Options, request.Variants)
+	if err != nil {
+		return err
 	}
 
-	productID, err := e.ecommerceRepo.CreateProduct(ctx, product)
+	attributes, err := toProductAttributes(request.Attributes)
 	if err != nil {
 		return err
 	}
 
-	for _, v := range request.ProductImages {
-		productImage := entity.ProductImage{
-			ProductID: productID,
-			ImageUrl:  v.ImageUrl,
+	categoryID, category, err := e.resolveProductCategory(ctx, request.CategoryID, request.Category)
+	if err != nil {
+		return err
+	}
+
+	return e.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		err := e.ecommerceRepo.LockProduct(ctx, id)
+		if err != nil {
+			return err
 		}
 
-		if v.ShortDescription != "" {
-			productImage.ShortDescription = sql.NullString{
-				Valid:  true,
-				String: v.ShortDescription,
+		product, err := e.ecommerceRepo.GetProductByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if request.Price.Currency != "" && request.Price.Currency != product.Price.Currency {
+			return fmt.Errorf("%w: the currency of product %d can't be changed from %s", ErrInvalidCurrency, id, product.Price.Currency)
+		}
+
+		productRequest := entity.Product{
+			ID:          id,
+			UserID:      request.UserID,
+			Sku:         request.Sku,
+			Title:       request.Title,
+			Category:    category,
+			CategoryID:  categoryID,
+			Description: request.Description,
+			Etalase:     request.Etalase,
+			Price:       money.New(request.Price.Amount, product.Price.Currency),
+			Weight:      request.Weight,
+			Length:      request.Length,
+			Width:       request.Width,
+			Height:      request.Height,
+			Rating:      product.Rating,
+			Stock:       product.Stock,
+		}
+
+		err = e.ecommerceRepo.UpdateProduct(ctx, productRequest)
+		if err != nil {
+			return err
+		}
+
+		err = e.recordAudit(ctx, entity.AuditEntityProduct, id, id, product, productRequest)
+		if err != nil {
+			return err
+		}
+
+		err = e.recordProductEvent(ctx, id, entity.EventProductUpdated, toProductEvent(productRequest))
+		if err != nil {
+			return err
+		}
+
+		// the price of the product is the regular price in effect, so a product sent back as it was read keeps a
+		// regular price scheduled since its last update
+		if productRequest.Price.Amount != product.Price.Amount {
+			err = e.scheduleRegularPrice(ctx, id, productRequest.Price.Amount, priceNow())
+			if err != nil {
+				return err
 			}
 		}
 
-		err = e.ecommerceRepo.CreateProductImages(ctx, productImage)
+		err = e.saveProductImages(ctx, id, request)
 		if err != nil {
 			return err
 		}
+
+		// options and variants left out of the request are kept, sending either replaces both
+		if request.Options != nil || request.Variants != nil {
+			err = e.saveProductVariants(ctx, id, request.Options, request.Variants)
+			if err != nil {
+				return err
+			}
+		}
+
+		return e.saveProductAttributes(ctx, id, attributes)
+	})
+}
+
+// resolveProductCategory returns the category id and name of a product. Clients still sending the category as free
+// text get it matched by slug, it is kept as is when no category matches.
+func (e *ecommerceService) resolveProductCategory(ctx context.Context, categoryID int64, category string) (int64, string, error) {
+	if categoryID == 0 {
+		found, err := e.categoryRepo.GetCategoryBySlug(ctx, slugify(category))
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, category, nil
+		}
+		if err != nil {
+			return 0, "", err
+		}
+
+		return found.ID, found.Name, nil
 	}
 
-	return nil
+	found, err := e.categoryRepo.GetCategoryByID(ctx, categoryID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, "", fmt.Errorf("%w: category %d not found", ErrInvalidCategory, categoryID)
+	}
+	if err != nil {
+		return 0, "", err
+	}
+
+	return found.ID, found.Name, nil
 }
 
-func (e *ecommerceService) UpdateProduct(ctx context.Context, id int64, request request.UpsertProduct) (err error) {
-	product, err := e.ecommerceRepo.GetProductByID(ctx, id)
+// saveProductImages replaces the images of a product with the images of the request and records what changed.
+func (e *ecommerceService) saveProductImages(ctx context.Context, productID int64, request request.UpsertProduct) (err error) {
+	oldImages, err := e.ecommerceRepo.GetProductImagesByProductID(ctx, productID)
 	if err != nil {
 		return err
 	}
 
-	productRequest := entity.Product{
-		ID:          id,
-		UserID:      request.UserID,
-		Sku:         request.Sku,
-		Title:       request.Title,
-		Category:    request.Category,
-		Description: request.Description,
-		Etalase:     request.Etalase,
-		Price:       request.Price,
-		Weight:      request.Weight,
-		Rating:      product.Rating,
+	err = e.ecommerceRepo.DeleteProductImagesByID(ctx, productID)
+	if err != nil {
+		return err
 	}
 
-	err = e.ecommerceRepo.UpdateProduct(ctx, productRequest)
+	err = e.createProductImages(ctx, productID, request)
 	if err != nil {
 		return err
 	}
 
-	err = e.ecommerceRepo.DeleteProductImagesByID(ctx, id)
+	return e.recordProductImageChanges(ctx, productID, oldImages)
+}
+
+// recordProductImageChanges records the changes from oldImages to the images the product has now.
+func (e *ecommerceService) recordProductImageChanges(ctx context.Context, productID int64, oldImages []entity.ProductImage) (err error) {
+	images, err := e.ecommerceRepo.GetProductImagesByProductID(ctx, productID)
 	if err != nil {
 		return err
 	}
 
-	for _, v := range request.ProductImages {
+	return e.recordProductImageAudits(ctx, productID, oldImages, images)
+}
+
+// createProductImages stores the images in the order of the request, the first image is the primary image unless
+// another one is marked as primary.
+func (e *ecommerceService) createProductImages(ctx context.Context, productID int64, request request.UpsertProduct) (err error) {
+	primary := 0
+	totalPrimary := 0
+	for i, v := range request.ProductImages {
+		if v.IsPrimary {
+			primary = i
+			totalPrimary++
+		}
+	}
+
+	if totalPrimary > 1 {
+		return fmt.Errorf("%w: only one image can be primary", ErrInvalidProductImage)
+	}
+
+	for i, v := range request.ProductImages {
 		productImage := entity.ProductImage{
-			ProductID: id,
+			ProductID: productID,
 			ImageUrl:  v.ImageUrl,
+			Position:  i,
+			IsPrimary: i == primary,
 		}
 
 		if v.ShortDescription != "" {
@@ -128,6 +420,83 @@ func (e *ecommerceService) UpdateProduct(ctx context.Context, id int64, request
 	return nil
 }
 
+// ReorderProductImages moves the images of the product to the order of their ids in the request.
+func (e *ecommerceService) ReorderProductImages(ctx context.Context, productID int64, request request.ReorderProductImages) (err error) {
+	return e.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		err := e.ecommerceRepo.LockProduct(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		images, err := e.ecommerceRepo.GetProductImagesByProductID(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		imageIDs := make(map[int64]bool, len(images))
+		for _, v := range images {
+			imageIDs[v.ID] = true
+		}
+
+		if len(request.ImageIDs) != len(images) {
+			return fmt.Errorf("%w: every image of the product should be ordered exactly once", ErrInvalidProductImage)
+		}
+
+		for _, id := range request.ImageIDs {
+			if !imageIDs[id] {
+				return fmt.Errorf("%w: every image of the product should be ordered exactly once", ErrInvalidProductImage)
+			}
+			delete(imageIDs, id)
+		}
+
+		for i, id := range request.ImageIDs {
+			err = e.ecommerceRepo.UpdateProductImagePosition(ctx, productID, id, i)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = e.recordProductImageChanges(ctx, productID, images)
+		if err != nil {
+			return err
+		}
+
+		return e.recordProductImagesEvent(ctx, productID)
+	})
+}
+
+func (e *ecommerceService) SetPrimaryProductImage(ctx context.Context, productID int64, imageID int64) (err error) {
+	return e.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		err := e.ecommerceRepo.LockProduct(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		images, err := e.ecommerceRepo.GetProductImagesByProductID(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range images {
+			if v.ID == imageID {
+				err = e.ecommerceRepo.SetPrimaryProductImage(ctx, productID, imageID)
+				if err != nil {
+					return err
+				}
+
+				err = e.recordProductImageChanges(ctx, productID, images)
+				if err != nil {
+					return err
+				}
+
+				return e.recordProductImagesEvent(ctx, productID)
+			}
+		}
+
+		return ErrProductImageNotFound
+	})
+}
+
 func (e *ecommerceService) GetProductByID(ctx context.Context, id int64) (response.GetProductDetailResponse, error) {
 	var resp response.GetProductDetailResponse
 
@@ -136,7 +505,7 @@ func (e *ecommerceService) GetProductByID(ctx context.Context, id int64) (respon
 		return resp, err
 	}
 
-	productImages, err := e.ecommerceRepo.GetProductImagesByProductID(ctx, id)
+	productImages, err := e.getProductImages(ctx, id)
 	if err != nil {
 		return resp, err
 	}
@@ -146,9 +515,31 @@ func (e *ecommerceService) GetProductByID(ctx context.Context, id int64) (respon
 		return resp, err
 	}
 
-	resp.Data.Product = products
+	productOptions, productVariants, err := e.getProductVariants(ctx, products)
+	if err != nil {
+		return resp, err
+	}
+
+	productAttributes, err := e.attributeRepo.GetProductAttributesByProductID(ctx, id)
+	if err != nil {
+		return resp, err
+	}
+
+	for _, v := range productImages {
+		if v.IsPrimary {
+			products.PrimaryImageUrl = v.ImageUrl
+		}
+	}
+
+	resp.Data.Product, err = toProductResponse(products, e.exchangeRates, "")
+	if err != nil {
+		return resp, err
+	}
 	resp.Data.ProductImages = productImages
 	resp.Data.Review = productReview
+	resp.Data.Options = productOptions
+	resp.Data.Variants = productVariants
+	resp.Data.Attributes = productAttributes
 
 	return resp, nil
 }
@@ -167,33 +558,53 @@ func (e *ecommerceService) CreateProductReview(ctx context.Context, request requ
 		}
 	}
 
-	err = e.ecommerceRepo.CreateProductReview(ctx, productReview)
-	if err != nil {
-		return err
-	}
+	return e.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		err := e.ecommerceRepo.LockProduct(ctx, request.ProductID)
+		if err != nil {
+			return err
+		}
 
-	product, err := e.ecommerceRepo.GetProductByID(ctx, request.ProductID)
-	if err != nil {
-		return err
-	}
+		productReview.ID, err = e.ecommerceRepo.CreateProductReview(ctx, productReview)
+		if err != nil {
+			return err
+		}
 
-	productReviews, err := e.ecommerceRepo.GetProductReviewByProductID(ctx, request.ProductID)
-	if err != nil {
-		return err
-	}
+		product, err := e.ecommerceRepo.GetProductByID(ctx, request.ProductID)
+		if err != nil {
+			return err
+		}
 
-	totalRating := 0
-	for _, v := range productReviews {
-		totalRating += v.Rating
-	}
+		productReviews, err := e.ecommerceRepo.GetProductReviewByProductID(ctx, request.ProductID)
+		if err != nil {
+			return err
+		}
 
-	rating := float64(totalRating) / float64(len(productReviews))
-	product.Rating = math.Round(rating*10) / 10
+		totalRating := 0
+		for _, v := range productReviews {
+			totalRating += v.Rating
+		}
 
-	err = e.ecommerceRepo.UpdateProduct(ctx, product)
-	if err != nil {
-		return err
-	}
+		oldProduct := product
+		rating := float64(totalRating) / float64(len(productReviews))
+		product.Rating = math.Round(rating*10) / 10
 
-	return nil
+		err = e.ecommerceRepo.UpdateProduct(ctx, product)
+		if err != nil {
+			return err
+		}
+
+		err = e.recordAudit(ctx, entity.AuditEntityProduct, product.ID, product.ID, oldProduct, product)
+		if err != nil {
+			return err
+		}
+
+		return recordSellerEvent(ctx, e.outboxRepo, e.webhookRepo, product.UserID, entity.EventAggregateProduct, product.ID, entity.EventReviewCreated, reviewEvent{
+			ID:            productReview.ID,
+			ProductID:     product.ID,
+			SellerID:      product.UserID,
+			Rating:        productReview.Rating,
+			Comment:       productReview.Comment.String,
+			ProductRating: product.Rating,
+		})
+	})
 }
@@ -0,0 +1,44 @@
+package service
+
+import "errors"
+
+var (
+	ErrInvalidQuantity        = errors.New("quantity should be greater than 0")
+	ErrCartItemNotFound       = errors.New("cart item not found")
+	ErrEmptyCart              = errors.New("cart is empty")
+	ErrInvalidOrderStatus     = errors.New("order status is not valid")
+	ErrIllegalOrderTransition = errors.New("order status transition is not allowed")
+	ErrInvalidStock           = errors.New("stock should not be negative")
+	ErrInsufficientStock      = errors.New("insufficient stock")
+	ErrInvalidVariant         = errors.New("product variant is not valid")
+	ErrInvalidCategory        = errors.New("category is not valid")
+	ErrCategoryInUse          = errors.New("category still has sub categories or products")
+	ErrEtalaseNotFound        = errors.New("etalase not found")
+	ErrInvalidEtalase         = errors.New("etalase is not valid")
+	ErrInvalidSeller          = errors.New("seller is not valid")
+	ErrInvalidAttribute       = errors.New("product attribute is not valid")
+	ErrInvalidImage           = errors.New("image is not valid")
+	ErrImageTooLarge          = errors.New("image is too large")
+	ErrProductImageNotFound   = errors.New("product image not found")
+	ErrInvalidProductImage    = errors.New("product image is not valid")
+	ErrInvalidImport          = errors.New("product import is not valid")
+	ErrImportReportNotFound   = errors.New("product import report not found")
+	ErrInvalidExport          = errors.New("product export is not valid")
+	ErrFeedNotFound           = errors.New("product feed not found")
+	ErrInvalidProductPrice    = errors.New("product price is not valid")
+	ErrProductPriceNotFound   = errors.New("product price not found")
+	ErrInvalidCurrency        = errors.New("currency is not valid")
+	ErrInvalidPromotion       = errors.New("promotion is not valid")
+	ErrPromotionInUse         = errors.New("promotion has been used")
+	ErrInvalidDestination     = errors.New("shipping destination is not valid")
+	ErrInvalidPayment         = errors.New("payment is not valid")
+	ErrInvalidWebhook         = errors.New("webhook is not valid")
+	ErrInvalidReturn          = errors.New("return request is not valid")
+	ErrReturnNotFound         = errors.New("return request not found")
+	ErrWishlistItemNotFound   = errors.New("wishlist item not found")
+	ErrInvalidInvoice         = errors.New("invoice is not valid")
+	ErrInvoiceNotFound        = errors.New("invoice not found")
+	ErrInvalidSubscription    = errors.New("webhook subscription is not valid")
+	ErrSubscriptionNotFound   = errors.New("webhook subscription not found")
+	ErrDeliveryNotFound       = errors.New("webhook delivery not found")
+)
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ecommerce/model/entity"
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"ecommerce/repository"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type etalaseService struct {
+	etalaseRepo     repository.EtalaseProvider
+	ecommerceRepo   repository.EcommerceProvider
+	transactionRepo repository.TransactionProvider
+}
+
+type EtalaseConfig struct {
+	EtalaseRepo     repository.EtalaseProvider
+	EcommerceRepo   repository.EcommerceProvider
+	TransactionRepo repository.TransactionProvider
+}
+
+func NewEtalaseService(config EtalaseConfig) etalaseService {
+	etalaseProvider := etalaseService{
+		etalaseRepo:     config.EtalaseRepo,
+		ecommerceRepo:   config.EcommerceRepo,
+		transactionRepo: config.TransactionRepo,
+	}
+
+	return etalaseProvider
+}
+
+func (e *etalaseService) GetEtalaseList(ctx context.Context, userID int64) (response.GetEtalaseListResponse, error) {
+	var resp response.GetEtalaseListResponse
+
+	etalases, err := e.etalaseRepo.GetEtalasesByUserID(ctx, userID)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Data = etalases
+	return resp, nil
+}
+
+func (e *etalaseService) CreateEtalase(ctx context.Context, userID int64, request request.UpsertEtalase) (err error) {
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidEtalase)
+	}
+
+	_, err = e.etalaseRepo.CreateEtalase(ctx, entity.Etalase{
+		UserID:   userID,
+		Name:     name,
+		Position: request.Position,
+	})
+
+	return err
+}
+
+func (e *etalaseService) UpdateEtalase(ctx context.Context, userID int64, id int64, request request.UpsertEtalase) (err error) {
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidEtalase)
+	}
+
+	etalase, err := e.getSellerEtalase(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	etalase.Name = name
+	etalase.Position = request.Position
+
+	return e.etalaseRepo.UpdateEtalase(ctx, etalase)
+}
+
+func (e *etalaseService) DeleteEtalase(ctx context.Context, userID int64, id int64) (err error) {
+	return e.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		_, err := e.getSellerEtalase(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+
+		return e.etalaseRepo.DeleteEtalase(ctx, id)
+	})
+}
+
+func (e *etalaseService) AssignEtalaseProducts(ctx context.Context, userID int64, id int64, request request.AssignEtalaseProducts) (err error) {
+	return e.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		_, err := e.getSellerEtalase(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+
+		for _, productID := range request.ProductIDs {
+			product, err := e.ecommerceRepo.GetProductByID(ctx, productID)
+			if errors.Is(err, sql.ErrNoRows) || (err == nil && product.UserID != userID) {
+				return fmt.Errorf("%w: product %d doesn't belong to the seller", ErrInvalidEtalase, productID)
+			}
+			if err != nil {
+				return err
+			}
+
+			err = e.etalaseRepo.AddProductToEtalase(ctx, id, productID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (e *etalaseService) RemoveEtalaseProduct(ctx context.Context, userID int64, id int64, productID int64) (err error) {
+	_, err = e.getSellerEtalase(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	return e.etalaseRepo.RemoveProductFromEtalase(ctx, id, productID)
+}
+
+func (e *etalaseService) GetEtalaseProductList(ctx context.Context, userID int64, id int64, request request.Pagination, path string) (response.GetEtalaseProductListResponse, error) {
+	var resp response.GetEtalaseProductListResponse
+
+	_, err := e.getSellerEtalase(ctx, userID, id)
+	if err != nil {
+		return resp, err
+	}
+
+	total, err := e.etalaseRepo.CountProductsByEtalaseID(ctx, id)
+	if err != nil {
+		return resp, err
+	}
+
+	pagination, limit, offset := paginate(request, total, path)
+	products, err := e.etalaseRepo.GetProductsByEtalaseID(ctx, id, limit, offset)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Data, err = toProductResponses(products, nil, "")
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Pagination = pagination
+
+	return resp, nil
+}
+
+// getSellerEtalase returns the etalase when it is owned by the seller.
+func (e *etalaseService) getSellerEtalase(ctx context.Context, userID int64, id int64) (entity.Etalase, error) {
+	etalase, err := e.etalaseRepo.GetEtalaseByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && etalase.UserID != userID) {
+		return entity.Etalase{}, ErrEtalaseNotFound
+	}
+	if err != nil {
+		return entity.Etalase{}, err
+	}
+
+	return etalase, nil
+}
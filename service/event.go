@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"ecommerce/model/entity"
+	"ecommerce/model/money"
+	"ecommerce/model/weight"
+	"ecommerce/repository"
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// outboxRelayBatchSize is how many events a relay run publishes at most.
+const outboxRelayBatchSize = 100
+
+// productEvent is the payload of the created and updated events of a product.
+type productEvent struct {
+	ID          int64        `json:"id"`
+	SellerID    int64        `json:"seller_id"`
+	Sku         string       `json:"sku"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	CategoryID  int64        `json:"category_id"`
+	Category    string       `json:"category"`
+	Etalase     string       `json:"etalase"`
+	Price       money.Money  `json:"price"`
+	Weight      weight.Grams `json:"weight"`
+	Stock       int64        `json:"stock"`
+	Rating      float64      `json:"rating"`
+}
+
+// productImagesEvent is the payload of the event of the images of a product being reordered or given a new primary
+// image.
+type productImagesEvent struct {
+	ProductID int64                `json:"product_id"`
+	Images    []productImageDetail `json:"images"`
+}
+
+type productImageDetail struct {
+	ID        int64  `json:"id"`
+	ImageUrl  string `json:"image_url"`
+	Position  int    `json:"position"`
+	IsPrimary bool   `json:"is_primary"`
+}
+
+// productPriceEvent is the payload of the events of a price of a product being scheduled or cancelled.
+type productPriceEvent struct {
+	ProductID     int64       `json:"product_id"`
+	PriceID       int64       `json:"price_id,omitempty"`
+	Kind          string      `json:"kind"`
+	Price         money.Money `json:"price"`
+	EffectiveFrom time.Time   `json:"effective_from"`
+	EffectiveTo   *time.Time  `json:"effective_to,omitempty"`
+}
+
+// reviewEvent is the payload of the event of a product being reviewed.
+type reviewEvent struct {
+	ID            int64   `json:"id"`
+	ProductID     int64   `json:"product_id"`
+	SellerID      int64   `json:"seller_id"`
+	Rating        int     `json:"rating"`
+	Comment       string  `json:"comment"`
+	ProductRating float64 `json:"product_rating"`
+}
+
+// orderEvent is the payload of the events of an order, an event is recorded for each seller with the items of the
+// seller only.
+type orderEvent struct {
+	OrderID  int64            `json:"order_id"`
+	SellerID int64            `json:"seller_id"`
+	UserID   int64            `json:"user_id"`
+	Items    []orderEventItem `json:"items"`
+	Subtotal money.Money      `json:"subtotal"`
+	Discount money.Money      `json:"discount"`
+	Total    money.Money      `json:"total"`
+}
+
+type orderEventItem struct {
+	OrderItemID int64       `json:"order_item_id"`
+	ProductID   int64       `json:"product_id"`
+	Sku         string      `json:"sku"`
+	Title       string      `json:"title"`
+	Quantity    int64       `json:"quantity"`
+	Price       money.Money `json:"price"`
+	Discount    money.Money `json:"discount"`
+}
+
+func toProductEvent(product entity.Product) productEvent {
+	return productEvent{
+		ID:          product.ID,
+		SellerID:    product.UserID,
+		Sku:         product.Sku,
+		Title:       product.Title,
+		Description: product.Description,
+		CategoryID:  product.CategoryID,
+		Category:    product.Category,
+		Etalase:     product.Etalase,
+		Price:       product.Price,
+		Weight:      product.Weight,
+		Stock:       product.Stock,
+		Rating:      product.Rating,
+	}
+}
+
+// recordEvent writes an event to the outbox. It has to run in the transaction of the change the event describes, so
+// the event is published if and only if the change is committed.
+func recordEvent(ctx context.Context, outboxRepo repository.OutboxProvider, aggregateType string, aggregateID int64, eventType string, payload interface{}) error {
+	_, err := writeEvent(ctx, outboxRepo, aggregateType, aggregateID, eventType, payload)
+	return err
+}
+
+// writeEvent writes an event to the outbox like recordEvent and returns it.
+func writeEvent(ctx context.Context, outboxRepo repository.OutboxProvider, aggregateType string, aggregateID int64, eventType string, payload interface{}) (entity.OutboxEvent, error) {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return entity.OutboxEvent{}, err
+	}
+
+	event := entity.OutboxEvent{
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Type:          eventType,
+		Payload:       encoded,
+	}
+
+	event.ID, err = outboxRepo.CreateOutboxEvent(ctx, event)
+	if err != nil {
+		return entity.OutboxEvent{}, err
+	}
+
+	return event, nil
+}
+
+// recordProductEvent writes an event of the product to the outbox.
+func (e *ecommerceService) recordProductEvent(ctx context.Context, productID int64, eventType string, payload interface{}) error {
+	return recordEvent(ctx, e.outboxRepo, entity.EventAggregateProduct, productID, eventType, payload)
+}
+
+type eventService struct {
+	outboxRepo      repository.OutboxProvider
+	publisher       repository.EventPublisher
+	transactionRepo repository.TransactionProvider
+}
+
+type EventConfig struct {
+	OutboxRepo      repository.OutboxProvider
+	Publisher       repository.EventPublisher
+	TransactionRepo repository.TransactionProvider
+}
+
+func NewEventService(config EventConfig) eventService {
+	return eventService{
+		outboxRepo:      config.OutboxRepo,
+		publisher:       config.Publisher,
+		transactionRepo: config.TransactionRepo,
+	}
+}
+
+// RelayEvents publishes the events of the outbox in the order they were written. An event is marked published only
+// after the publisher accepted it, so an event is published again when the relay stops in between: delivery is at
+// least once. A failing event stops the run so the events after it are not published before it.
+func (e *eventService) RelayEvents(ctx context.Context) (err error) {
+	var publishErr error
+	err = e.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		events, err := e.outboxRepo.ClaimOutboxEvents(ctx, outboxRelayBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			publishErr = e.publisher.Publish(ctx, event)
+			if publishErr != nil {
+				// the events published before are still marked, so the transaction is committed
+				return e.outboxRepo.MarkOutboxEventFailed(ctx, event.ID, publishErr.Error())
+			}
+
+			err = e.outboxRepo.MarkOutboxEventPublished(ctx, event.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return publishErr
+}
+
+// recordProductImagesEvent writes the images the product has now to the outbox.
+func (e *ecommerceService) recordProductImagesEvent(ctx context.Context, productID int64) error {
+	images, err := e.ecommerceRepo.GetProductImagesByProductID(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	payload := productImagesEvent{ProductID: productID, Images: []productImageDetail{}}
+	for _, v := range images {
+		payload.Images = append(payload.Images, productImageDetail{
+			ID:        v.ID,
+			ImageUrl:  v.ImageUrl,
+			Position:  v.Position,
+			IsPrimary: v.IsPrimary,
+		})
+	}
+
+	return e.recordProductEvent(ctx, productID, entity.EventProductImagesChanged, payload)
+}
+
+// recordProductPriceCancelledEvent writes the cancellation of a price of the product to the outbox.
+func (e *ecommerceService) recordProductPriceCancelledEvent(ctx context.Context, price entity.ProductPrice) error {
+	product, err := e.ecommerceRepo.GetProductByID(ctx, price.ProductID)
+	if err != nil {
+		return err
+	}
+
+	payload := productPriceEvent{
+		ProductID:     price.ProductID,
+		PriceID:       price.ID,
+		Kind:          price.Kind,
+		Price:         money.New(price.Price, product.Price.Currency),
+		EffectiveFrom: price.EffectiveFrom,
+	}
+	if price.EffectiveTo.Valid {
+		payload.EffectiveTo = &price.EffectiveTo.Time
+	}
+
+	return e.recordProductEvent(ctx, price.ProductID, entity.EventProductPriceCancelled, payload)
+}
+
+// recordOrderEvents writes an event of the order for each of its sellers to the outbox, in the order of the seller
+// ids, and queues its delivery to the webhooks of the seller.
+func (o *orderService) recordOrderEvents(ctx context.Context, order entity.Order, eventType string) error {
+	orderItems, err := o.orderRepo.GetOrderItemsByOrderID(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+
+	events := map[int64]*orderEvent{}
+	var sellerIDs []int64
+	for _, v := range orderItems {
+		event, ok := events[v.SellerID]
+		if !ok {
+			event = &orderEvent{
+				OrderID:  order.ID,
+				SellerID: v.SellerID,
+				UserID:   order.UserID,
+				Subtotal: money.New(0, order.Currency),
+				Discount: money.New(0, order.Currency),
+				Total:    money.New(0, order.Currency),
+			}
+			events[v.SellerID] = event
+			sellerIDs = append(sellerIDs, v.SellerID)
+		}
+
+		event.Items = append(event.Items, orderEventItem{
+			OrderItemID: v.ID,
+			ProductID:   v.ProductID,
+			Sku:         v.Sku,
+			Title:       v.Title,
+			Quantity:    v.Quantity,
+			Price:       money.New(v.Price, order.Currency),
+			Discount:    money.New(v.Discount, order.Currency),
+		})
+		event.Subtotal.Amount += v.Price * v.Quantity
+		event.Discount.Amount += v.Discount
+		event.Total.Amount += v.Price*v.Quantity - v.Discount
+	}
+
+	sort.Slice(sellerIDs, func(i, j int) bool {
+		return sellerIDs[i] < sellerIDs[j]
+	})
+
+	for _, id := range sellerIDs {
+		err = recordSellerEvent(ctx, o.outboxRepo, o.webhookRepo, id, entity.EventAggregateOrder, order.ID, eventType, events[id])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
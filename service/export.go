@@ -0,0 +1,204 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"ecommerce/model/entity"
+	"ecommerce/model/money"
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"ecommerce/model/weight"
+	"ecommerce/utils/xlsx"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ProductExportFormatCSV    = "csv"
+	ProductExportFormatNDJSON = "ndjson"
+	ProductExportFormatXLSX   = "xlsx"
+)
+
+// productExportContentTypes are the supported export formats with their content type.
+var productExportContentTypes = map[string]string{
+	ProductExportFormatCSV:    "text/csv",
+	ProductExportFormatNDJSON: "application/x-ndjson",
+	ProductExportFormatXLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// productExportColumns are the header of the csv and xlsx exports, in the order of productExportRecord.
+var productExportColumns = []interface{}{
+	"id", "user_id", "sku", "title", "description", "category", "category_id", "etalase", "weight", "length", "width",
+	"height", "price", "currency", "stock", "rating", "primary_image_url", "image_urls", "created_at", "updated_at",
+}
+
+// productExportRow is a line of the ndjson export.
+type productExportRow struct {
+	ID              int64        `json:"id"`
+	UserID          int64        `json:"user_id"`
+	Sku             string       `json:"sku"`
+	Title           string       `json:"title"`
+	Description     string       `json:"description"`
+	Category        string       `json:"category"`
+	CategoryID      int64        `json:"category_id"`
+	Etalase         string       `json:"etalase"`
+	Weight          weight.Grams `json:"weight"`
+	Length          float64      `json:"length"`
+	Width           float64      `json:"width"`
+	Height          float64      `json:"height"`
+	Price           money.Money  `json:"price"`
+	Stock           int64        `json:"stock"`
+	Rating          float64      `json:"rating"`
+	PrimaryImageUrl string       `json:"primary_image_url"`
+	ImageUrls       []string     `json:"image_urls"`
+	CreatedAt       time.Time    `json:"created_at"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+}
+
+// ExportProducts prepares the export of the products matching payload in format, csv by default. Nothing is read
+// until the Write of the export is called, the products are then streamed to its writer.
+func (e *ecommerceService) ExportProducts(ctx context.Context, payload request.FilterProduct, format string) (response.ProductExport, error) {
+	var resp response.ProductExport
+
+	if format == "" {
+		format = ProductExportFormatCSV
+	}
+
+	contentType, ok := productExportContentTypes[format]
+	if !ok {
+		return resp, fmt.Errorf("%w: format %q is not supported", ErrInvalidExport, format)
+	}
+
+	payload = normalizeProductFilter(payload, e.currency)
+
+	resp.ContentType = contentType
+	resp.FileName = "products." + format
+	resp.Write = func(w io.Writer) error {
+		switch format {
+		case ProductExportFormatNDJSON:
+			return e.exportProductsNDJSON(ctx, payload, w)
+		case ProductExportFormatXLSX:
+			return e.exportProductsXLSX(ctx, payload, w)
+		default:
+			return e.exportProductsCSV(ctx, payload, w)
+		}
+	}
+
+	return resp, nil
+}
+
+func (e *ecommerceService) exportProductsCSV(ctx context.Context, payload request.FilterProduct, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	record := make([]string, len(productExportColumns))
+
+	write := func(values []interface{}) error {
+		for i, v := range values {
+			record[i] = formatProductExportValue(v)
+		}
+
+		return writer.Write(record)
+	}
+
+	err := write(productExportColumns)
+	if err != nil {
+		return err
+	}
+
+	err = e.ecommerceRepo.ExportProducts(ctx, payload, func(product entity.ProductExport) error {
+		return write(productExportRecord(product))
+	})
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func (e *ecommerceService) exportProductsNDJSON(ctx context.Context, payload request.FilterProduct, w io.Writer) error {
+	buffered := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buffered)
+
+	err := e.ecommerceRepo.ExportProducts(ctx, payload, func(product entity.ProductExport) error {
+		imageUrls := []string{}
+		if product.ImageUrls != "" {
+			imageUrls = strings.Split(product.ImageUrls, "|")
+		}
+
+		return encoder.Encode(productExportRow{
+			ID:              product.ID,
+			UserID:          product.UserID,
+			Sku:             product.Sku,
+			Title:           product.Title,
+			Description:     product.Description,
+			Category:        product.Category,
+			CategoryID:      product.CategoryID,
+			Etalase:         product.Etalase,
+			Weight:          product.Weight,
+			Length:          product.Length,
+			Width:           product.Width,
+			Height:          product.Height,
+			Price:           product.Price,
+			Stock:           product.Stock,
+			Rating:          product.Rating,
+			PrimaryImageUrl: product.PrimaryImageUrl,
+			ImageUrls:       imageUrls,
+			CreatedAt:       product.CreatedAt,
+			UpdatedAt:       product.UpdatedAt,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	return buffered.Flush()
+}
+
+func (e *ecommerceService) exportProductsXLSX(ctx context.Context, payload request.FilterProduct, w io.Writer) error {
+	writer, err := xlsx.NewWriter(w, "products")
+	if err != nil {
+		return err
+	}
+
+	err = writer.Write(productExportColumns)
+	if err != nil {
+		return err
+	}
+
+	err = e.ecommerceRepo.ExportProducts(ctx, payload, func(product entity.ProductExport) error {
+		return writer.Write(productExportRecord(product))
+	})
+	if err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
+
+// productExportRecord returns the values of the product in the order of productExportColumns.
+func productExportRecord(product entity.ProductExport) []interface{} {
+	return []interface{}{
+		product.ID, product.UserID, product.Sku, product.Title, product.Description, product.Category,
+		product.CategoryID, product.Etalase, float64(product.Weight), product.Length, product.Width, product.Height,
+		product.Price.Amount, product.Price.Currency, product.Stock,
+		product.Rating, product.PrimaryImageUrl, product.ImageUrls, product.CreatedAt, product.UpdatedAt,
+	}
+}
+
+func formatProductExportValue(v interface{}) string {
+	switch value := v.(type) {
+	case int64:
+		return strconv.FormatInt(value, 10)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case time.Time:
+		return value.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(value)
+	}
+}
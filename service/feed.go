@@ -0,0 +1,231 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"ecommerce/model/entity"
+	"ecommerce/model/request"
+	"ecommerce/repository"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+const (
+	FeedFormatXML = "xml"
+	FeedFormatTSV = "tsv"
+
+	// googleFeedKeyPrefix is the storage key of the google merchant feeds without their extension.
+	googleFeedKeyPrefix = "feeds/google."
+	// maxFeedAdditionalImages is the maximum number of additional images google merchant accepts per product.
+	maxFeedAdditionalImages = 10
+)
+
+// feedContentTypes are the content types of the feed formats.
+var feedContentTypes = map[string]string{
+	FeedFormatXML: "application/xml",
+	FeedFormatTSV: "text/tab-separated-values",
+}
+
+// googleFeedColumns are the header of the tsv feed, in the order of googleFeedItem.record.
+var googleFeedColumns = []string{"id", "title", "description", "link", "image_link", "availability", "price", "condition"}
+
+// googleFeedItem is a product in the google merchant center format.
+type googleFeedItem struct {
+	XMLName              xml.Name `xml:"item"`
+	ID                   string   `xml:"g:id"`
+	Title                string   `xml:"g:title"`
+	Description          string   `xml:"g:description"`
+	Link                 string   `xml:"g:link"`
+	ImageLink            string   `xml:"g:image_link"`
+	AdditionalImageLinks []string `xml:"g:additional_image_link"`
+	Availability         string   `xml:"g:availability"`
+	Price                string   `xml:"g:price"`
+	Condition            string   `xml:"g:condition"`
+}
+
+func (g googleFeedItem) record() []string {
+	return []string{g.ID, g.Title, g.Description, g.Link, g.ImageLink, g.Availability, g.Price, g.Condition}
+}
+
+type feedService struct {
+	ecommerceRepo repository.EcommerceProvider
+	storageRepo   repository.StorageProvider
+	title         string
+	linkBaseURL   string
+}
+
+type FeedConfig struct {
+	EcommerceRepo repository.EcommerceProvider
+	StorageRepo   repository.StorageProvider
+	// Title is the name of the store in the feeds
+	Title string
+	// LinkBaseURL is prepended to the product id to build the link of a product page
+	LinkBaseURL string
+}
+
+func NewFeedService(config FeedConfig) feedService {
+	feedProvider := feedService{
+		ecommerceRepo: config.EcommerceRepo,
+		storageRepo:   config.StorageRepo,
+		title:         config.Title,
+		linkBaseURL:   strings.TrimSuffix(config.LinkBaseURL, "/"),
+	}
+
+	return feedProvider
+}
+
+// GenerateProductFeeds writes the google merchant feeds of the whole catalog to the storage, replacing the previous
+// feeds once they are complete.
+func (f *feedService) GenerateProductFeeds(ctx context.Context) (err error) {
+	for _, format := range []string{FeedFormatXML, FeedFormatTSV} {
+		reader, writer := io.Pipe()
+		go func(format string) {
+			writer.CloseWithError(f.writeGoogleFeed(ctx, format, writer))
+		}(format)
+
+		err = f.storageRepo.Put(ctx, googleFeedKeyPrefix+format, feedContentTypes[format], reader)
+		// the writer stops as soon as the reader is closed when the feed couldn't be stored
+		reader.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetProductFeed opens the last generated google merchant feed in format, the caller has to close it.
+func (f *feedService) GetProductFeed(ctx context.Context, format string) (feed io.ReadCloser, contentType string, err error) {
+	contentType, ok := feedContentTypes[format]
+	if !ok {
+		return nil, "", ErrFeedNotFound
+	}
+
+	feed, err = f.storageRepo.Get(ctx, googleFeedKeyPrefix+format)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, "", ErrFeedNotFound
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	return feed, contentType, nil
+}
+
+func (f *feedService) writeGoogleFeed(ctx context.Context, format string, w io.Writer) error {
+	buffered := bufio.NewWriter(w)
+
+	var write func(item googleFeedItem) error
+	switch format {
+	case FeedFormatXML:
+		_, err := fmt.Fprintf(buffered, `%s<rss xmlns:g="http://base.google.com/ns/1.0" version="2.0"><channel>`, xml.Header)
+		if err != nil {
+			return err
+		}
+
+		encoder := xml.NewEncoder(buffered)
+		for _, v := range [][2]string{{"title", f.title}, {"link", f.linkBaseURL}, {"description", f.title + " products"}} {
+			err = encoder.EncodeElement(v[1], xml.StartElement{Name: xml.Name{Local: v[0]}})
+			if err != nil {
+				return err
+			}
+		}
+
+		write = func(item googleFeedItem) error {
+			return encoder.Encode(item)
+		}
+	case FeedFormatTSV:
+		_, err := buffered.WriteString(strings.Join(googleFeedColumns, "\t") + "\n")
+		if err != nil {
+			return err
+		}
+
+		write = func(item googleFeedItem) error {
+			record := item.record()
+			for i, v := range record {
+				record[i] = tsvField(v)
+			}
+
+			_, err := buffered.WriteString(strings.Join(record, "\t") + "\n")
+			return err
+		}
+	default:
+		return ErrFeedNotFound
+	}
+
+	payload := normalizeProductFilter(request.FilterProduct{IsAsc: true}, "")
+	err := f.ecommerceRepo.ExportProducts(ctx, payload, func(product entity.ProductExport) error {
+		item, ok := f.toGoogleFeedItem(product)
+		if !ok {
+			return nil
+		}
+
+		return write(item)
+	})
+	if err != nil {
+		return err
+	}
+
+	if format == FeedFormatXML {
+		_, err = buffered.WriteString("</channel></rss>\n")
+		if err != nil {
+			return err
+		}
+	}
+
+	return buffered.Flush()
+}
+
+// toGoogleFeedItem converts the product to a feed item, products without an image or a price are left out since
+// google merchant rejects them.
+func (f *feedService) toGoogleFeedItem(product entity.ProductExport) (googleFeedItem, bool) {
+	if product.ImageUrls == "" || product.EffectivePrice.Amount <= 0 {
+		return googleFeedItem{}, false
+	}
+
+	imageUrls := strings.Split(product.ImageUrls, "|")
+	imageLink := product.PrimaryImageUrl
+	if imageLink == "" {
+		imageLink = imageUrls[0]
+	}
+
+	additionalImageLinks := make([]string, 0, len(imageUrls))
+	for _, v := range imageUrls {
+		if v != imageLink && len(additionalImageLinks) < maxFeedAdditionalImages {
+			additionalImageLinks = append(additionalImageLinks, v)
+		}
+	}
+
+	description := product.Description
+	if description == "" {
+		description = product.Title
+	}
+
+	availability := "in_stock"
+	if product.Stock <= 0 {
+		availability = "out_of_stock"
+	}
+
+	id := strconv.FormatInt(product.ID, 10)
+	return googleFeedItem{
+		ID:                   id,
+		Title:                product.Title,
+		Description:          description,
+		Link:                 f.linkBaseURL + "/" + id,
+		ImageLink:            imageLink,
+		AdditionalImageLinks: additionalImageLinks,
+		Availability:         availability,
+		Price:                product.EffectivePrice.String(),
+		Condition:            "new",
+	}, true
+}
+
+// tsvField replaces the tabs and line breaks of s which would break the tsv layout.
+func tsvField(s string) string {
+	return strings.NewReplacer("\t", " ", "\r", " ", "\n", " ").Replace(s)
+}
@@ -0,0 +1,328 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"ecommerce/model/entity"
+	"ecommerce/model/response"
+	"ecommerce/repository"
+	"encoding/hex"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// defaultMaxImageSize is used when no maximum image size is configured.
+	defaultMaxImageSize = 2 << 20
+	// maxImagePixels keeps images that would take too much memory to decode from being uploaded.
+	maxImagePixels = 40_000_000
+	// productImageKeyPrefix is the storage folder of the product images.
+	productImageKeyPrefix = "products/"
+	// imageProcessingBatchSize is the number of images processed on each ProcessPendingImages run.
+	imageProcessingBatchSize = 10
+	// maxImageProcessingAttempts is the number of times an image is processed before it is marked as failed.
+	maxImageProcessingAttempts = 3
+	// staleImageProcessingAfter is how long an image may stay processing before it is claimed again.
+	staleImageProcessingAfter = 10 * time.Minute
+	// imageVariantJPEGQuality is the quality of the jpeg variants.
+	imageVariantJPEGQuality = 85
+)
+
+// imageExtensions are the accepted image content types with the extension their file is stored with.
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// imageVariantSizes are the variants generated for every uploaded image with the maximum length of their longest side.
+var imageVariantSizes = []struct {
+	Name string
+	Size int
+}{
+	{Name: entity.ImageVariantThumb, Size: 150},
+	{Name: entity.ImageVariantMedium, Size: 600},
+	{Name: entity.ImageVariantLarge, Size: 1200},
+}
+
+type imageService struct {
+	storageRepo repository.StorageProvider
+	imageRepo   repository.ImageProvider
+	maxSize     int64
+}
+
+type ImageConfig struct {
+	StorageRepo repository.StorageProvider
+	ImageRepo   repository.ImageProvider
+	// MaxSize is the maximum size in bytes of an uploaded image
+	MaxSize int64
+}
+
+func NewImageService(config ImageConfig) imageService {
+	imageProvider := imageService{
+		storageRepo: config.StorageRepo,
+		imageRepo:   config.ImageRepo,
+		maxSize:     config.MaxSize,
+	}
+
+	if imageProvider.maxSize <= 0 {
+		imageProvider.maxSize = defaultMaxImageSize
+	}
+
+	return imageProvider
+}
+
+// UploadImage stores the image under the hash of its content, so uploading the same image twice returns the same url.
+// Its variants are generated later by ProcessPendingImages.
+func (i *imageService) UploadImage(ctx context.Context, file io.Reader) (response.UploadImageResponse, error) {
+	var resp response.UploadImageResponse
+
+	// one byte more than the maximum is read to tell a file of exactly the maximum size from a bigger one
+	content, err := io.ReadAll(io.LimitReader(file, i.maxSize+1))
+	if err != nil {
+		return resp, err
+	}
+
+	if int64(len(content)) > i.maxSize {
+		return resp, fmt.Errorf("%w: maximum size is %d bytes", ErrImageTooLarge, i.maxSize)
+	}
+
+	// the content type sent by the client is ignored, it is sniffed from the content instead
+	contentType := http.DetectContentType(content)
+	extension, ok := imageExtensions[contentType]
+	if !ok {
+		return resp, fmt.Errorf("%w: %s is not supported", ErrInvalidImage, contentType)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return resp, fmt.Errorf("%w: %s", ErrInvalidImage, err.Error())
+	}
+
+	if config.Width*config.Height > maxImagePixels {
+		return resp, fmt.Errorf("%w: %dx%d is too many pixels", ErrInvalidImage, config.Width, config.Height)
+	}
+
+	hash := sha256.Sum256(content)
+	key := productImageKeyPrefix + hex.EncodeToString(hash[:]) + extension
+
+	err = i.storageRepo.Put(ctx, key, contentType, bytes.NewReader(content))
+	if err != nil {
+		return resp, err
+	}
+
+	err = i.imageRepo.UpsertImage(ctx, entity.Image{
+		StorageKey:  key,
+		ImageUrl:    i.storageRepo.URL(key),
+		ContentType: contentType,
+		Size:        int64(len(content)),
+		Status:      entity.ImageStatusPending,
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Data = response.UploadedImage{
+		ImageUrl:    i.storageRepo.URL(key),
+		ContentType: contentType,
+		Size:        int64(len(content)),
+	}
+
+	return resp, nil
+}
+
+// ProcessPendingImages generates the variants of the uploaded images. An image failing to process is retried on the
+// next runs until maxImageProcessingAttempts is reached.
+func (i *imageService) ProcessPendingImages(ctx context.Context) (err error) {
+	images, err := i.imageRepo.ClaimPendingImages(ctx, time.Now().Add(-staleImageProcessingAfter), imageProcessingBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range images {
+		v.Attempts++
+		v.Status = entity.ImageStatusProcessed
+		v.LastError = ""
+
+		processErr := i.generateImageVariants(ctx, v)
+		if processErr != nil {
+			v.Status = entity.ImageStatusPending
+			v.LastError = processErr.Error()
+			if v.Attempts >= maxImageProcessingAttempts {
+				v.Status = entity.ImageStatusFailed
+			}
+		}
+
+		err = i.imageRepo.UpdateImageStatus(ctx, v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// generateImageVariants stores every size of the image in its own format and in webp.
+func (i *imageService) generateImageVariants(ctx context.Context, original entity.Image) error {
+	body, err := i.storageRepo.Get(ctx, original.StorageKey)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	src, _, err := image.Decode(body)
+	if err != nil {
+		return err
+	}
+
+	// only photos are worth the loss of jpeg, other images may have transparency
+	format := entity.ImageFormatPNG
+	if original.ContentType == "image/jpeg" {
+		format = entity.ImageFormatJPEG
+	}
+
+	for _, size := range imageVariantSizes {
+		resized := resizeImage(src, size.Size)
+
+		for _, f := range []string{format, entity.ImageFormatWebP} {
+			var buf bytes.Buffer
+			contentType, err := encodeImage(&buf, resized, f)
+			if err != nil {
+				return err
+			}
+
+			key := imageVariantKey(original.StorageKey, size.Name, f)
+			err = i.storageRepo.Put(ctx, key, contentType, &buf)
+			if err != nil {
+				return err
+			}
+
+			err = i.imageRepo.UpsertImageVariant(ctx, entity.ImageVariant{
+				ImageID: original.ID,
+				Name:    size.Name,
+				Format:  f,
+				Url:     i.storageRepo.URL(key),
+				Width:   resized.Bounds().Dx(),
+				Height:  resized.Bounds().Dy(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// getProductImages returns the images of the product with the variants generated for them.
+func (e *ecommerceService) getProductImages(ctx context.Context, productID int64) ([]response.ProductImage, error) {
+	images, err := e.ecommerceRepo.GetProductImagesByProductID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	urls := make([]string, 0, len(images))
+	for _, v := range images {
+		urls = append(urls, v.ImageUrl)
+	}
+
+	variants, err := e.imageRepo.GetImageVariantsByImageURLs(ctx, urls)
+	if err != nil {
+		return nil, err
+	}
+
+	variantsByURL := make(map[string][]response.ImageVariant, len(images))
+	for _, v := range variants {
+		variantsByURL[v.OriginalUrl] = append(variantsByURL[v.OriginalUrl], response.ImageVariant{
+			Name:   v.Name,
+			Format: v.Format,
+			Url:    v.Url,
+			Width:  v.Width,
+			Height: v.Height,
+		})
+	}
+
+	productImages := make([]response.ProductImage, 0, len(images))
+	for _, v := range images {
+		productImage := response.ProductImage{
+			ProductImage: v,
+			Variants:     variantsByURL[v.ImageUrl],
+		}
+
+		if productImage.Variants == nil {
+			productImage.Variants = []response.ImageVariant{}
+		}
+
+		productImages = append(productImages, productImage)
+	}
+
+	return productImages, nil
+}
+
+// resizeImage scales src down so its longest side is at most size, smaller images are kept as they are.
+func resizeImage(src image.Image, size int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= size && height <= size {
+		return src
+	}
+
+	if width >= height {
+		height = height * size / width
+		width = size
+	} else {
+		width = width * size / height
+		height = size
+	}
+
+	// a very thin image keeps a side of at least one pixel
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+
+	return dst
+}
+
+// encodeImage writes img to w in format and returns its content type.
+func encodeImage(w io.Writer, img image.Image, format string) (string, error) {
+	switch format {
+	case entity.ImageFormatJPEG:
+		return "image/jpeg", jpeg.Encode(w, img, &jpeg.Options{Quality: imageVariantJPEGQuality})
+	case entity.ImageFormatPNG:
+		return "image/png", png.Encode(w, img)
+	case entity.ImageFormatWebP:
+		return "image/webp", nativewebp.Encode(w, img, nil)
+	default:
+		return "", fmt.Errorf("unknown image format %q", format)
+	}
+}
+
+// imageVariantKey returns the storage key of a variant next to its original, e.g. products/<hash>_thumb.webp.
+func imageVariantKey(key string, name string, format string) string {
+	extension := "." + format
+	if format == entity.ImageFormatJPEG {
+		extension = ".jpg"
+	}
+
+	return strings.TrimSuffix(key, path.Ext(key)) + "_" + name + extension
+}
@@ -0,0 +1,697 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"ecommerce/model/entity"
+	"ecommerce/model/money"
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"ecommerce/model/weight"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const (
+	// productImportBatchSize is the number of rows upserted in one transaction.
+	productImportBatchSize = 100
+	// maxProductImportLineSize is the longest line accepted in a ndjson import.
+	maxProductImportLineSize = 1 << 20
+	// productImportKeyPrefix is the storage folder of the import reports.
+	productImportKeyPrefix = "imports/"
+)
+
+// productImportColumns are the columns a csv import may have, sku and title are required.
+var productImportColumns = map[string]bool{
+	"sku":         true,
+	"title":       true,
+	"description": true,
+	"category":    true,
+	"category_id": true,
+	"etalase":     true,
+	"weight":      true,
+	"length":      true,
+	"width":       true,
+	"height":      true,
+	"price":       true,
+	"currency":    true,
+	"stock":       true,
+	"image_urls":  true,
+}
+
+// productImportReportHeader is the header of the per row report of an import.
+var productImportReportHeader = []string{"row", "sku", "status", "product_id", "error"}
+
+// productImportRow is a row read from an import file, err is set when the row couldn't be parsed.
+type productImportRow struct {
+	line int
+	row  request.ImportProductRow
+	err  error
+}
+
+// productImportResult is the outcome of importing a row.
+type productImportResult struct {
+	status    string
+	productID int64
+	err       error
+}
+
+// ImportProducts upserts the products of the file by sku for the seller. Rows are upserted in batches of
+// productImportBatchSize in one transaction, a batch failing is retried row by row so only the failing rows are
+// rejected. Only the fields a row has are changed on an existing product: columns missing from the file, empty cells
+// and keys missing from a json line leave the field as it is, and so are options, variants and attributes.
+func (e *ecommerceService) ImportProducts(ctx context.Context, request request.ImportProducts, file io.Reader) (response.GetProductImportResponse, error) {
+	var resp response.GetProductImportResponse
+
+	if request.UserID <= 0 {
+		return resp, fmt.Errorf("%w: user_id is required", ErrInvalidImport)
+	}
+
+	format := request.Format
+	if format == "" {
+		format = productImportFormat(request.FileName)
+	}
+
+	next, err := newProductImportReader(format, file)
+	if err != nil {
+		return resp, err
+	}
+
+	productImport := entity.ProductImport{
+		UserID: request.UserID,
+		Format: format,
+		Status: entity.ProductImportStatusProcessing,
+	}
+
+	productImport.ID, err = e.productImportRepo.CreateProductImport(ctx, productImport)
+	if err != nil {
+		return resp, err
+	}
+
+	var report bytes.Buffer
+	reportWriter := csv.NewWriter(&report)
+	err = reportWriter.Write(productImportReportHeader)
+	if err != nil {
+		return resp, err
+	}
+
+	batch := make([]productImportRow, 0, productImportBatchSize)
+	flush := func() error {
+		results := e.importProductBatch(ctx, productImport, batch)
+		for i, v := range results {
+			productImport.TotalRows++
+
+			record := []string{strconv.Itoa(batch[i].line), batch[i].row.Sku, v.status, "", ""}
+			switch v.status {
+			case entity.ProductImportRowCreated:
+				productImport.CreatedRows++
+			case entity.ProductImportRowUpdated:
+				productImport.UpdatedRows++
+			default:
+				productImport.FailedRows++
+			}
+
+			if v.productID != 0 {
+				record[3] = strconv.FormatInt(v.productID, 10)
+			}
+
+			if v.err != nil {
+				record[4] = v.err.Error()
+			}
+
+			err := reportWriter.Write(record)
+			if err != nil {
+				return err
+			}
+		}
+
+		batch = batch[:0]
+		return nil
+	}
+
+	for {
+		row, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return resp, e.failProductImport(ctx, productImport, err)
+		}
+
+		batch = append(batch, row)
+		if len(batch) == productImportBatchSize {
+			err = flush()
+			if err != nil {
+				return resp, e.failProductImport(ctx, productImport, err)
+			}
+		}
+	}
+
+	err = flush()
+	if err != nil {
+		return resp, e.failProductImport(ctx, productImport, err)
+	}
+
+	reportWriter.Flush()
+	err = reportWriter.Error()
+	if err != nil {
+		return resp, e.failProductImport(ctx, productImport, err)
+	}
+
+	// the report holds the seller's catalog, its key can't be guessed from the import id
+	token := make([]byte, 16)
+	_, err = rand.Read(token)
+	if err != nil {
+		return resp, e.failProductImport(ctx, productImport, err)
+	}
+
+	productImport.ReportKey = fmt.Sprintf("%s%d-%s.csv", productImportKeyPrefix, productImport.ID, hex.EncodeToString(token))
+	err = e.storageRepo.Put(ctx, productImport.ReportKey, "text/csv", &report)
+	if err != nil {
+		return resp, e.failProductImport(ctx, productImport, err)
+	}
+
+	productImport.Status = entity.ProductImportStatusCompleted
+	err = e.productImportRepo.UpdateProductImport(ctx, productImport)
+	if err != nil {
+		return resp, err
+	}
+
+	return e.GetProductImport(ctx, productImport.ID)
+}
+
+func (e *ecommerceService) GetProductImport(ctx context.Context, id int64) (response.GetProductImportResponse, error) {
+	var resp response.GetProductImportResponse
+
+	productImport, err := e.productImportRepo.GetProductImportByID(ctx, id)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Data = productImport
+	return resp, nil
+}
+
+// GetProductImportReport opens the per row report of the import as csv, the caller has to close it.
+func (e *ecommerceService) GetProductImportReport(ctx context.Context, id int64) (io.ReadCloser, error) {
+	productImport, err := e.productImportRepo.GetProductImportByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if productImport.ReportKey == "" {
+		return nil, ErrImportReportNotFound
+	}
+
+	return e.storageRepo.Get(ctx, productImport.ReportKey)
+}
+
+// failProductImport marks the import as failed and returns cause.
+func (e *ecommerceService) failProductImport(ctx context.Context, productImport entity.ProductImport, cause error) error {
+	productImport.Status = entity.ProductImportStatusFailed
+	err := e.productImportRepo.UpdateProductImport(ctx, productImport)
+	if err != nil {
+		return err
+	}
+
+	return cause
+}
+
+// importProductBatch upserts the valid rows of the batch and returns the result of every row.
+func (e *ecommerceService) importProductBatch(ctx context.Context, productImport entity.ProductImport, batch []productImportRow) []productImportResult {
+	results := make([]productImportResult, len(batch))
+	valid := make([]int, 0, len(batch))
+	for i, v := range batch {
+		err := v.err
+		if err == nil {
+			err = validateImportProductRow(v.row)
+		}
+
+		if err != nil {
+			results[i] = productImportResult{status: entity.ProductImportRowFailed, err: err}
+			continue
+		}
+
+		valid = append(valid, i)
+	}
+
+	batchResults := make([]productImportResult, len(valid))
+	err := e.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		for j, i := range valid {
+			upserted, err := e.importProduct(ctx, productImport, batch[i].row)
+			if err != nil {
+				return fmt.Errorf("row %d: %w", batch[i].line, err)
+			}
+
+			batchResults[j] = importedProductResult(upserted)
+		}
+
+		return nil
+	})
+
+	if err == nil {
+		for j, i := range valid {
+			results[i] = batchResults[j]
+		}
+
+		return results
+	}
+
+	// the whole batch was rolled back, every row is upserted on its own to find the failing ones
+	for _, i := range valid {
+		var upserted entity.UpsertedProduct
+		err := e.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			upserted, err = e.importProduct(ctx, productImport, batch[i].row)
+			return err
+		})
+		if err != nil {
+			results[i] = productImportResult{status: entity.ProductImportRowFailed, err: err}
+			continue
+		}
+
+		results[i] = importedProductResult(upserted)
+	}
+
+	return results
+}
+
+// importProduct upserts a row, the stock change of an existing product is recorded in the inventory ledger.
+func (e *ecommerceService) importProduct(ctx context.Context, productImport entity.ProductImport, row request.ImportProductRow) (entity.UpsertedProduct, error) {
+	oldProduct, err := e.ecommerceRepo.GetProductBySku(ctx, productImport.UserID, row.Sku)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return entity.UpsertedProduct{}, err
+	}
+
+	if oldProduct.ID != 0 {
+		err = e.ecommerceRepo.LockProduct(ctx, oldProduct.ID)
+		if err != nil {
+			return entity.UpsertedProduct{}, err
+		}
+
+		// read again once locked, the product may have changed while the lock was awaited
+		oldProduct, err = e.ecommerceRepo.GetProductByID(ctx, oldProduct.ID)
+		if err != nil {
+			return entity.UpsertedProduct{}, err
+		}
+	}
+
+	product, err := e.importedProduct(ctx, productImport.UserID, oldProduct, row)
+	if err != nil {
+		return entity.UpsertedProduct{}, err
+	}
+
+	upserted, err := e.ecommerceRepo.UpsertProduct(ctx, product)
+	if err != nil {
+		return entity.UpsertedProduct{}, err
+	}
+
+	product.ID = upserted.ID
+	if upserted.Created {
+		err = e.recordAudit(ctx, entity.AuditEntityProduct, upserted.ID, upserted.ID, nil, product)
+	} else {
+		err = e.recordAudit(ctx, entity.AuditEntityProduct, upserted.ID, upserted.ID, oldProduct, product)
+	}
+	if err != nil {
+		return entity.UpsertedProduct{}, err
+	}
+
+	eventType := entity.EventProductUpdated
+	if upserted.Created {
+		eventType = entity.EventProductCreated
+	}
+
+	err = e.recordProductEvent(ctx, upserted.ID, eventType, toProductEvent(product))
+	if err != nil {
+		return entity.UpsertedProduct{}, err
+	}
+
+	if upserted.Created || row.Price != nil && product.Price.Amount != oldProduct.Price.Amount {
+		err = e.scheduleRegularPrice(ctx, upserted.ID, product.Price.Amount, priceNow())
+		if err != nil {
+			return entity.UpsertedProduct{}, err
+		}
+	}
+
+	reference := importReference(productImport.ID)
+	if upserted.Created && product.Stock > 0 {
+		err = e.inventoryRepo.CreateInventoryLedger(ctx, entity.InventoryLedger{
+			ProductID:      upserted.ID,
+			QuantityChange: product.Stock,
+			StockAfter:     product.Stock,
+			Reason:         entity.InventoryReasonInitialStock,
+			Reference:      reference,
+		})
+		if err != nil {
+			return entity.UpsertedProduct{}, err
+		}
+	}
+
+	if !upserted.Created && row.Stock != nil {
+		stock, err := e.inventoryRepo.GetProductStockForUpdate(ctx, upserted.ID)
+		if err != nil {
+			return entity.UpsertedProduct{}, err
+		}
+
+		if stock != product.Stock {
+			err = e.inventoryRepo.UpdateProductStock(ctx, upserted.ID, product.Stock)
+			if err != nil {
+				return entity.UpsertedProduct{}, err
+			}
+
+			err = e.inventoryRepo.CreateInventoryLedger(ctx, entity.InventoryLedger{
+				ProductID:      upserted.ID,
+				QuantityChange: product.Stock - stock,
+				StockAfter:     product.Stock,
+				Reason:         entity.InventoryReasonImport,
+				Reference:      reference,
+			})
+			if err != nil {
+				return entity.UpsertedProduct{}, err
+			}
+		}
+	}
+
+	if len(row.ImageUrls) > 0 {
+		images := request.UpsertProduct{}
+		for _, v := range row.ImageUrls {
+			images.ProductImages = append(images.ProductImages, request.UpsertProductImage{ImageUrl: v})
+		}
+
+		err = e.saveProductImages(ctx, upserted.ID, images)
+		if err != nil {
+			return entity.UpsertedProduct{}, err
+		}
+	}
+
+	return upserted, nil
+}
+
+// importedProduct returns the product a row upserts: a new product when oldProduct has no id, else oldProduct with the
+// fields the row has.
+func (e *ecommerceService) importedProduct(ctx context.Context, userID int64, oldProduct entity.Product, row request.ImportProductRow) (entity.Product, error) {
+	product := oldProduct
+	if oldProduct.ID == 0 {
+		if row.Price == nil {
+			return entity.Product{}, fmt.Errorf("%w: price is required to create a product", ErrInvalidImport)
+		}
+
+		currency, err := resolveCurrency(row.Price.Currency, e.currency)
+		if err != nil {
+			return entity.Product{}, err
+		}
+
+		product = entity.Product{UserID: userID, Sku: row.Sku, Price: money.New(0, currency)}
+	} else if row.Price != nil && row.Price.Currency != "" && row.Price.Currency != oldProduct.Price.Currency {
+		return entity.Product{}, fmt.Errorf("%w: the currency of product %d can't be changed from %s", ErrInvalidCurrency, oldProduct.ID, oldProduct.Price.Currency)
+	}
+
+	product.Title = row.Title
+	if oldProduct.ID == 0 || row.CategoryID != nil || row.Category != nil {
+		var categoryID int64
+		var category string
+		if row.CategoryID != nil {
+			categoryID = *row.CategoryID
+		}
+		if row.Category != nil {
+			category = *row.Category
+		}
+
+		var err error
+		product.CategoryID, product.Category, err = e.resolveProductCategory(ctx, categoryID, category)
+		if err != nil {
+			return entity.Product{}, err
+		}
+	}
+
+	for _, v := range []struct {
+		from *string
+		to   *string
+	}{
+		{from: row.Description, to: &product.Description},
+		{from: row.Etalase, to: &product.Etalase},
+	} {
+		if v.from != nil {
+			*v.to = *v.from
+		}
+	}
+
+	if row.Weight != nil {
+		product.Weight = *row.Weight
+	}
+
+	for _, v := range []struct {
+		from *float64
+		to   *float64
+	}{
+		{from: row.Length, to: &product.Length},
+		{from: row.Width, to: &product.Width},
+		{from: row.Height, to: &product.Height},
+	} {
+		if v.from != nil {
+			*v.to = *v.from
+		}
+	}
+
+	if row.Price != nil {
+		product.Price.Amount = row.Price.Amount
+	}
+	if row.Stock != nil {
+		product.Stock = *row.Stock
+	}
+
+	return product, nil
+}
+
+func validateImportProductRow(row request.ImportProductRow) error {
+	switch {
+	case strings.TrimSpace(row.Sku) == "":
+		return fmt.Errorf("%w: sku is required", ErrInvalidImport)
+	case strings.TrimSpace(row.Title) == "":
+		return fmt.Errorf("%w: title is required", ErrInvalidImport)
+	case row.Price != nil && row.Price.Amount <= 0:
+		return fmt.Errorf("%w: price should be positive", ErrInvalidImport)
+	case row.Weight != nil && *row.Weight < 0:
+		return fmt.Errorf("%w: weight should not be negative", ErrInvalidImport)
+	case isNegative(row.Length), isNegative(row.Width), isNegative(row.Height):
+		return fmt.Errorf("%w: length, width and height should not be negative", ErrInvalidImport)
+	case row.Stock != nil && *row.Stock < 0:
+		return ErrInvalidStock
+	}
+
+	return nil
+}
+
+func isNegative(v *float64) bool {
+	return v != nil && *v < 0
+}
+
+func importedProductResult(upserted entity.UpsertedProduct) productImportResult {
+	status := entity.ProductImportRowUpdated
+	if upserted.Created {
+		status = entity.ProductImportRowCreated
+	}
+
+	return productImportResult{status: status, productID: upserted.ID}
+}
+
+// importReference is the ledger reference of stock movements caused by an import.
+func importReference(importID int64) string {
+	return fmt.Sprintf("import:%d", importID)
+}
+
+// productImportFormat guesses the format of an import file from its name.
+func productImportFormat(fileName string) string {
+	switch strings.ToLower(path.Ext(fileName)) {
+	case ".ndjson", ".jsonl":
+		return entity.ProductImportFormatNDJSON
+	default:
+		return entity.ProductImportFormatCSV
+	}
+}
+
+// newProductImportReader returns a function reading the rows of file one by one until io.EOF. Rows that can't be
+// parsed are returned with their error so they end up in the report, other errors stop the import.
+func newProductImportReader(format string, file io.Reader) (func() (productImportRow, error), error) {
+	switch format {
+	case entity.ProductImportFormatCSV:
+		return newCSVProductImportReader(file)
+	case entity.ProductImportFormatNDJSON:
+		return newNDJSONProductImportReader(file), nil
+	default:
+		return nil, fmt.Errorf("%w: format %q is not supported", ErrInvalidImport, format)
+	}
+}
+
+func newCSVProductImportReader(file io.Reader) (func() (productImportRow, error), error) {
+	reader := csv.NewReader(file)
+	reader.ReuseRecord = true
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: file is empty", ErrInvalidImport)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidImport, err.Error())
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, v := range header {
+		name := strings.ToLower(strings.TrimSpace(v))
+		if !productImportColumns[name] {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalidImport, v)
+		}
+		columns[name] = i
+	}
+
+	for _, v := range []string{"sku", "title"} {
+		if _, ok := columns[v]; !ok {
+			return nil, fmt.Errorf("%w: column %s is required", ErrInvalidImport, v)
+		}
+	}
+
+	return func() (productImportRow, error) {
+		record, err := reader.Read()
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return productImportRow{line: parseErr.StartLine, err: fmt.Errorf("%w: %s", ErrInvalidImport, parseErr.Err.Error())}, nil
+		}
+		if err != nil {
+			return productImportRow{}, err
+		}
+
+		value := func(name string) string {
+			i, ok := columns[name]
+			if !ok {
+				return ""
+			}
+
+			return strings.TrimSpace(record[i])
+		}
+
+		line, _ := reader.FieldPos(0)
+		result := productImportRow{
+			line: line,
+			row: request.ImportProductRow{
+				Sku:   value("sku"),
+				Title: value("title"),
+			},
+		}
+
+		// an empty cell leaves the field as it is, like a column missing from the file
+		for _, v := range []struct {
+			column string
+			value  **string
+		}{
+			{column: "description", value: &result.row.Description},
+			{column: "category", value: &result.row.Category},
+			{column: "etalase", value: &result.row.Etalase},
+		} {
+			if s := value(v.column); s != "" {
+				*v.value = &s
+			}
+		}
+
+		for _, v := range []struct {
+			column string
+			value  **int64
+		}{
+			{column: "category_id", value: &result.row.CategoryID},
+			{column: "stock", value: &result.row.Stock},
+		} {
+			if s := value(v.column); s != "" {
+				n, err := strconv.ParseInt(s, 10, 64)
+				if err != nil {
+					result.err = fmt.Errorf("%w: %s should be an integer", ErrInvalidImport, v.column)
+				}
+				*v.value = &n
+			}
+		}
+
+		// the currency column is the currency of the price column
+		if s := value("price"); s != "" {
+			n, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				result.err = fmt.Errorf("%w: price should be an integer", ErrInvalidImport)
+			}
+			result.row.Price = &money.Money{Amount: n, Currency: value("currency")}
+		}
+
+		if s := value("weight"); s != "" {
+			n, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				result.err = fmt.Errorf("%w: weight should be a number", ErrInvalidImport)
+			}
+			grams := weight.Grams(n)
+			result.row.Weight = &grams
+		}
+
+		for _, v := range []struct {
+			column string
+			value  **float64
+		}{
+			{column: "length", value: &result.row.Length},
+			{column: "width", value: &result.row.Width},
+			{column: "height", value: &result.row.Height},
+		} {
+			if s := value(v.column); s != "" {
+				n, err := strconv.ParseFloat(s, 64)
+				if err != nil {
+					result.err = fmt.Errorf("%w: %s should be a number", ErrInvalidImport, v.column)
+				}
+				*v.value = &n
+			}
+		}
+
+		// image urls are separated with a pipe since they may contain commas
+		for _, v := range strings.Split(value("image_urls"), "|") {
+			if v = strings.TrimSpace(v); v != "" {
+				result.row.ImageUrls = append(result.row.ImageUrls, v)
+			}
+		}
+
+		return result, nil
+	}, nil
+}
+
+func newNDJSONProductImportReader(file io.Reader) func() (productImportRow, error) {
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxProductImportLineSize)
+
+	line := 0
+	return func() (productImportRow, error) {
+		for scanner.Scan() {
+			line++
+			if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+				continue
+			}
+
+			result := productImportRow{line: line}
+			err := json.Unmarshal(scanner.Bytes(), &result.row)
+			if err != nil {
+				result.err = fmt.Errorf("%w: %s", ErrInvalidImport, err.Error())
+			}
+
+			return result, nil
+		}
+
+		err := scanner.Err()
+		if err != nil {
+			return productImportRow{}, err
+		}
+
+		return productImportRow{}, io.EOF
+	}
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ecommerce/model/entity"
+	"ecommerce/model/money"
+	"ecommerce/repository"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type importCategoryRepo struct {
+	repository.CategoryProvider
+}
+
+func (importCategoryRepo) GetCategoryBySlug(ctx context.Context, slug string) (entity.Category, error) {
+	return entity.Category{}, sql.ErrNoRows
+}
+
+func readCSVImportRows(t *testing.T, file string) []productImportRow {
+	next, err := newCSVProductImportReader(strings.NewReader(file))
+	require.NoError(t, err)
+
+	var rows []productImportRow
+	for {
+		row, err := next()
+		if err != nil {
+			return rows
+		}
+		require.NoError(t, row.err)
+		rows = append(rows, row)
+	}
+}
+
+func TestImportedProductKeepsFieldsLeftOut(t *testing.T) {
+	existing := entity.Product{
+		ID:          7,
+		UserID:      3,
+		Sku:         "SKU-1",
+		Title:       "Shirt",
+		Description: "Cotton shirt",
+		Category:    "Shirts",
+		CategoryID:  4,
+		Price:       money.New(150000, "IDR"),
+		Weight:      200,
+		Length:      30,
+		Stock:       5,
+		Rating:      4.5,
+	}
+
+	service := NewEcommerceService(EcommerceConfig{CategoryRepo: importCategoryRepo{}})
+
+	tests := []struct {
+		name string
+		file string
+		want func(p entity.Product) entity.Product
+	}{
+		{
+			name: "stock only",
+			file: "sku,title,stock\nSKU-1,Shirt,9\n",
+			want: func(p entity.Product) entity.Product {
+				p.Stock = 9
+				return p
+			},
+		},
+		{
+			name: "empty cells",
+			file: "sku,title,description,price,weight\nSKU-1,Shirt v2,,,\n",
+			want: func(p entity.Product) entity.Product {
+				p.Title = "Shirt v2"
+				return p
+			},
+		},
+		{
+			name: "price and weight",
+			file: "sku,title,price,weight\nSKU-1,Shirt,175000,250\n",
+			want: func(p entity.Product) entity.Product {
+				p.Price = money.New(175000, "IDR")
+				p.Weight = 250
+				return p
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := readCSVImportRows(t, tt.file)
+			require.Len(t, rows, 1)
+			require.NoError(t, validateImportProductRow(rows[0].row))
+
+			product, err := service.importedProduct(context.Background(), existing.UserID, existing, rows[0].row)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want(existing), product)
+		})
+	}
+}
+
+func TestImportedProductRequiresPriceToCreate(t *testing.T) {
+	service := NewEcommerceService(EcommerceConfig{CategoryRepo: importCategoryRepo{}})
+
+	rows := readCSVImportRows(t, "sku,title,stock\nSKU-2,Hat,3\n")
+	_, err := service.importedProduct(context.Background(), 3, entity.Product{}, rows[0].row)
+	assert.ErrorIs(t, err, ErrInvalidImport)
+
+	rows = readCSVImportRows(t, "sku,title,price\nSKU-2,Hat,0\n")
+	assert.ErrorIs(t, validateImportProductRow(rows[0].row), ErrInvalidImport)
+
+	rows = readCSVImportRows(t, "sku,title,price\nSKU-2,Hat,50000\n")
+	product, err := service.importedProduct(context.Background(), 3, entity.Product{}, rows[0].row)
+	require.NoError(t, err)
+	assert.Equal(t, entity.Product{UserID: 3, Sku: "SKU-2", Title: "Hat", Price: money.New(50000, defaultCurrency)}, product)
+}
+
+func TestNDJSONImportKeysLeftOut(t *testing.T) {
+	next := newNDJSONProductImportReader(strings.NewReader(`{"sku":"SKU-1","title":"Shirt","description":""}` + "\n"))
+	row, err := next()
+	require.NoError(t, err)
+	require.NoError(t, row.err)
+
+	require.NotNil(t, row.row.Description)
+	assert.Equal(t, "", *row.row.Description)
+	assert.Nil(t, row.row.Price)
+	assert.Nil(t, row.row.Weight)
+}
+
+func TestNDJSONImportPrice(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want money.Money
+	}{
+		{
+			name: "money",
+			line: `{"sku":"SKU-1","title":"Shirt","price":{"amount":1050,"currency":"USD"}}`,
+			want: money.New(1050, "USD"),
+		},
+		{
+			name: "bare number",
+			line: `{"sku":"SKU-1","title":"Shirt","price":150000}`,
+			want: money.New(150000, ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := newNDJSONProductImportReader(strings.NewReader(tt.line + "\n"))
+			row, err := next()
+			require.NoError(t, err)
+			require.NoError(t, row.err)
+
+			require.NotNil(t, row.row.Price)
+			assert.Equal(t, tt.want, *row.row.Price)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ecommerce/model/entity"
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"ecommerce/repository"
+	"errors"
+	"fmt"
+)
+
+type inventoryService struct {
+	inventoryRepo   repository.InventoryProvider
+	transactionRepo repository.TransactionProvider
+}
+
+type InventoryConfig struct {
+	InventoryRepo   repository.InventoryProvider
+	TransactionRepo repository.TransactionProvider
+}
+
+func NewInventoryService(config InventoryConfig) inventoryService {
+	inventoryProvider := inventoryService{
+		inventoryRepo:   config.InventoryRepo,
+		transactionRepo: config.TransactionRepo,
+	}
+
+	return inventoryProvider
+}
+
+// AdjustStock changes the stock of a product, or of one of its variants, and records the change in the ledger.
+func (i *inventoryService) AdjustStock(ctx context.Context, productID int64, request request.AdjustStock) (err error) {
+	return i.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		var stock int64
+		if request.VariantID != 0 {
+			stock, err = i.inventoryRepo.GetVariantStockForUpdate(ctx, productID, request.VariantID)
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("%w: product %d has no variant %d", ErrInvalidVariant, productID, request.VariantID)
+			}
+		} else {
+			stock, err = i.inventoryRepo.GetProductStockForUpdate(ctx, productID)
+		}
+		if err != nil {
+			return err
+		}
+
+		stock += request.Quantity
+		if stock < 0 {
+			return ErrInsufficientStock
+		}
+
+		if request.VariantID != 0 {
+			err = i.inventoryRepo.UpdateVariantStock(ctx, request.VariantID, stock)
+		} else {
+			err = i.inventoryRepo.UpdateProductStock(ctx, productID, stock)
+		}
+		if err != nil {
+			return err
+		}
+
+		return i.inventoryRepo.CreateInventoryLedger(ctx, entity.InventoryLedger{
+			ProductID:      productID,
+			VariantID:      request.VariantID,
+			QuantityChange: request.Quantity,
+			StockAfter:     stock,
+			Reason:         entity.InventoryReasonAdjustment,
+			Reference:      request.Note,
+		})
+	})
+}
+
+func (i *inventoryService) GetInventoryLedger(ctx context.Context, productID int64) (response.GetInventoryLedgerResponse, error) {
+	var resp response.GetInventoryLedgerResponse
+
+	ledger, err := i.inventoryRepo.GetInventoryLedgerByProductID(ctx, productID)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Data = ledger
+	return resp, nil
+}
+
+// decreaseStock takes quantity from the stock of the product, or of its variant when variantID isn't 0, and returns
+// the stock left. sql.ErrNoRows is returned when not enough is left.
+func decreaseStock(ctx context.Context, inventoryRepo repository.InventoryProvider, productID int64, variantID int64, quantity int64) (int64, error) {
+	if variantID != 0 {
+		return inventoryRepo.DecreaseVariantStock(ctx, variantID, quantity)
+	}
+
+	return inventoryRepo.DecreaseProductStock(ctx, productID, quantity)
+}
+
+// restock gives quantity back to the stock of the product, or of its variant when variantID isn't 0, and records it
+// in the ledger. Nothing is given back to a variant which has been deleted since.
+func restock(ctx context.Context, inventoryRepo repository.InventoryProvider, productID int64, variantID int64, quantity int64, reason string, reference string) error {
+	var stock int64
+	var err error
+	if variantID != 0 {
+		stock, err = inventoryRepo.IncreaseVariantStock(ctx, variantID, quantity)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+	} else {
+		stock, err = inventoryRepo.IncreaseProductStock(ctx, productID, quantity)
+	}
+	if err != nil {
+		return err
+	}
+
+	return inventoryRepo.CreateInventoryLedger(ctx, entity.InventoryLedger{
+		ProductID:      productID,
+		VariantID:      variantID,
+		QuantityChange: quantity,
+		StockAfter:     stock,
+		Reason:         reason,
+		Reference:      reference,
+	})
+}
+
+// orderReference is the ledger reference of stock movements caused by an order.
+func orderReference(orderID int64) string {
+	return fmt.Sprintf("order:%d", orderID)
+}
+
+// returnReference is the ledger reference of stock given back by a return.
+func returnReference(returnRequestID int64) string {
+	return fmt.Sprintf("return:%d", returnRequestID)
+}
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"ecommerce/model/entity"
+	"ecommerce/model/money"
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"ecommerce/utils/pdf"
+	"embed"
+	"errors"
+	"fmt"
+	htmlTemplate "html/template"
+	"io/fs"
+	"sort"
+	textTemplate "text/template"
+	"time"
+)
+
+const (
+	InvoiceFormatPDF  = "pdf"
+	InvoiceFormatHTML = "html"
+
+	invoiceKeyPrefix = "invoices/"
+)
+
+var invoiceContentTypes = map[string]string{
+	InvoiceFormatPDF:  "application/pdf",
+	InvoiceFormatHTML: "text/html; charset=utf-8",
+}
+
+//go:embed templates/invoice.html templates/invoice.txt
+var invoiceTemplateFiles embed.FS
+
+var (
+	invoiceHTMLTemplate = htmlTemplate.Must(htmlTemplate.ParseFS(invoiceTemplateFiles, "templates/invoice.html"))
+	// invoiceTextTemplate lays the invoice out in fixed width columns for the pdf
+	invoiceTextTemplate = textTemplate.Must(textTemplate.ParseFS(invoiceTemplateFiles, "templates/invoice.txt"))
+)
+
+// invoiceView is what the invoice templates are rendered with, amounts are formatted with their currency.
+type invoiceView struct {
+	Number     string
+	IssuedAt   string
+	OrderID    int64
+	UserID     int64
+	SellerName string
+	SellerCity string
+	Items      []invoiceLine
+	Subtotal   string
+	Discount   string
+	Total      string
+}
+
+type invoiceLine struct {
+	Sku      string
+	Title    string
+	Quantity int64
+	Price    string
+	Amount   string
+}
+
+// issueInvoices numbers an invoice for each seller of a paid order. It runs in the transaction moving the order to
+// paid, so an invoice number is only used when the order is paid.
+func (o *orderService) issueInvoices(ctx context.Context, order entity.Order) error {
+	orderItems, err := o.orderRepo.GetOrderItemsByOrderID(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+
+	invoices := map[int64]*entity.Invoice{}
+	var sellerIDs []int64
+	for _, v := range orderItems {
+		invoice, ok := invoices[v.SellerID]
+		if !ok {
+			invoice = &entity.Invoice{OrderID: order.ID, SellerID: v.SellerID, Currency: order.Currency}
+			invoices[v.SellerID] = invoice
+			sellerIDs = append(sellerIDs, v.SellerID)
+		}
+
+		invoice.Subtotal += v.Price * v.Quantity
+		invoice.Discount += v.Discount
+	}
+
+	// sequences are always locked in the same order so concurrent payments can't deadlock
+	sort.Slice(sellerIDs, func(i, j int) bool {
+		return sellerIDs[i] < sellerIDs[j]
+	})
+
+	issuedAt := time.Now()
+	for _, id := range sellerIDs {
+		invoice := invoices[id]
+		invoice.Sequence, err = o.invoiceRepo.NextInvoiceSequence(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		invoice.Number = fmt.Sprintf("INV-%d-%06d", id, invoice.Sequence)
+		invoice.Total = invoice.Subtotal - invoice.Discount
+		invoice.IssuedAt = issuedAt
+
+		_, err = o.invoiceRepo.CreateInvoice(ctx, *invoice)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetOrderInvoice returns the invoice of a seller for a paid order as pdf or html. The document is rendered and
+// stored the first time it is asked for, later downloads are served from the storage.
+func (o *orderService) GetOrderInvoice(ctx context.Context, orderID int64, request request.GetOrderInvoice) (response.InvoiceDocument, error) {
+	var resp response.InvoiceDocument
+
+	format := request.Format
+	if format == "" {
+		format = InvoiceFormatPDF
+	}
+
+	contentType, ok := invoiceContentTypes[format]
+	if !ok {
+		return resp, fmt.Errorf("%w: format should be %s or %s", ErrInvalidInvoice, InvoiceFormatPDF, InvoiceFormatHTML)
+	}
+
+	order, err := o.orderRepo.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return resp, err
+	}
+
+	invoices, err := o.invoiceRepo.GetInvoicesByOrderID(ctx, orderID)
+	if err != nil {
+		return resp, err
+	}
+
+	if request.SellerID == 0 && len(invoices) > 1 {
+		return resp, fmt.Errorf("%w: order %d has invoices of %d sellers, seller_id is required", ErrInvalidInvoice, orderID, len(invoices))
+	}
+
+	var invoice entity.Invoice
+	for _, v := range invoices {
+		if request.SellerID == 0 || v.SellerID == request.SellerID {
+			invoice = v
+		}
+	}
+	if invoice.ID == 0 {
+		return resp, ErrInvoiceNotFound
+	}
+
+	key := fmt.Sprintf("%s%d/%s.%s", invoiceKeyPrefix, invoice.SellerID, invoice.Number, format)
+	body, err := o.storageRepo.Get(ctx, key)
+	if errors.Is(err, fs.ErrNotExist) {
+		err = o.storeInvoice(ctx, order, invoice, format, key)
+		if err != nil {
+			return resp, err
+		}
+
+		body, err = o.storageRepo.Get(ctx, key)
+	}
+	if err != nil {
+		return resp, err
+	}
+
+	resp.ContentType = contentType
+	resp.FileName = invoice.Number + "." + format
+	resp.Body = body
+
+	return resp, nil
+}
+
+// storeInvoice renders an invoice in format and stores it under key.
+func (o *orderService) storeInvoice(ctx context.Context, order entity.Order, invoice entity.Invoice, format string, key string) error {
+	view, err := o.invoiceView(ctx, order, invoice)
+	if err != nil {
+		return err
+	}
+
+	var document bytes.Buffer
+	switch format {
+	case InvoiceFormatHTML:
+		err = invoiceHTMLTemplate.Execute(&document, view)
+	default:
+		var text bytes.Buffer
+		err = invoiceTextTemplate.Execute(&text, view)
+		if err == nil {
+			err = pdf.Write(&document, o.invoiceFont, "Invoice "+invoice.Number, text.String())
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	return o.storageRepo.Put(ctx, key, invoiceContentTypes[format], &document)
+}
+
+func (o *orderService) invoiceView(ctx context.Context, order entity.Order, invoice entity.Invoice) (invoiceView, error) {
+	view := invoiceView{
+		Number:   invoice.Number,
+		IssuedAt: invoice.IssuedAt.UTC().Format("2 January 2006 15:04 MST"),
+		OrderID:  order.ID,
+		UserID:   order.UserID,
+		Subtotal: money.New(invoice.Subtotal, invoice.Currency).String(),
+		Discount: money.New(invoice.Discount, invoice.Currency).String(),
+		Total:    money.New(invoice.Total, invoice.Currency).String(),
+	}
+
+	seller, err := o.sellerRepo.GetSellerByUserID(ctx, invoice.SellerID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return view, err
+	}
+
+	view.SellerName = seller.Name
+	view.SellerCity = seller.City
+	if view.SellerName == "" {
+		view.SellerName = fmt.Sprintf("Seller %d", invoice.SellerID)
+	}
+
+	orderItems, err := o.orderRepo.GetOrderItemsByOrderID(ctx, order.ID)
+	if err != nil {
+		return view, err
+	}
+
+	for _, v := range orderItems {
+		if v.SellerID != invoice.SellerID {
+			continue
+		}
+
+		view.Items = append(view.Items, invoiceLine{
+			Sku:      v.Sku,
+			Title:    v.Title,
+			Quantity: v.Quantity,
+			Price:    money.New(v.Price, invoice.Currency).String(),
+			Amount:   money.New(v.Price*v.Quantity, invoice.Currency).String(),
+		})
+	}
+
+	return view, nil
+}
@@ -0,0 +1,516 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ecommerce/model/entity"
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"ecommerce/repository"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// defaultReservationTTL is used when no reservation TTL is configured.
+	defaultReservationTTL = 30 * time.Minute
+	// expiredReservationBatchSize is the number of orders released on each ExpireStockReservations run.
+	expiredReservationBatchSize = 100
+)
+
+// orderTransitions lists the statuses an order may move to from each status.
+var orderTransitions = map[string][]string{
+	entity.OrderStatusPendingPayment: {entity.OrderStatusPaid, entity.OrderStatusCancelled},
+	entity.OrderStatusPaid:           {entity.OrderStatusShipped, entity.OrderStatusRefunded},
+	entity.OrderStatusShipped:        {entity.OrderStatusDelivered},
+	entity.OrderStatusDelivered:      {entity.OrderStatusRefunded},
+	entity.OrderStatusCancelled:      {},
+	entity.OrderStatusRefunded:       {},
+}
+
+type orderService struct {
+	orderRepo         repository.OrderProvider
+	ecommerceRepo     repository.EcommerceProvider
+	cartRepo          repository.CartProvider
+	inventoryRepo     repository.InventoryProvider
+	promotionRepo     repository.PromotionProvider
+	categoryRepo      repository.CategoryProvider
+	paymentRepo       repository.PaymentProvider
+	paymentGateway    repository.PaymentGateway
+	returnRequestRepo repository.ReturnRequestProvider
+	invoiceRepo       repository.InvoiceProvider
+	sellerRepo        repository.SellerProvider
+	storageRepo       repository.StorageProvider
+	outboxRepo        repository.OutboxProvider
+	webhookRepo       repository.WebhookProvider
+	transactionRepo   repository.TransactionProvider
+	reservationTTL    time.Duration
+	invoiceFont       []byte
+}
+
+type OrderConfig struct {
+	OrderRepo         repository.OrderProvider
+	EcommerceRepo     repository.EcommerceProvider
+	CartRepo          repository.CartProvider
+	InventoryRepo     repository.InventoryProvider
+	PromotionRepo     repository.PromotionProvider
+	CategoryRepo      repository.CategoryProvider
+	PaymentRepo       repository.PaymentProvider
+	PaymentGateway    repository.PaymentGateway
+	ReturnRequestRepo repository.ReturnRequestProvider
+	InvoiceRepo       repository.InvoiceProvider
+	SellerRepo        repository.SellerProvider
+	// StorageRepo stores the invoices of the orders, it must not be served publicly since an invoice is only served
+	// to the buyer and the seller of its order
+	StorageRepo     repository.StorageProvider
+	OutboxRepo      repository.OutboxProvider
+	WebhookRepo     repository.WebhookProvider
+	TransactionRepo repository.TransactionProvider
+	// ReservationTTL is how long the stock of an unpaid order is held
+	ReservationTTL time.Duration
+	// InvoiceFont is the TrueType font the pdf invoices are set in, the default font of pdf.Write when nil
+	InvoiceFont []byte
+}
+
+func NewOrderService(config OrderConfig) orderService {
+	orderProvider := orderService{
+		orderRepo:         config.OrderRepo,
+		ecommerceRepo:     config.EcommerceRepo,
+		cartRepo:          config.CartRepo,
+		inventoryRepo:     config.InventoryRepo,
+		promotionRepo:     config.PromotionRepo,
+		categoryRepo:      config.CategoryRepo,
+		paymentRepo:       config.PaymentRepo,
+		paymentGateway:    config.PaymentGateway,
+		returnRequestRepo: config.ReturnRequestRepo,
+		invoiceRepo:       config.InvoiceRepo,
+		sellerRepo:        config.SellerRepo,
+		storageRepo:       config.StorageRepo,
+		outboxRepo:        config.OutboxRepo,
+		webhookRepo:       config.WebhookRepo,
+		transactionRepo:   config.TransactionRepo,
+		reservationTTL:    config.ReservationTTL,
+		invoiceFont:       config.InvoiceFont,
+	}
+
+	if orderProvider.reservationTTL <= 0 {
+		orderProvider.reservationTTL = defaultReservationTTL
+	}
+
+	return orderProvider
+}
+
+func (o *orderService) CreateOrder(ctx context.Context, request request.CreateOrder) (response.GetOrderDetailResponse, error) {
+	var resp response.GetOrderDetailResponse
+
+	var orderID int64
+	err := o.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		cart, err := o.cartRepo.GetCartByUserID(ctx, request.UserID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrEmptyCart
+		}
+		if err != nil {
+			return err
+		}
+
+		cartItems, err := o.cartRepo.GetCartItemsByCartID(ctx, cart.ID)
+		if err != nil {
+			return err
+		}
+
+		if len(cartItems) == 0 {
+			return ErrEmptyCart
+		}
+
+		// products and variants are always locked in the same order so concurrent checkouts can't deadlock
+		sort.Slice(cartItems, func(i, j int) bool {
+			if cartItems[i].ProductID != cartItems[j].ProductID {
+				return cartItems[i].ProductID < cartItems[j].ProductID
+			}
+			return cartItems[i].VariantID < cartItems[j].VariantID
+		})
+
+		summary := summarizeCart(cartItems)
+		promotions, err := evaluatePromotions(ctx, o.promotionRepo, o.categoryRepo, request.UserID, cartItems,
+			request.PromotionCodes, time.Now())
+		if err != nil {
+			return err
+		}
+
+		if len(promotions.Rejected) > 0 {
+			return fmt.Errorf("%w: %s, %s", ErrInvalidPromotion, promotions.Rejected[0].Code, promotions.Rejected[0].Reason)
+		}
+
+		orderID, err = o.orderRepo.CreateOrder(ctx, entity.Order{
+			UserID:      request.UserID,
+			Status:      entity.OrderStatusPendingPayment,
+			TotalPrice:  promotions.Total,
+			Discount:    promotions.TotalDiscount,
+			Currency:    summary.Currency,
+			TotalWeight: summary.TotalWeight,
+		})
+		if err != nil {
+			return err
+		}
+
+		itemDiscounts, err := o.usePromotions(ctx, orderID, request.UserID, promotions.Discounts)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range cartItems {
+			err = o.orderRepo.CreateOrderItem(ctx, entity.OrderItem{
+				OrderID:   orderID,
+				ProductID: v.ProductID,
+				VariantID: v.VariantID,
+				SellerID:  v.SellerID,
+				Sku:       v.Sku,
+				Title:     v.Title,
+				Price:     v.Price,
+				Quantity:  v.Quantity,
+				Discount:  itemDiscounts[cartLine{productID: v.ProductID, variantID: v.VariantID}],
+				Weight:    v.Weight,
+			})
+			if err != nil {
+				return err
+			}
+
+			err = o.reserveStock(ctx, orderID, v)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = o.orderRepo.CreateOrderStatusHistory(ctx, entity.OrderStatusHistory{
+			OrderID:  orderID,
+			ToStatus: entity.OrderStatusPendingPayment,
+		})
+		if err != nil {
+			return err
+		}
+
+		order, err := o.orderRepo.GetOrderByID(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		err = o.recordOrderEvents(ctx, order, entity.EventOrderCreated)
+		if err != nil {
+			return err
+		}
+
+		return o.cartRepo.DeleteCartItemsByCartID(ctx, cart.ID)
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	return o.GetOrderByID(ctx, orderID)
+}
+
+func (o *orderService) GetOrderByID(ctx context.Context, id int64) (response.GetOrderDetailResponse, error) {
+	var resp response.GetOrderDetailResponse
+
+	order, err := o.orderRepo.GetOrderByID(ctx, id)
+	if err != nil {
+		return resp, err
+	}
+
+	orderItems, err := o.orderRepo.GetOrderItemsByOrderID(ctx, id)
+	if err != nil {
+		return resp, err
+	}
+
+	histories, err := o.orderRepo.GetOrderStatusHistoryByOrderID(ctx, id)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Data.Order = order
+	resp.Data.Items = orderItems
+	resp.Data.StatusHistory = histories
+
+	return resp, nil
+}
+
+// UpdateOrderStatus moves an order through fulfilment. Orders are paid by the webhooks of the payment gateway and
+// refunded through RefundOrder or returns, so both statuses can't be set by hand.
+func (o *orderService) UpdateOrderStatus(ctx context.Context, id int64, request request.UpdateOrderStatus) (err error) {
+	switch request.Status {
+	case entity.OrderStatusPaid:
+		return fmt.Errorf("%w: orders are paid through their payment", ErrIllegalOrderTransition)
+	case entity.OrderStatusRefunded:
+		return fmt.Errorf("%w: orders are refunded through a refund or their returns", ErrIllegalOrderTransition)
+	}
+
+	return o.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		_, err := o.transitionOrder(ctx, id, request.Status)
+		return err
+	})
+}
+
+// transitionOrder moves the order to status, it must be called inside a transaction since the order row is locked.
+func (o *orderService) transitionOrder(ctx context.Context, id int64, status string) (entity.Order, error) {
+	if _, ok := orderTransitions[status]; !ok {
+		return entity.Order{}, ErrInvalidOrderStatus
+	}
+
+	order, err := o.orderRepo.GetOrderByIDForUpdate(ctx, id)
+	if err != nil {
+		return entity.Order{}, err
+	}
+
+	if !canTransitionOrder(order.Status, status) {
+		return entity.Order{}, fmt.Errorf("%w: %s to %s", ErrIllegalOrderTransition, order.Status, status)
+	}
+
+	err = o.orderRepo.UpdateOrderStatus(ctx, id, status)
+	if err != nil {
+		return entity.Order{}, err
+	}
+
+	err = o.orderRepo.CreateOrderStatusHistory(ctx, entity.OrderStatusHistory{
+		OrderID:    id,
+		FromStatus: order.Status,
+		ToStatus:   status,
+	})
+	if err != nil {
+		return entity.Order{}, err
+	}
+
+	switch status {
+	case entity.OrderStatusPaid:
+		err = o.commitStockReservations(ctx, id)
+		if err == nil {
+			err = o.issueInvoices(ctx, order)
+		}
+		if err == nil {
+			err = o.recordOrderEvents(ctx, order, entity.EventOrderPaid)
+		}
+	case entity.OrderStatusCancelled:
+		err = o.releaseStockReservations(ctx, id, entity.StockReservationStatusReleased, entity.InventoryReasonReservationReleased)
+		if err == nil {
+			err = o.releasePromotions(ctx, id)
+		}
+	case entity.OrderStatusRefunded:
+		// the goods of a delivered order come back through its returns, a paid order never left the warehouse
+		if order.Status == entity.OrderStatusPaid {
+			err = o.restockOrder(ctx, id)
+			if err == nil {
+				err = o.releasePromotions(ctx, id)
+			}
+		}
+	}
+	if err != nil {
+		return entity.Order{}, err
+	}
+
+	order.Status = status
+	return order, nil
+}
+
+// ExpireStockReservations cancels unpaid orders whose reservation has expired and gives their stock back. An order
+// failing to expire doesn't keep the others from expiring, the errors of the orders are returned together.
+func (o *orderService) ExpireStockReservations(ctx context.Context) (err error) {
+	orderIDs, err := o.inventoryRepo.GetExpiredStockReservationOrderIDs(ctx, time.Now(), expiredReservationBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, id := range orderIDs {
+		err = o.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+			order, err := o.orderRepo.GetOrderByIDForUpdate(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			if order.Status != entity.OrderStatusPendingPayment {
+				return nil
+			}
+
+			err = o.releaseStockReservations(ctx, id, entity.StockReservationStatusExpired, entity.InventoryReasonReservationExpired)
+			if err != nil {
+				return err
+			}
+
+			_, err = o.transitionOrder(ctx, id, entity.OrderStatusCancelled)
+			return err
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("order %d: %w", id, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// reserveStock takes the stock of a cart item for the order, the conditional update keeps the stock from going negative.
+func (o *orderService) reserveStock(ctx context.Context, orderID int64, cartItem entity.CartItemDetail) error {
+	stock, err := decreaseStock(ctx, o.inventoryRepo, cartItem.ProductID, cartItem.VariantID, cartItem.Quantity)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %s", ErrInsufficientStock, cartItem.Sku)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = o.inventoryRepo.CreateInventoryLedger(ctx, entity.InventoryLedger{
+		ProductID:      cartItem.ProductID,
+		VariantID:      cartItem.VariantID,
+		QuantityChange: -cartItem.Quantity,
+		StockAfter:     stock,
+		Reason:         entity.InventoryReasonReservation,
+		Reference:      orderReference(orderID),
+	})
+	if err != nil {
+		return err
+	}
+
+	return o.inventoryRepo.CreateStockReservation(ctx, entity.StockReservation{
+		ProductID: cartItem.ProductID,
+		VariantID: cartItem.VariantID,
+		OrderID:   orderID,
+		Quantity:  cartItem.Quantity,
+		Status:    entity.StockReservationStatusActive,
+		ExpiresAt: time.Now().Add(o.reservationTTL),
+	})
+}
+
+func (o *orderService) commitStockReservations(ctx context.Context, orderID int64) error {
+	reservations, err := o.inventoryRepo.GetActiveStockReservationsByOrderID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range reservations {
+		err = o.inventoryRepo.UpdateStockReservationStatus(ctx, v.ID, entity.StockReservationStatusCommitted)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// releaseStockReservations gives the reserved stock of the order back and closes its reservations with status.
+func (o *orderService) releaseStockReservations(ctx context.Context, orderID int64, status string, reason string) error {
+	reservations, err := o.inventoryRepo.GetActiveStockReservationsByOrderID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range reservations {
+		err = restock(ctx, o.inventoryRepo, v.ProductID, v.VariantID, v.Quantity, reason, orderReference(orderID))
+		if err != nil {
+			return err
+		}
+
+		err = o.inventoryRepo.UpdateStockReservationStatus(ctx, v.ID, status)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restockOrder puts the stock taken by the lines of the order back.
+func (o *orderService) restockOrder(ctx context.Context, orderID int64) error {
+	orderItems, err := o.orderRepo.GetOrderItemsByOrderID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range orderItems {
+		err = restock(ctx, o.inventoryRepo, v.ProductID, v.VariantID, v.Quantity, entity.InventoryReasonOrderRefunded, orderReference(orderID))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cartLine is a line of a cart or an order, a product with its chosen variant.
+type cartLine struct {
+	productID int64
+	variantID int64
+}
+
+// usePromotions counts the promotions applied to the order as used and returns the discount of every line. The
+// usage limits were checked when the promotions were evaluated, they are checked again here since the promotion row
+// is locked by the increment and concurrent checkouts could have used the promotion in between.
+func (o *orderService) usePromotions(ctx context.Context, orderID int64, userID int64, discounts []response.PromotionDiscount) (map[cartLine]int64, error) {
+	itemDiscounts := map[cartLine]int64{}
+	for _, v := range discounts {
+		promotion, err := o.promotionRepo.GetPromotionByID(ctx, v.PromotionID)
+		if err != nil {
+			return nil, err
+		}
+
+		err = o.promotionRepo.IncrementPromotionUsage(ctx, promotion.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s has reached its usage limit", ErrInvalidPromotion, promotion.Name)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if promotion.PerUserLimit > 0 {
+			used, err := o.promotionRepo.CountPromotionUsagesByUserID(ctx, promotion.ID, userID)
+			if err != nil {
+				return nil, err
+			}
+
+			if used >= promotion.PerUserLimit {
+				return nil, fmt.Errorf("%w: %s has reached its usage limit for the user", ErrInvalidPromotion, promotion.Name)
+			}
+		}
+
+		err = o.promotionRepo.CreatePromotionUsage(ctx, entity.PromotionUsage{
+			PromotionID: promotion.ID,
+			UserID:      userID,
+			OrderID:     orderID,
+			Discount:    v.Discount,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range v.Items {
+			itemDiscounts[cartLine{productID: item.ProductID, variantID: item.VariantID}] += item.Discount
+		}
+	}
+
+	return itemDiscounts, nil
+}
+
+// releasePromotions gives the uses of the promotions applied to a cancelled order back.
+func (o *orderService) releasePromotions(ctx context.Context, orderID int64) error {
+	usages, err := o.promotionRepo.GetPromotionUsagesByOrderID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range usages {
+		err = o.promotionRepo.DecrementPromotionUsage(ctx, v.PromotionID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return o.promotionRepo.DeletePromotionUsagesByOrderID(ctx, orderID)
+}
+
+func canTransitionOrder(from string, to string) bool {
+	for _, v := range orderTransitions[from] {
+		if v == to {
+			return true
+		}
+	}
+
+	return false
+}
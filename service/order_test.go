@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"ecommerce/model/entity"
+	"ecommerce/repository"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type expiredReservationRepo struct {
+	repository.InventoryProvider
+	orderIDs []int64
+}
+
+func (r expiredReservationRepo) GetExpiredStockReservationOrderIDs(ctx context.Context, now time.Time, limit int) ([]int64, error) {
+	return r.orderIDs, nil
+}
+
+// expiringOrderRepo fails to lock the orders of errs, the other orders are already paid.
+type expiringOrderRepo struct {
+	repository.OrderProvider
+	errs   map[int64]error
+	locked []int64
+}
+
+func (r *expiringOrderRepo) GetOrderByIDForUpdate(ctx context.Context, id int64) (entity.Order, error) {
+	r.locked = append(r.locked, id)
+	if err := r.errs[id]; err != nil {
+		return entity.Order{}, err
+	}
+
+	return entity.Order{ID: id, Status: entity.OrderStatusPaid}, nil
+}
+
+func TestExpireStockReservationsContinuesPastFailingOrders(t *testing.T) {
+	errLocked := errors.New("lock timeout")
+	errGone := errors.New("connection reset")
+	orderRepo := &expiringOrderRepo{errs: map[int64]error{1: errLocked, 3: errGone}}
+	o := &orderService{
+		orderRepo:       orderRepo,
+		inventoryRepo:   expiredReservationRepo{orderIDs: []int64{1, 2, 3, 4}},
+		transactionRepo: inlineTransaction{},
+	}
+
+	err := o.ExpireStockReservations(context.Background())
+
+	assert.Equal(t, []int64{1, 2, 3, 4}, orderRepo.locked)
+	assert.ErrorIs(t, err, errLocked)
+	assert.ErrorIs(t, err, errGone)
+	assert.EqualError(t, err, "order 1: lock timeout\norder 3: connection reset")
+}
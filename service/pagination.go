@@ -0,0 +1,28 @@
+package service
+
+import (
+	"ecommerce/model/request"
+	sdkSql "ecommerce/utils/sql"
+)
+
+const defaultPerPage = 10
+
+// paginate fills the pagination meta for totalItems and returns it with the limit and offset of the requested page.
+func paginate(request request.Pagination, totalItems int64, path string) (sdkSql.PaginationMetaMessage, int64, int64) {
+	perPage := request.PerPage
+	if perPage <= 0 {
+		perPage = defaultPerPage
+	}
+
+	params := map[string]interface{}{
+		"per_page": float64(perPage),
+	}
+	if request.Page > 0 {
+		params["page"] = float64(request.Page)
+	}
+
+	meta := sdkSql.PaginationMetaMessage{TotalItems: totalItems}
+	sdkSql.Paginate(params, &meta, path)
+
+	return meta, meta.PerPage, meta.FromItem
+}
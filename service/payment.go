@@ -0,0 +1,402 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ecommerce/model/entity"
+	"ecommerce/model/money"
+	"ecommerce/model/response"
+	"errors"
+	"fmt"
+)
+
+// CreatePayment starts the charge of an order waiting for payment. A charge still waiting to be paid is returned
+// instead of a new one, so retrying a checkout never charges the order twice. The payment is recorded before the
+// charge is created at the gateway, outside of the transaction, with the idempotency key of the payment, so a checkout
+// retried after the gateway failed gets the same charge.
+func (o *orderService) CreatePayment(ctx context.Context, orderID int64) (response.GetPaymentResponse, error) {
+	var resp response.GetPaymentResponse
+
+	var payment entity.Payment
+	err := o.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		order, err := o.orderRepo.GetOrderByIDForUpdate(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		if order.Status != entity.OrderStatusPendingPayment {
+			return fmt.Errorf("%w: order %d is %s", ErrInvalidPayment, orderID, order.Status)
+		}
+
+		if order.TotalPrice <= 0 {
+			return fmt.Errorf("%w: order %d has nothing to pay", ErrInvalidPayment, orderID)
+		}
+
+		payments, err := o.paymentRepo.GetPaymentsByOrderID(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range payments {
+			switch v.Status {
+			case entity.PaymentStatusCreated, entity.PaymentStatusPending, entity.PaymentStatusAuthorized:
+				payment = v
+				return nil
+			}
+		}
+
+		payment = entity.Payment{
+			OrderID:  orderID,
+			Gateway:  o.paymentGateway.Name(),
+			Amount:   order.TotalPrice,
+			Currency: order.Currency,
+			Status:   entity.PaymentStatusCreated,
+		}
+		payment.ID, err = o.paymentRepo.CreatePayment(ctx, payment)
+		return err
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	if payment.Status == entity.PaymentStatusCreated {
+		charge, err := o.paymentGateway.CreateCharge(ctx, paymentIdempotencyKey(payment.ID), orderID, payment.Amount, payment.Currency)
+		if err != nil {
+			return resp, err
+		}
+
+		err = o.paymentRepo.UpdatePaymentCharge(ctx, payment.ID, charge)
+		if err != nil {
+			return resp, err
+		}
+
+		payment.ChargeID = charge.ID
+		payment.Status = charge.Status
+		payment.PaymentUrl = charge.PaymentUrl
+	}
+
+	resp.Data = toPaymentResponse(payment)
+
+	return resp, nil
+}
+
+func (o *orderService) GetOrderPayments(ctx context.Context, orderID int64) (response.GetPaymentListResponse, error) {
+	var resp response.GetPaymentListResponse
+
+	_, err := o.orderRepo.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return resp, err
+	}
+
+	payments, err := o.paymentRepo.GetPaymentsByOrderID(ctx, orderID)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Data = make([]response.Payment, 0, len(payments))
+	for _, v := range payments {
+		resp.Data = append(resp.Data, toPaymentResponse(v))
+	}
+
+	return resp, nil
+}
+
+// HandlePaymentWebhook applies a webhook event of the payment gateway to its payment and order. Gateways deliver
+// events at least once, an event already applied is recorded once and then ignored. The capture and the refunds the
+// event leads to are asked from the gateway once the event is committed, a redelivered event retries them.
+func (o *orderService) HandlePaymentWebhook(ctx context.Context, payload []byte, signature string) (err error) {
+	event, err := o.paymentGateway.VerifyWebhook(ctx, payload, signature)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+
+	var payment entity.Payment
+	err = o.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		payment, err = o.paymentRepo.GetPaymentByChargeIDForUpdate(ctx, o.paymentGateway.Name(), event.ChargeID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: charge %s is not known", ErrInvalidPayment, event.ChargeID)
+		}
+		if err != nil {
+			return err
+		}
+
+		created, err := o.paymentRepo.CreatePaymentEvent(ctx, entity.PaymentEvent{
+			Gateway:   payment.Gateway,
+			EventID:   event.ID,
+			PaymentID: payment.ID,
+			Type:      event.Type,
+			Payload:   payload,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !created {
+			return nil
+		}
+
+		if event.Amount != 0 && event.Amount != payment.Amount {
+			return fmt.Errorf("%w: charge %s is of %d, the event is of %d", ErrInvalidPayment, payment.ChargeID, payment.Amount, event.Amount)
+		}
+
+		switch event.Type {
+		case entity.PaymentEventAuthorized:
+			payment, err = o.authorizePayment(ctx, payment)
+		case entity.PaymentEventCaptured:
+			payment, err = o.completePayment(ctx, payment)
+		case entity.PaymentEventFailed:
+			if payment.Status != entity.PaymentStatusPending && payment.Status != entity.PaymentStatusAuthorized {
+				return nil
+			}
+
+			err = o.paymentRepo.UpdatePaymentStatus(ctx, payment.ID, entity.PaymentStatusFailed)
+		}
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return o.settlePayment(ctx, payment)
+}
+
+// authorizePayment marks a charge authorized while its order still waits for payment, the charge of an order which
+// can't be paid anymore is left to expire at the gateway.
+func (o *orderService) authorizePayment(ctx context.Context, payment entity.Payment) (entity.Payment, error) {
+	if payment.Status != entity.PaymentStatusPending {
+		return payment, nil
+	}
+
+	order, err := o.orderRepo.GetOrderByIDForUpdate(ctx, payment.OrderID)
+	if err != nil {
+		return entity.Payment{}, err
+	}
+
+	payment.Status = entity.PaymentStatusAuthorized
+	if order.Status != entity.OrderStatusPendingPayment {
+		payment.Status = entity.PaymentStatusFailed
+	}
+
+	err = o.paymentRepo.UpdatePaymentStatus(ctx, payment.ID, payment.Status)
+	if err != nil {
+		return entity.Payment{}, err
+	}
+
+	return payment, nil
+}
+
+// completePayment marks a captured charge and moves its order to paid. The money of a charge captured for an order
+// which is already paid or can't be paid anymore is refunded.
+func (o *orderService) completePayment(ctx context.Context, payment entity.Payment) (entity.Payment, error) {
+	if payment.Status != entity.PaymentStatusPending && payment.Status != entity.PaymentStatusAuthorized {
+		return payment, nil
+	}
+
+	order, err := o.orderRepo.GetOrderByIDForUpdate(ctx, payment.OrderID)
+	if err != nil {
+		return entity.Payment{}, err
+	}
+
+	if order.Status != entity.OrderStatusPendingPayment {
+		return o.refundPayment(ctx, payment, entity.Refund{
+			OrderID: payment.OrderID,
+			Amount:  payment.Amount,
+		})
+	}
+
+	payment.Status = entity.PaymentStatusCaptured
+	err = o.paymentRepo.UpdatePaymentStatus(ctx, payment.ID, payment.Status)
+	if err != nil {
+		return entity.Payment{}, err
+	}
+
+	_, err = o.transitionOrder(ctx, order.ID, entity.OrderStatusPaid)
+	if err != nil {
+		return entity.Payment{}, err
+	}
+
+	return payment, nil
+}
+
+// settlePayment asks the gateway for what a committed payment still waits for, it runs outside of transactions so no
+// row is locked while the gateway is called. An authorized charge is captured and then completed, and the pending
+// refunds of the order are given back.
+func (o *orderService) settlePayment(ctx context.Context, payment entity.Payment) error {
+	if payment.Status == entity.PaymentStatusAuthorized {
+		err := o.paymentGateway.Capture(ctx, captureIdempotencyKey(payment.ID), payment.ChargeID, payment.Amount)
+		if err != nil {
+			return err
+		}
+
+		err = o.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+			payment, err := o.paymentRepo.GetPaymentByChargeIDForUpdate(ctx, payment.Gateway, payment.ChargeID)
+			if err != nil {
+				return err
+			}
+
+			_, err = o.completePayment(ctx, payment)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return o.settleRefunds(ctx, payment.OrderID)
+}
+
+// RefundOrder gives the whole payment of a paid order back before it is shipped, its stock is put back and its
+// promotions can be used again. The goods of shipped orders are refunded through returns. The refund is recorded with
+// the order and asked from the gateway afterwards, refunding the order again retries a refund the gateway failed.
+func (o *orderService) RefundOrder(ctx context.Context, orderID int64) (err error) {
+	err = o.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		order, err := o.orderRepo.GetOrderByIDForUpdate(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		if order.Status == entity.OrderStatusRefunded {
+			return nil
+		}
+
+		if order.Status != entity.OrderStatusPaid {
+			return fmt.Errorf("%w: order %d is %s, only paid orders which are not shipped yet can be refunded", ErrIllegalOrderTransition, orderID, order.Status)
+		}
+
+		payment, err := o.capturedPaymentForUpdate(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		_, err = o.refundPayment(ctx, payment, entity.Refund{
+			OrderID: orderID,
+			Amount:  payment.Amount - payment.RefundedAmount,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = o.transitionOrder(ctx, orderID, entity.OrderStatusRefunded)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return o.settleRefunds(ctx, orderID)
+}
+
+// capturedPaymentForUpdate returns the captured payment of the order, the payment row is locked so concurrent refunds
+// are added up one after the other.
+func (o *orderService) capturedPaymentForUpdate(ctx context.Context, orderID int64) (entity.Payment, error) {
+	payments, err := o.paymentRepo.GetPaymentsByOrderID(ctx, orderID)
+	if err != nil {
+		return entity.Payment{}, err
+	}
+
+	var payment entity.Payment
+	for _, v := range payments {
+		if v.Status == entity.PaymentStatusCaptured || v.Status == entity.PaymentStatusPartiallyRefunded {
+			payment = v
+		}
+	}
+	if payment.ID == 0 {
+		return entity.Payment{}, fmt.Errorf("%w: order %d has no captured payment to refund", ErrInvalidPayment, orderID)
+	}
+
+	return o.paymentRepo.GetPaymentByChargeIDForUpdate(ctx, payment.Gateway, payment.ChargeID)
+}
+
+// refundPayment records a pending refund of the locked payment, the payment is returned with its refunded amount and
+// status updated. The money is given back by settleRefunds once the transaction is committed.
+func (o *orderService) refundPayment(ctx context.Context, payment entity.Payment, refund entity.Refund) (entity.Payment, error) {
+	refundedAmount := payment.RefundedAmount + refund.Amount
+	if refund.Amount <= 0 || refundedAmount > payment.Amount {
+		return entity.Payment{}, fmt.Errorf("%w: only %d of payment %d is left to refund", ErrInvalidPayment, payment.Amount-payment.RefundedAmount, payment.ID)
+	}
+
+	status := entity.PaymentStatusPartiallyRefunded
+	if refundedAmount == payment.Amount {
+		status = entity.PaymentStatusRefunded
+	}
+
+	err := o.paymentRepo.UpdatePaymentRefundedAmount(ctx, payment.ID, refundedAmount, status)
+	if err != nil {
+		return entity.Payment{}, err
+	}
+
+	refund.PaymentID = payment.ID
+	refund.Status = entity.RefundStatusPending
+	err = o.paymentRepo.CreateRefund(ctx, refund)
+	if err != nil {
+		return entity.Payment{}, err
+	}
+
+	payment.RefundedAmount = refundedAmount
+	payment.Status = status
+	return payment, nil
+}
+
+// settleRefunds asks the gateway for the pending refunds of the order and records the ones given back. It runs outside
+// of transactions, a refund the gateway failed stays pending and is retried with the same idempotency key.
+func (o *orderService) settleRefunds(ctx context.Context, orderID int64) error {
+	refunds, err := o.paymentRepo.GetPendingRefundsByOrderID(ctx, orderID)
+	if err != nil || len(refunds) == 0 {
+		return err
+	}
+
+	payments, err := o.paymentRepo.GetPaymentsByOrderID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	chargeIDs := make(map[int64]string, len(payments))
+	for _, v := range payments {
+		chargeIDs[v.ID] = v.ChargeID
+	}
+
+	for _, v := range refunds {
+		err = o.paymentGateway.Refund(ctx, refundIdempotencyKey(v.ID), chargeIDs[v.PaymentID], v.Amount)
+		if err != nil {
+			return err
+		}
+
+		err = o.paymentRepo.UpdateRefundStatus(ctx, v.ID, entity.RefundStatusSucceeded)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// paymentIdempotencyKey is the idempotency key of the charge of a payment.
+func paymentIdempotencyKey(paymentID int64) string {
+	return fmt.Sprintf("payment:%d", paymentID)
+}
+
+// captureIdempotencyKey is the idempotency key of the capture of a payment.
+func captureIdempotencyKey(paymentID int64) string {
+	return fmt.Sprintf("payment:%d:capture", paymentID)
+}
+
+// refundIdempotencyKey is the idempotency key of a refund.
+func refundIdempotencyKey(refundID int64) string {
+	return fmt.Sprintf("refund:%d", refundID)
+}
+
+func toPaymentResponse(payment entity.Payment) response.Payment {
+	return response.Payment{
+		ID:             payment.ID,
+		OrderID:        payment.OrderID,
+		Gateway:        payment.Gateway,
+		ChargeID:       payment.ChargeID,
+		Amount:         money.New(payment.Amount, payment.Currency),
+		RefundedAmount: money.New(payment.RefundedAmount, payment.Currency),
+		Status:         payment.Status,
+		PaymentUrl:     payment.PaymentUrl,
+		CreatedAt:      payment.CreatedAt,
+		UpdatedAt:      payment.UpdatedAt,
+	}
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"ecommerce/model/entity"
+	"ecommerce/repository"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type refundPaymentRepo struct {
+	repository.PaymentProvider
+	payments []entity.Payment
+	refunds  []entity.Refund
+}
+
+func (r *refundPaymentRepo) GetPaymentsByOrderID(ctx context.Context, orderID int64) ([]entity.Payment, error) {
+	return r.payments, nil
+}
+
+func (r *refundPaymentRepo) GetPendingRefundsByOrderID(ctx context.Context, orderID int64) ([]entity.Refund, error) {
+	var refunds []entity.Refund
+	for _, v := range r.refunds {
+		if v.OrderID == orderID && v.Status == entity.RefundStatusPending {
+			refunds = append(refunds, v)
+		}
+	}
+
+	return refunds, nil
+}
+
+func (r *refundPaymentRepo) UpdateRefundStatus(ctx context.Context, id int64, status string) error {
+	for i := range r.refunds {
+		if r.refunds[i].ID == id {
+			r.refunds[i].Status = status
+		}
+	}
+
+	return nil
+}
+
+// failingRefundGateway fails the first failures refunds it is asked for.
+type failingRefundGateway struct {
+	repository.PaymentGateway
+	failures int
+	keys     []string
+	charges  []string
+}
+
+func (f *failingRefundGateway) Refund(ctx context.Context, idempotencyKey string, chargeID string, amount int64) error {
+	f.keys = append(f.keys, idempotencyKey)
+	f.charges = append(f.charges, chargeID)
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("gateway is unavailable")
+	}
+
+	return nil
+}
+
+func TestSettleRefundsRetriesFailedRefundWithSameKey(t *testing.T) {
+	paymentRepo := &refundPaymentRepo{
+		payments: []entity.Payment{{ID: 3, OrderID: 9, ChargeID: "ch_1"}},
+		refunds: []entity.Refund{
+			{ID: 1, OrderID: 9, PaymentID: 3, Amount: 500, Status: entity.RefundStatusPending},
+			{ID: 2, OrderID: 9, PaymentID: 3, Amount: 700, Status: entity.RefundStatusPending},
+			{ID: 4, OrderID: 9, PaymentID: 3, Amount: 100, Status: entity.RefundStatusSucceeded},
+		},
+	}
+	gateway := &failingRefundGateway{failures: 1}
+	o := &orderService{paymentRepo: paymentRepo, paymentGateway: gateway}
+
+	err := o.settleRefunds(context.Background(), 9)
+	require.Error(t, err)
+	assert.Equal(t, entity.RefundStatusPending, paymentRepo.refunds[0].Status)
+	assert.Equal(t, entity.RefundStatusPending, paymentRepo.refunds[1].Status)
+
+	err = o.settleRefunds(context.Background(), 9)
+	require.NoError(t, err)
+	assert.Equal(t, entity.RefundStatusSucceeded, paymentRepo.refunds[0].Status)
+	assert.Equal(t, entity.RefundStatusSucceeded, paymentRepo.refunds[1].Status)
+
+	assert.Equal(t, []string{"refund:1", "refund:1", "refund:2"}, gateway.keys)
+	assert.Equal(t, []string{"ch_1", "ch_1", "ch_1"}, gateway.charges)
+}
@@ -0,0 +1,279 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ecommerce/model/entity"
+	"ecommerce/model/money"
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"fmt"
+	"time"
+)
+
+const (
+	ProductPriceStatusExpired   = "expired"
+	ProductPriceStatusActive    = "active"
+	ProductPriceStatusScheduled = "scheduled"
+)
+
+// priceNow is the current time at the precision prices are stored with, so stored starts and ends compare equal to
+// the times they were written with.
+func priceNow() time.Time {
+	return time.Now().Truncate(time.Microsecond)
+}
+
+// GetProductPrices returns the price history of a product, including the prices scheduled for later.
+func (e *ecommerceService) GetProductPrices(ctx context.Context, productID int64) (response.GetProductPricesResponse, error) {
+	var resp response.GetProductPricesResponse
+
+	product, err := e.ecommerceRepo.GetProductByID(ctx, productID)
+	if err != nil {
+		return resp, err
+	}
+
+	prices, err := e.productPriceRepo.GetProductPricesByProductID(ctx, productID)
+	if err != nil {
+		return resp, err
+	}
+
+	now := priceNow()
+	resp.Data = make([]response.ProductPrice, 0, len(prices))
+	for _, v := range prices {
+		price := response.ProductPrice{
+			ID:            v.ID,
+			Kind:          v.Kind,
+			Price:         money.New(v.Price, product.Price.Currency),
+			EffectiveFrom: v.EffectiveFrom,
+			Status:        productPriceStatus(v, now),
+		}
+
+		if v.EffectiveTo.Valid {
+			effectiveTo := v.EffectiveTo.Time
+			price.EffectiveTo = &effectiveTo
+		}
+
+		resp.Data = append(resp.Data, price)
+	}
+
+	return resp, nil
+}
+
+func productPriceStatus(price entity.ProductPrice, now time.Time) string {
+	switch {
+	case price.EffectiveFrom.After(now):
+		return ProductPriceStatusScheduled
+	case price.EffectiveTo.Valid && !price.EffectiveTo.Time.After(now):
+		return ProductPriceStatusExpired
+	default:
+		return ProductPriceStatusActive
+	}
+}
+
+// ScheduleProductPrice schedules a regular price change or a sale of a product.
+func (e *ecommerceService) ScheduleProductPrice(ctx context.Context, productID int64, request request.ScheduleProductPrice) (err error) {
+	now := priceNow()
+	effectiveFrom := request.EffectiveFrom.Truncate(time.Microsecond)
+	if effectiveFrom.IsZero() {
+		effectiveFrom = now
+	}
+
+	switch {
+	case request.Price.Amount < 0:
+		return fmt.Errorf("%w: price should not be negative", ErrInvalidProductPrice)
+	case effectiveFrom.Before(now):
+		return fmt.Errorf("%w: effective_from should not be in the past", ErrInvalidProductPrice)
+	case request.Kind == entity.ProductPriceSale && !request.EffectiveTo.After(effectiveFrom):
+		return fmt.Errorf("%w: effective_to of a sale should be after its effective_from", ErrInvalidProductPrice)
+	case request.Kind != entity.ProductPriceRegular && request.Kind != entity.ProductPriceSale:
+		return fmt.Errorf("%w: kind should be %s or %s", ErrInvalidProductPrice, entity.ProductPriceRegular, entity.ProductPriceSale)
+	}
+
+	return e.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		err := e.ecommerceRepo.LockProduct(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		product, err := e.ecommerceRepo.GetProductByID(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		if request.Price.Currency != "" && request.Price.Currency != product.Price.Currency {
+			return fmt.Errorf("%w: the price should be in %s, the currency of the product", ErrInvalidCurrency, product.Price.Currency)
+		}
+
+		payload := productPriceEvent{
+			ProductID:     productID,
+			Kind:          request.Kind,
+			Price:         money.New(request.Price.Amount, product.Price.Currency),
+			EffectiveFrom: effectiveFrom,
+		}
+
+		if request.Kind == entity.ProductPriceRegular {
+			err = e.scheduleRegularPrice(ctx, productID, request.Price.Amount, effectiveFrom)
+		} else {
+			effectiveTo := request.EffectiveTo.Truncate(time.Microsecond)
+			payload.EffectiveTo = &effectiveTo
+			err = e.scheduleSalePrice(ctx, productID, request.Price.Amount, effectiveFrom, effectiveTo)
+		}
+		if err != nil {
+			return err
+		}
+
+		return e.recordProductEvent(ctx, productID, entity.EventProductPriceScheduled, payload)
+	})
+}
+
+// scheduleRegularPrice inserts a regular price starting at effectiveFrom into the regular prices of the product. The
+// price in effect at that time ends there, the new price ends where the next scheduled one starts.
+func (e *ecommerceService) scheduleRegularPrice(ctx context.Context, productID int64, price int64, effectiveFrom time.Time) (err error) {
+	prices, err := e.productPriceRepo.GetProductPricesByProductID(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	var effectiveTo sql.NullTime
+	for _, v := range prices {
+		if v.Kind != entity.ProductPriceRegular {
+			continue
+		}
+
+		if v.EffectiveFrom.Equal(effectiveFrom) {
+			return fmt.Errorf("%w: a regular price already starts at %s", ErrInvalidProductPrice, effectiveFrom.Format(time.RFC3339))
+		}
+
+		if v.EffectiveFrom.After(effectiveFrom) && (!effectiveTo.Valid || v.EffectiveFrom.Before(effectiveTo.Time)) {
+			effectiveTo = sql.NullTime{Valid: true, Time: v.EffectiveFrom}
+		}
+	}
+
+	for _, v := range prices {
+		covers := v.EffectiveFrom.Before(effectiveFrom) && (!v.EffectiveTo.Valid || v.EffectiveTo.Time.After(effectiveFrom))
+		if v.Kind != entity.ProductPriceRegular || !covers {
+			continue
+		}
+
+		err = e.updateProductPriceEffectiveTo(ctx, v, sql.NullTime{Valid: true, Time: effectiveFrom})
+		if err != nil {
+			return err
+		}
+	}
+
+	return e.createProductPrice(ctx, entity.ProductPrice{
+		ProductID:     productID,
+		Kind:          entity.ProductPriceRegular,
+		Price:         price,
+		EffectiveFrom: effectiveFrom,
+		EffectiveTo:   effectiveTo,
+	})
+}
+
+// scheduleSalePrice adds a sale of the product, sales of a product can't overlap.
+func (e *ecommerceService) scheduleSalePrice(ctx context.Context, productID int64, price int64, effectiveFrom time.Time, effectiveTo time.Time) (err error) {
+	prices, err := e.productPriceRepo.GetProductPricesByProductID(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range prices {
+		if v.Kind == entity.ProductPriceSale && v.EffectiveFrom.Before(effectiveTo) && v.EffectiveTo.Time.After(effectiveFrom) {
+			return fmt.Errorf("%w: the sale overlaps sale %d", ErrInvalidProductPrice, v.ID)
+		}
+	}
+
+	return e.createProductPrice(ctx, entity.ProductPrice{
+		ProductID:     productID,
+		Kind:          entity.ProductPriceSale,
+		Price:         price,
+		EffectiveFrom: effectiveFrom,
+		EffectiveTo:   sql.NullTime{Valid: true, Time: effectiveTo},
+	})
+}
+
+// CancelProductPrice removes a price which is not in effect yet, the regular price before it then lasts until the
+// next one. A running sale ends right away.
+func (e *ecommerceService) CancelProductPrice(ctx context.Context, productID int64, priceID int64) (err error) {
+	return e.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		err := e.ecommerceRepo.LockProduct(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		prices, err := e.productPriceRepo.GetProductPricesByProductID(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		var price entity.ProductPrice
+		for _, v := range prices {
+			if v.ID == priceID {
+				price = v
+			}
+		}
+		if price.ID == 0 {
+			return ErrProductPriceNotFound
+		}
+
+		now := priceNow()
+		switch productPriceStatus(price, now) {
+		case ProductPriceStatusExpired:
+			return fmt.Errorf("%w: price %d is already expired", ErrInvalidProductPrice, priceID)
+		case ProductPriceStatusActive:
+			if price.Kind == entity.ProductPriceRegular {
+				return fmt.Errorf("%w: the regular price in effect can only be replaced", ErrInvalidProductPrice)
+			}
+
+			err = e.updateProductPriceEffectiveTo(ctx, price, sql.NullTime{Valid: true, Time: now})
+			if err != nil {
+				return err
+			}
+
+			return e.recordProductPriceCancelledEvent(ctx, price)
+		}
+
+		if price.Kind == entity.ProductPriceRegular {
+			for _, v := range prices {
+				if v.Kind == entity.ProductPriceRegular && v.EffectiveTo.Valid && v.EffectiveTo.Time.Equal(price.EffectiveFrom) {
+					err = e.updateProductPriceEffectiveTo(ctx, v, price.EffectiveTo)
+					if err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		err = e.productPriceRepo.DeleteProductPrice(ctx, price.ID)
+		if err != nil {
+			return err
+		}
+
+		err = e.recordAudit(ctx, entity.AuditEntityProductPrice, price.ID, productID, price, nil)
+		if err != nil {
+			return err
+		}
+
+		return e.recordProductPriceCancelledEvent(ctx, price)
+	})
+}
+
+func (e *ecommerceService) createProductPrice(ctx context.Context, price entity.ProductPrice) (err error) {
+	price.ID, err = e.productPriceRepo.CreateProductPrice(ctx, price)
+	if err != nil {
+		return err
+	}
+
+	return e.recordAudit(ctx, entity.AuditEntityProductPrice, price.ID, price.ProductID, nil, price)
+}
+
+func (e *ecommerceService) updateProductPriceEffectiveTo(ctx context.Context, price entity.ProductPrice, effectiveTo sql.NullTime) (err error) {
+	err = e.productPriceRepo.UpdateProductPriceEffectiveTo(ctx, price.ID, effectiveTo)
+	if err != nil {
+		return err
+	}
+
+	updated := price
+	updated.EffectiveTo = effectiveTo
+	return e.recordAudit(ctx, entity.AuditEntityProductPrice, price.ID, price.ProductID, price, updated)
+}
@@ -0,0 +1,469 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ecommerce/model/entity"
+	"ecommerce/model/money"
+	"ecommerce/model/request"
+	"ecommerce/repository"
+	"math"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryPriceRepo keeps the prices of products in memory.
+type memoryPriceRepo struct {
+	repository.ProductPriceProvider
+	prices []entity.ProductPrice
+	nextID int64
+}
+
+func (m *memoryPriceRepo) GetProductPricesByProductID(ctx context.Context, productID int64) ([]entity.ProductPrice, error) {
+	var prices []entity.ProductPrice
+	for _, v := range m.prices {
+		if v.ProductID == productID {
+			prices = append(prices, v)
+		}
+	}
+
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].EffectiveFrom.After(prices[j].EffectiveFrom)
+	})
+
+	return prices, nil
+}
+
+func (m *memoryPriceRepo) CreateProductPrice(ctx context.Context, payload entity.ProductPrice) (int64, error) {
+	m.nextID++
+	payload.ID = m.nextID
+	m.prices = append(m.prices, payload)
+
+	return payload.ID, nil
+}
+
+func (m *memoryPriceRepo) UpdateProductPriceEffectiveTo(ctx context.Context, id int64, effectiveTo sql.NullTime) error {
+	for i := range m.prices {
+		if m.prices[i].ID == id {
+			m.prices[i].EffectiveTo = effectiveTo
+		}
+	}
+
+	return nil
+}
+
+func (m *memoryPriceRepo) DeleteProductPrice(ctx context.Context, id int64) error {
+	for i := range m.prices {
+		if m.prices[i].ID == id {
+			m.prices = append(m.prices[:i], m.prices[i+1:]...)
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// inlineTransaction runs the function of a transaction as is.
+type inlineTransaction struct{}
+
+func (inlineTransaction) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type noAuditLogRepo struct {
+	repository.AuditLogProvider
+}
+
+func (noAuditLogRepo) CreateAuditLog(ctx context.Context, payload entity.AuditLog) error {
+	return nil
+}
+
+type noOutboxRepo struct {
+	repository.OutboxProvider
+}
+
+func (noOutboxRepo) CreateOutboxEvent(ctx context.Context, payload entity.OutboxEvent) (int64, error) {
+	return 1, nil
+}
+
+type priceProductRepo struct {
+	repository.EcommerceProvider
+}
+
+func (priceProductRepo) LockProduct(ctx context.Context, id int64) error {
+	return nil
+}
+
+func (priceProductRepo) GetProductByID(ctx context.Context, id int64) (entity.Product, error) {
+	return entity.Product{ID: id, Price: money.New(10000, "IDR")}, nil
+}
+
+// openEnded is the end of a price lasting until another one is scheduled.
+const openEnded = time.Duration(math.MinInt64)
+
+// priceSpan returns a price of product 1 in effect from now+from until now+to.
+func priceSpan(now time.Time, id int64, kind string, price int64, from time.Duration, to time.Duration) entity.ProductPrice {
+	productPrice := entity.ProductPrice{ID: id, ProductID: 1, Kind: kind, Price: price, EffectiveFrom: now.Add(from)}
+	if to != openEnded {
+		productPrice.EffectiveTo = sql.NullTime{Valid: true, Time: now.Add(to)}
+	}
+
+	return productPrice
+}
+
+func newPriceTestService(prices []entity.ProductPrice) (*ecommerceService, *memoryPriceRepo) {
+	priceRepo := &memoryPriceRepo{prices: prices, nextID: 10}
+	e := &ecommerceService{
+		ecommerceRepo:    priceProductRepo{},
+		productPriceRepo: priceRepo,
+		auditLogRepo:     noAuditLogRepo{},
+		outboxRepo:       noOutboxRepo{},
+		transactionRepo:  inlineTransaction{},
+	}
+
+	return e, priceRepo
+}
+
+// sortedPrices returns the prices ordered by start then by id, the order they are compared in.
+func sortedPrices(prices []entity.ProductPrice) []entity.ProductPrice {
+	sorted := append([]entity.ProductPrice{}, prices...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].EffectiveFrom.Equal(sorted[j].EffectiveFrom) {
+			return sorted[i].EffectiveFrom.Before(sorted[j].EffectiveFrom)
+		}
+
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	return sorted
+}
+
+func TestScheduleRegularPrice(t *testing.T) {
+	now := priceNow()
+	h := time.Hour
+	regular, sale := entity.ProductPriceRegular, entity.ProductPriceSale
+
+	tests := []struct {
+		name    string
+		prices  []entity.ProductPrice
+		from    time.Duration
+		want    []entity.ProductPrice
+		wantErr error
+	}{
+		{
+			name: "first price",
+			from: h,
+			want: []entity.ProductPrice{priceSpan(now, 11, regular, 20000, h, openEnded)},
+		},
+		{
+			name:   "after the price in effect",
+			prices: []entity.ProductPrice{priceSpan(now, 1, regular, 10000, -h, openEnded)},
+			from:   2 * h,
+			want: []entity.ProductPrice{
+				priceSpan(now, 1, regular, 10000, -h, 2*h),
+				priceSpan(now, 11, regular, 20000, 2*h, openEnded),
+			},
+		},
+		{
+			name: "between the price in effect and a scheduled one",
+			prices: []entity.ProductPrice{
+				priceSpan(now, 1, regular, 10000, -h, 5*h),
+				priceSpan(now, 2, regular, 30000, 5*h, openEnded),
+			},
+			from: 2 * h,
+			want: []entity.ProductPrice{
+				priceSpan(now, 1, regular, 10000, -h, 2*h),
+				priceSpan(now, 11, regular, 20000, 2*h, 5*h),
+				priceSpan(now, 2, regular, 30000, 5*h, openEnded),
+			},
+		},
+		{
+			name: "before every scheduled price",
+			prices: []entity.ProductPrice{
+				priceSpan(now, 1, regular, 10000, 3*h, 6*h),
+				priceSpan(now, 2, regular, 30000, 6*h, openEnded),
+			},
+			from: h,
+			want: []entity.ProductPrice{
+				priceSpan(now, 11, regular, 20000, h, 3*h),
+				priceSpan(now, 1, regular, 10000, 3*h, 6*h),
+				priceSpan(now, 2, regular, 30000, 6*h, openEnded),
+			},
+		},
+		{
+			name: "sales are left as they are",
+			prices: []entity.ProductPrice{
+				priceSpan(now, 1, regular, 10000, -h, openEnded),
+				priceSpan(now, 2, sale, 5000, 0, 4*h),
+			},
+			from: 2 * h,
+			want: []entity.ProductPrice{
+				priceSpan(now, 1, regular, 10000, -h, 2*h),
+				priceSpan(now, 2, sale, 5000, 0, 4*h),
+				priceSpan(now, 11, regular, 20000, 2*h, openEnded),
+			},
+		},
+		{
+			name: "a regular price already starts then",
+			prices: []entity.ProductPrice{
+				priceSpan(now, 1, regular, 10000, -h, 2*h),
+				priceSpan(now, 2, regular, 30000, 2*h, openEnded),
+			},
+			from:    2 * h,
+			wantErr: ErrInvalidProductPrice,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, priceRepo := newPriceTestService(tt.prices)
+
+			err := e.scheduleRegularPrice(context.Background(), 1, 20000, now.Add(tt.from))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, sortedPrices(priceRepo.prices))
+		})
+	}
+}
+
+func TestCancelProductPrice(t *testing.T) {
+	now := priceNow()
+	h := time.Hour
+	regular, sale := entity.ProductPriceRegular, entity.ProductPriceSale
+
+	tests := []struct {
+		name    string
+		prices  []entity.ProductPrice
+		priceID int64
+		want    []entity.ProductPrice
+		wantErr error
+	}{
+		{
+			name: "regular price between two others",
+			prices: []entity.ProductPrice{
+				priceSpan(now, 1, regular, 10000, -h, 2*h),
+				priceSpan(now, 2, regular, 20000, 2*h, 5*h),
+				priceSpan(now, 3, regular, 30000, 5*h, openEnded),
+			},
+			priceID: 2,
+			want: []entity.ProductPrice{
+				priceSpan(now, 1, regular, 10000, -h, 5*h),
+				priceSpan(now, 3, regular, 30000, 5*h, openEnded),
+			},
+		},
+		{
+			name: "last scheduled regular price",
+			prices: []entity.ProductPrice{
+				priceSpan(now, 1, regular, 10000, -h, 2*h),
+				priceSpan(now, 2, regular, 20000, 2*h, openEnded),
+			},
+			priceID: 2,
+			want:    []entity.ProductPrice{priceSpan(now, 1, regular, 10000, -h, openEnded)},
+		},
+		{
+			name: "scheduled sale",
+			prices: []entity.ProductPrice{
+				priceSpan(now, 1, regular, 10000, -h, openEnded),
+				priceSpan(now, 2, sale, 5000, h, 2*h),
+			},
+			priceID: 2,
+			want:    []entity.ProductPrice{priceSpan(now, 1, regular, 10000, -h, openEnded)},
+		},
+		{
+			name:    "regular price in effect",
+			prices:  []entity.ProductPrice{priceSpan(now, 1, regular, 10000, -h, openEnded)},
+			priceID: 1,
+			wantErr: ErrInvalidProductPrice,
+		},
+		{
+			name: "expired price",
+			prices: []entity.ProductPrice{
+				priceSpan(now, 1, regular, 10000, -2*h, -h),
+				priceSpan(now, 2, regular, 20000, -h, openEnded),
+			},
+			priceID: 1,
+			wantErr: ErrInvalidProductPrice,
+		},
+		{
+			name:    "price of another product",
+			prices:  []entity.ProductPrice{priceSpan(now, 1, regular, 10000, -h, openEnded)},
+			priceID: 7,
+			wantErr: ErrProductPriceNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, priceRepo := newPriceTestService(tt.prices)
+
+			err := e.CancelProductPrice(context.Background(), 1, tt.priceID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, sortedPrices(priceRepo.prices))
+		})
+	}
+}
+
+func TestCancelProductPriceEndsRunningSale(t *testing.T) {
+	now := priceNow()
+	e, priceRepo := newPriceTestService([]entity.ProductPrice{
+		priceSpan(now, 1, entity.ProductPriceRegular, 10000, -time.Hour, openEnded),
+		priceSpan(now, 2, entity.ProductPriceSale, 5000, -time.Hour, time.Hour),
+	})
+
+	err := e.CancelProductPrice(context.Background(), 1, 2)
+	require.NoError(t, err)
+
+	prices := sortedPrices(priceRepo.prices)
+	require.Len(t, prices, 2)
+	assert.Equal(t, sql.NullTime{}, prices[0].EffectiveTo)
+	require.True(t, prices[1].EffectiveTo.Valid)
+	assert.False(t, prices[1].EffectiveTo.Time.Before(now))
+	assert.False(t, prices[1].EffectiveTo.Time.After(priceNow()))
+}
+
+// roundTripProductRepo reads product as the repository does, with the regular price in effect as its price.
+type roundTripProductRepo struct {
+	repository.EcommerceProvider
+	product entity.Product
+	prices  *memoryPriceRepo
+}
+
+func (r *roundTripProductRepo) LockProduct(ctx context.Context, id int64) error {
+	return nil
+}
+
+func (r *roundTripProductRepo) GetProductByID(ctx context.Context, id int64) (entity.Product, error) {
+	product := r.product
+	now := priceNow()
+	for _, v := range sortedPrices(r.prices.prices) {
+		if v.Kind == entity.ProductPriceRegular && productPriceStatus(v, now) == ProductPriceStatusActive {
+			product.Price.Amount = v.Price
+		}
+	}
+	product.EffectivePrice = product.Price
+
+	return product, nil
+}
+
+func (r *roundTripProductRepo) UpdateProduct(ctx context.Context, payload entity.Product) error {
+	r.product = payload
+
+	return nil
+}
+
+func (r *roundTripProductRepo) GetProductImagesByProductID(ctx context.Context, id int64) ([]entity.ProductImage, error) {
+	return nil, nil
+}
+
+func (r *roundTripProductRepo) DeleteProductImagesByID(ctx context.Context, productID int64) error {
+	return nil
+}
+
+func (r *roundTripProductRepo) GetProductReviewByProductID(ctx context.Context, id int64) ([]entity.ProductReview, error) {
+	return nil, nil
+}
+
+type noVariantRepo struct {
+	repository.VariantProvider
+}
+
+func (noVariantRepo) GetProductOptionsByProductID(ctx context.Context, productID int64) ([]entity.ProductOption, error) {
+	return nil, nil
+}
+
+func (noVariantRepo) GetProductOptionValuesByProductID(ctx context.Context, productID int64) ([]entity.ProductOptionValue, error) {
+	return nil, nil
+}
+
+func (noVariantRepo) GetProductVariantsByProductID(ctx context.Context, productID int64) ([]entity.ProductVariant, error) {
+	return nil, nil
+}
+
+func (noVariantRepo) GetProductVariantOptionValuesByProductID(ctx context.Context, productID int64) ([]entity.ProductVariantOptionValue, error) {
+	return nil, nil
+}
+
+type noAttributeRepo struct {
+	repository.AttributeProvider
+}
+
+func (noAttributeRepo) GetProductAttributesByProductID(ctx context.Context, productID int64) ([]entity.ProductAttribute, error) {
+	return nil, nil
+}
+
+func (noAttributeRepo) DeleteProductAttributesByProductID(ctx context.Context, productID int64) error {
+	return nil
+}
+
+type noImageRepo struct {
+	repository.ImageProvider
+}
+
+func (noImageRepo) GetImageVariantsByImageURLs(ctx context.Context, urls []string) ([]entity.ImageVariantDetail, error) {
+	return nil, nil
+}
+
+type noCategoryRepo struct {
+	repository.CategoryProvider
+}
+
+func (noCategoryRepo) GetCategoryBySlug(ctx context.Context, slug string) (entity.Category, error) {
+	return entity.Category{}, sql.ErrNoRows
+}
+
+func TestUpdateProductKeepsScheduledRegularPrice(t *testing.T) {
+	now := priceNow()
+	h := time.Hour
+	regular := entity.ProductPriceRegular
+
+	// the product was last updated at 10000, the regular price of 12000 scheduled since took effect an hour ago
+	e, priceRepo := newPriceTestService([]entity.ProductPrice{
+		priceSpan(now, 1, regular, 10000, -48*h, -h),
+		priceSpan(now, 2, regular, 12000, -h, openEnded),
+	})
+	e.ecommerceRepo = &roundTripProductRepo{
+		product: entity.Product{ID: 1, UserID: 7, Sku: "SKU-1", Title: "Kettle", Category: "kitchen",
+			Price: money.New(10000, "IDR"), Stock: 3},
+		prices: priceRepo,
+	}
+	e.variantRepo = noVariantRepo{}
+	e.attributeRepo = noAttributeRepo{}
+	e.imageRepo = noImageRepo{}
+	e.categoryRepo = noCategoryRepo{}
+	want := sortedPrices(priceRepo.prices)
+
+	detail, err := e.GetProductByID(context.Background(), 1)
+	require.NoError(t, err)
+	got := detail.Data.Product
+	require.Equal(t, money.New(12000, "IDR"), got.Price)
+
+	err = e.UpdateProduct(context.Background(), 1, request.UpsertProduct{
+		UserID:      got.UserID,
+		Sku:         got.Sku,
+		Title:       got.Title,
+		Description: got.Description,
+		Category:    got.Category,
+		Etalase:     got.Etalase,
+		Weight:      got.Weight,
+		Price:       got.Price,
+		Stock:       got.Stock,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, want, sortedPrices(priceRepo.prices))
+
+	detail, err = e.GetProductByID(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, money.New(12000, "IDR"), detail.Data.Product.Price)
+}
@@ -0,0 +1,542 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ecommerce/model/entity"
+	"ecommerce/model/money"
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"ecommerce/repository"
+	"errors"
+	"fmt"
+	"math/bits"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// promotionCode matches the codes once they are upper cased.
+var promotionCode = regexp.MustCompile(`^[A-Z0-9_-]*$`)
+
+type promotionService struct {
+	promotionRepo repository.PromotionProvider
+	categoryRepo  repository.CategoryProvider
+	cartRepo      repository.CartProvider
+	currency      string
+}
+
+type PromotionConfig struct {
+	PromotionRepo repository.PromotionProvider
+	CategoryRepo  repository.CategoryProvider
+	CartRepo      repository.CartProvider
+	// Currency is the currency of the promotions created without one
+	Currency string
+}
+
+func NewPromotionService(config PromotionConfig) promotionService {
+	promotionProvider := promotionService{
+		promotionRepo: config.PromotionRepo,
+		categoryRepo:  config.CategoryRepo,
+		cartRepo:      config.CartRepo,
+		currency:      config.Currency,
+	}
+
+	if promotionProvider.currency == "" {
+		promotionProvider.currency = defaultCurrency
+	}
+
+	return promotionProvider
+}
+
+func (p *promotionService) GetPromotionList(ctx context.Context, request request.Pagination, path string) (response.GetPromotionListResponse, error) {
+	var resp response.GetPromotionListResponse
+
+	total, err := p.promotionRepo.CountPromotions(ctx)
+	if err != nil {
+		return resp, err
+	}
+
+	meta, limit, offset := paginate(request, total, path)
+	promotions, err := p.promotionRepo.GetPromotions(ctx, limit, offset)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Data = make([]response.Promotion, 0, len(promotions))
+	for _, v := range promotions {
+		resp.Data = append(resp.Data, toPromotionResponse(v))
+	}
+	resp.Pagination = meta
+
+	return resp, nil
+}
+
+func (p *promotionService) GetPromotionByID(ctx context.Context, id int64) (response.GetPromotionDetailResponse, error) {
+	var resp response.GetPromotionDetailResponse
+
+	promotion, err := p.promotionRepo.GetPromotionByID(ctx, id)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Data = toPromotionResponse(promotion)
+
+	return resp, nil
+}
+
+func (p *promotionService) CreatePromotion(ctx context.Context, request request.UpsertPromotion) (err error) {
+	promotion, err := p.buildPromotion(ctx, 0, request)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.promotionRepo.CreatePromotion(ctx, promotion)
+	return err
+}
+
+// UpdatePromotion replaces the campaign of a promotion, the uses counted so far are kept.
+func (p *promotionService) UpdatePromotion(ctx context.Context, id int64, request request.UpsertPromotion) (err error) {
+	_, err = p.promotionRepo.GetPromotionByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	promotion, err := p.buildPromotion(ctx, id, request)
+	if err != nil {
+		return err
+	}
+
+	promotion.ID = id
+	return p.promotionRepo.UpdatePromotion(ctx, promotion)
+}
+
+// DeletePromotion deletes a promotion which has never been used, a used promotion can only be deactivated so the
+// discounts of its orders can still be traced to it.
+func (p *promotionService) DeletePromotion(ctx context.Context, id int64) (err error) {
+	promotion, err := p.promotionRepo.GetPromotionByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if promotion.UsageCount > 0 {
+		return fmt.Errorf("%w: promotion %d has been used %d times, deactivate it instead", ErrPromotionInUse, id, promotion.UsageCount)
+	}
+
+	return p.promotionRepo.DeletePromotion(ctx, id)
+}
+
+// EvaluateCartPromotions returns the discount the cart of the user gets from the automatic promotions and the
+// entered codes, nothing is counted as used until the order is created.
+func (p *promotionService) EvaluateCartPromotions(ctx context.Context, userID int64, request request.ApplyPromotions) (response.GetCartPromotionsResponse, error) {
+	var resp response.GetCartPromotionsResponse
+
+	cart, err := p.cartRepo.GetCartByUserID(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return resp, ErrEmptyCart
+	}
+	if err != nil {
+		return resp, err
+	}
+
+	cartItems, err := p.cartRepo.GetCartItemsByCartID(ctx, cart.ID)
+	if err != nil {
+		return resp, err
+	}
+
+	if len(cartItems) == 0 {
+		return resp, ErrEmptyCart
+	}
+
+	resp.Data, err = evaluatePromotions(ctx, p.promotionRepo, p.categoryRepo, userID, cartItems, request.Codes, time.Now())
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// buildPromotion validates the request of promotion id, 0 for a new one.
+func (p *promotionService) buildPromotion(ctx context.Context, id int64, request request.UpsertPromotion) (entity.Promotion, error) {
+	code := strings.ToUpper(strings.TrimSpace(request.Code))
+	startsAt := request.StartsAt
+	if startsAt.IsZero() {
+		startsAt = time.Now()
+	}
+
+	switch {
+	case !promotionCode.MatchString(code):
+		return entity.Promotion{}, fmt.Errorf("%w: code should only hold letters, digits, _ and -", ErrInvalidPromotion)
+	case strings.TrimSpace(request.Name) == "":
+		return entity.Promotion{}, fmt.Errorf("%w: name is required", ErrInvalidPromotion)
+	case request.Type != entity.PromotionPercentage && request.Type != entity.PromotionFixed:
+		return entity.Promotion{}, fmt.Errorf("%w: type should be %s or %s", ErrInvalidPromotion, entity.PromotionPercentage, entity.PromotionFixed)
+	case request.Type == entity.PromotionPercentage && (request.Value <= 0 || request.Value > 100):
+		return entity.Promotion{}, fmt.Errorf("%w: value of a percentage promotion should be between 1 and 100", ErrInvalidPromotion)
+	case request.Type == entity.PromotionFixed && request.Value <= 0:
+		return entity.Promotion{}, fmt.Errorf("%w: value of a fixed promotion should be greater than 0", ErrInvalidPromotion)
+	case request.MaxDiscount < 0, request.MinSpend < 0, request.UsageLimit < 0, request.PerUserLimit < 0:
+		return entity.Promotion{}, fmt.Errorf("%w: max_discount, min_spend and limits should not be negative", ErrInvalidPromotion)
+	case request.EndsAt != nil && !request.EndsAt.After(startsAt):
+		return entity.Promotion{}, fmt.Errorf("%w: ends_at should be after starts_at", ErrInvalidPromotion)
+	}
+
+	scope := request.Scope
+	if scope == "" {
+		scope = entity.PromotionScopeAll
+	}
+
+	switch scope {
+	case entity.PromotionScopeAll:
+		if len(request.ScopeIDs) > 0 {
+			return entity.Promotion{}, fmt.Errorf("%w: scope_ids should be empty when the scope is %s", ErrInvalidPromotion, scope)
+		}
+	case entity.PromotionScopeSeller, entity.PromotionScopeCategory, entity.PromotionScopeProduct:
+		if len(request.ScopeIDs) == 0 {
+			return entity.Promotion{}, fmt.Errorf("%w: scope_ids is required when the scope is %s", ErrInvalidPromotion, scope)
+		}
+	default:
+		return entity.Promotion{}, fmt.Errorf("%w: scope should be %s, %s, %s or %s", ErrInvalidPromotion, entity.PromotionScopeAll,
+			entity.PromotionScopeSeller, entity.PromotionScopeCategory, entity.PromotionScopeProduct)
+	}
+
+	currency, err := resolveCurrency(request.Currency, p.currency)
+	if err != nil {
+		return entity.Promotion{}, err
+	}
+
+	if code != "" {
+		promotions, err := p.promotionRepo.GetApplicablePromotions(ctx, []string{code}, startsAt)
+		if err != nil {
+			return entity.Promotion{}, err
+		}
+
+		for _, v := range promotions {
+			if v.ID != id && strings.EqualFold(v.Code, code) {
+				return entity.Promotion{}, fmt.Errorf("%w: code %s is already used by promotion %d", ErrInvalidPromotion, code, v.ID)
+			}
+		}
+	}
+
+	promotion := entity.Promotion{
+		Code:         code,
+		Name:         strings.TrimSpace(request.Name),
+		Type:         request.Type,
+		Value:        request.Value,
+		MaxDiscount:  request.MaxDiscount,
+		MinSpend:     request.MinSpend,
+		Currency:     currency,
+		UsageLimit:   request.UsageLimit,
+		PerUserLimit: request.PerUserLimit,
+		Scope:        scope,
+		ScopeIDs:     pq.Int64Array(request.ScopeIDs),
+		StartsAt:     startsAt,
+		IsActive:     request.IsActive == nil || *request.IsActive,
+	}
+	if promotion.ScopeIDs == nil {
+		promotion.ScopeIDs = pq.Int64Array{}
+	}
+	if request.EndsAt != nil {
+		promotion.EndsAt.Valid = true
+		promotion.EndsAt.Time = *request.EndsAt
+	}
+
+	return promotion, nil
+}
+
+func toPromotionResponse(promotion entity.Promotion) response.Promotion {
+	resp := response.Promotion{
+		ID:           promotion.ID,
+		Code:         promotion.Code,
+		Name:         promotion.Name,
+		Type:         promotion.Type,
+		Value:        promotion.Value,
+		MaxDiscount:  promotion.MaxDiscount,
+		MinSpend:     promotion.MinSpend,
+		Currency:     promotion.Currency,
+		UsageLimit:   promotion.UsageLimit,
+		PerUserLimit: promotion.PerUserLimit,
+		UsageCount:   promotion.UsageCount,
+		Scope:        promotion.Scope,
+		ScopeIDs:     []int64(promotion.ScopeIDs),
+		StartsAt:     promotion.StartsAt,
+		IsActive:     promotion.IsActive,
+		CreatedAt:    promotion.CreatedAt,
+		UpdatedAt:    promotion.UpdatedAt,
+	}
+	if resp.ScopeIDs == nil {
+		resp.ScopeIDs = []int64{}
+	}
+
+	if promotion.EndsAt.Valid {
+		endsAt := promotion.EndsAt.Time
+		resp.EndsAt = &endsAt
+	}
+
+	return resp
+}
+
+// evaluatePromotions applies the automatic promotions running at now and then the codes, in the order they are
+// entered, to the cart items of the user. Each promotion discounts what the promotions before it left of the items it
+// applies to, while its min spend is checked on their full price. Codes which can't be applied are listed as rejected
+// with the reason why; automatic promotions which don't apply are left out.
+func evaluatePromotions(ctx context.Context, promotionRepo repository.PromotionProvider, categoryRepo repository.CategoryProvider,
+	userID int64, cartItems []entity.CartItemDetail, codes []string, now time.Time) (response.CartPromotions, error) {
+	summary := summarizeCart(cartItems)
+	resp := response.CartPromotions{
+		Currency:  summary.Currency,
+		Subtotal:  summary.Subtotal,
+		Discounts: []response.PromotionDiscount{},
+		Rejected:  []response.RejectedPromotion{},
+	}
+
+	codes = normalizePromotionCodes(codes)
+	promotions, err := promotionRepo.GetApplicablePromotions(ctx, codes, now)
+	if err != nil {
+		return resp, err
+	}
+
+	var automatic []entity.Promotion
+	byCode := map[string]entity.Promotion{}
+	for _, v := range promotions {
+		if v.Code == "" {
+			automatic = append(automatic, v)
+			continue
+		}
+
+		byCode[strings.ToUpper(v.Code)] = v
+	}
+
+	ordered := automatic
+	for _, code := range codes {
+		promotion, ok := byCode[code]
+		if !ok {
+			resp.Rejected = append(resp.Rejected, response.RejectedPromotion{Code: code, Reason: "code is not found"})
+			continue
+		}
+
+		ordered = append(ordered, promotion)
+	}
+
+	remaining := make([]int64, len(cartItems))
+	for i, v := range cartItems {
+		remaining[i] = v.Price * v.Quantity
+	}
+
+	categories := map[int64][]int64{}
+	for _, promotion := range ordered {
+		discount, reason, err := applyPromotion(ctx, promotionRepo, categoryRepo, categories, promotion, userID, cartItems,
+			remaining, resp.Currency, now)
+		if err != nil {
+			return resp, err
+		}
+
+		if reason != "" {
+			if promotion.Code != "" {
+				resp.Rejected = append(resp.Rejected, response.RejectedPromotion{Code: promotion.Code, Reason: reason})
+			}
+			continue
+		}
+
+		resp.Discounts = append(resp.Discounts, discount)
+		resp.TotalDiscount += discount.Discount
+	}
+
+	resp.Total = resp.Subtotal - resp.TotalDiscount
+
+	return resp, nil
+}
+
+// applyPromotion computes the discount of promotion on the remaining amounts of the cart items and takes it off them.
+// It returns the reason why when the promotion can't be applied.
+func applyPromotion(ctx context.Context, promotionRepo repository.PromotionProvider, categoryRepo repository.CategoryProvider,
+	categories map[int64][]int64, promotion entity.Promotion, userID int64, cartItems []entity.CartItemDetail, remaining []int64,
+	currency string, now time.Time) (response.PromotionDiscount, string, error) {
+	switch {
+	case !promotion.IsActive:
+		return response.PromotionDiscount{}, "promotion is not active", nil
+	case promotion.StartsAt.After(now):
+		return response.PromotionDiscount{}, fmt.Sprintf("promotion starts at %s", promotion.StartsAt.Format(time.RFC3339)), nil
+	case promotion.EndsAt.Valid && !promotion.EndsAt.Time.After(now):
+		return response.PromotionDiscount{}, "promotion has ended", nil
+	case promotion.Currency != currency:
+		return response.PromotionDiscount{}, fmt.Sprintf("promotion only applies to carts in %s", promotion.Currency), nil
+	case promotion.UsageLimit > 0 && promotion.UsageCount >= promotion.UsageLimit:
+		return response.PromotionDiscount{}, "promotion has reached its usage limit", nil
+	}
+
+	if promotion.PerUserLimit > 0 {
+		used, err := promotionRepo.CountPromotionUsagesByUserID(ctx, promotion.ID, userID)
+		if err != nil {
+			return response.PromotionDiscount{}, "", err
+		}
+
+		if used >= promotion.PerUserLimit {
+			return response.PromotionDiscount{}, "promotion has reached its usage limit for the user", nil
+		}
+	}
+
+	eligible, err := eligibleCartItems(ctx, categoryRepo, categories, promotion, cartItems)
+	if err != nil {
+		return response.PromotionDiscount{}, "", err
+	}
+
+	var subtotal, base int64
+	for _, i := range eligible {
+		subtotal += cartItems[i].Price * cartItems[i].Quantity
+		base += remaining[i]
+	}
+
+	switch {
+	case len(eligible) == 0:
+		return response.PromotionDiscount{}, "no item of the cart is eligible for the promotion", nil
+	case subtotal < promotion.MinSpend:
+		return response.PromotionDiscount{}, fmt.Sprintf("minimum spend of %s is not reached",
+			money.New(promotion.MinSpend, promotion.Currency)), nil
+	}
+
+	amount := promotion.Value
+	if promotion.Type == entity.PromotionPercentage {
+		amount = base * promotion.Value / 100
+		if promotion.MaxDiscount > 0 && amount > promotion.MaxDiscount {
+			amount = promotion.MaxDiscount
+		}
+	}
+	if amount > base {
+		amount = base
+	}
+
+	if amount <= 0 {
+		return response.PromotionDiscount{}, "the eligible items are already fully discounted", nil
+	}
+
+	discount := response.PromotionDiscount{
+		PromotionID: promotion.ID,
+		Code:        promotion.Code,
+		Name:        promotion.Name,
+		Discount:    amount,
+		Items:       []response.ItemDiscount{},
+	}
+
+	for j, share := range allocateDiscount(amount, eligible, remaining) {
+		if share == 0 {
+			continue
+		}
+
+		i := eligible[j]
+		remaining[i] -= share
+		discount.Items = append(discount.Items, response.ItemDiscount{ProductID: cartItems[i].ProductID, VariantID: cartItems[i].VariantID, Discount: share})
+	}
+
+	return discount, "", nil
+}
+
+// eligibleCartItems returns the indexes of the cart items in the scope of promotion. The descendants of the
+// categories are cached in categories across promotions.
+func eligibleCartItems(ctx context.Context, categoryRepo repository.CategoryProvider, categories map[int64][]int64,
+	promotion entity.Promotion, cartItems []entity.CartItemDetail) ([]int, error) {
+	ids := map[int64]bool{}
+	for _, v := range promotion.ScopeIDs {
+		if promotion.Scope != entity.PromotionScopeCategory {
+			ids[v] = true
+			continue
+		}
+
+		descendants, ok := categories[v]
+		if !ok {
+			var err error
+			descendants, err = categoryRepo.GetCategoryDescendantIDs(ctx, v)
+			if err != nil {
+				return nil, err
+			}
+
+			categories[v] = descendants
+		}
+
+		for _, id := range descendants {
+			ids[id] = true
+		}
+	}
+
+	var eligible []int
+	for i, v := range cartItems {
+		var inScope bool
+		switch promotion.Scope {
+		case entity.PromotionScopeAll:
+			inScope = true
+		case entity.PromotionScopeSeller:
+			inScope = ids[v.SellerID]
+		case entity.PromotionScopeCategory:
+			inScope = ids[v.CategoryID]
+		case entity.PromotionScopeProduct:
+			inScope = ids[v.ProductID]
+		}
+
+		if inScope {
+			eligible = append(eligible, i)
+		}
+	}
+
+	return eligible, nil
+}
+
+// allocateDiscount splits amount over the eligible items in proportion to their remaining amount. The cents lost
+// by rounding down go to the items with the largest remainders so the shares always add up to amount.
+func allocateDiscount(amount int64, eligible []int, remaining []int64) []int64 {
+	var base int64
+	for _, i := range eligible {
+		base += remaining[i]
+	}
+
+	shares := make([]int64, len(eligible))
+	remainders := make([]int64, len(eligible))
+	left := amount
+	for j, i := range eligible {
+		// amount is at most base so the quotient fits even when the product overflows int64
+		hi, lo := bits.Mul64(uint64(amount), uint64(remaining[i]))
+		share, remainder := bits.Div64(hi, lo, uint64(base))
+		shares[j], remainders[j] = int64(share), int64(remainder)
+		left -= shares[j]
+	}
+
+	order := make([]int, len(eligible))
+	for j := range order {
+		order[j] = j
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]] > remainders[order[b]]
+	})
+
+	for _, j := range order {
+		if left == 0 {
+			break
+		}
+
+		if shares[j] < remaining[eligible[j]] {
+			shares[j]++
+			left--
+		}
+	}
+
+	return shares
+}
+
+// normalizePromotionCodes upper cases the codes and drops the empty and repeated ones.
+func normalizePromotionCodes(codes []string) []string {
+	normalized := []string{}
+	seen := map[string]bool{}
+	for _, v := range codes {
+		code := strings.ToUpper(strings.TrimSpace(v))
+		if code == "" || seen[code] {
+			continue
+		}
+
+		seen[code] = true
+		normalized = append(normalized, code)
+	}
+
+	return normalized
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllocateDiscount(t *testing.T) {
+	tests := []struct {
+		name      string
+		amount    int64
+		eligible  []int
+		remaining []int64
+		want      []int64
+	}{
+		{
+			name:      "even split",
+			amount:    100,
+			eligible:  []int{0, 1},
+			remaining: []int64{5000, 5000},
+			want:      []int64{50, 50},
+		},
+		{
+			name:      "in proportion to the remaining amounts",
+			amount:    1000,
+			eligible:  []int{0, 1},
+			remaining: []int64{30000, 10000},
+			want:      []int64{750, 250},
+		},
+		{
+			name:      "the cent lost by rounding goes to the first of equal remainders",
+			amount:    100,
+			eligible:  []int{0, 1, 2},
+			remaining: []int64{1000, 1000, 1000},
+			want:      []int64{34, 33, 33},
+		},
+		{
+			name:      "the cents lost by rounding go to the largest remainders",
+			amount:    10,
+			eligible:  []int{0, 1, 2},
+			remaining: []int64{100, 350, 250},
+			want:      []int64{1, 5, 4},
+		},
+		{
+			name:      "only the eligible items",
+			amount:    100,
+			eligible:  []int{0, 2},
+			remaining: []int64{1000, 9990, 3000},
+			want:      []int64{25, 75},
+		},
+		{
+			name:      "the whole remaining amount",
+			amount:    7,
+			eligible:  []int{0, 1},
+			remaining: []int64{3, 4},
+			want:      []int64{3, 4},
+		},
+		{
+			name:      "amounts whose product overflows",
+			amount:    math.MaxInt64 / 2,
+			eligible:  []int{0, 1, 2},
+			remaining: []int64{math.MaxInt64 / 4, math.MaxInt64 / 4, math.MaxInt64 / 4},
+			want:      []int64{math.MaxInt64 / 6, math.MaxInt64 / 6, math.MaxInt64 / 6},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shares := allocateDiscount(tt.amount, tt.eligible, tt.remaining)
+			assert.Equal(t, tt.want, shares)
+
+			var total int64
+			for j, i := range tt.eligible {
+				assert.LessOrEqual(t, shares[j], tt.remaining[i])
+				total += shares[j]
+			}
+			assert.Equal(t, tt.amount, total)
+		})
+	}
+}
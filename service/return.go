@@ -0,0 +1,369 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ecommerce/model/entity"
+	"ecommerce/model/money"
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// maxReturnPhotos is the number of photos a return request may carry.
+const maxReturnPhotos = 5
+
+// CreateReturnRequest asks the seller to take back part of an order line of a delivered order.
+func (o *orderService) CreateReturnRequest(ctx context.Context, orderID int64, request request.CreateReturnRequest) (response.GetReturnRequestResponse, error) {
+	var resp response.GetReturnRequestResponse
+
+	reason := strings.TrimSpace(request.Reason)
+	photoUrls := make([]string, 0, len(request.PhotoUrls))
+	for _, v := range request.PhotoUrls {
+		if v = strings.TrimSpace(v); v != "" {
+			photoUrls = append(photoUrls, v)
+		}
+	}
+
+	switch {
+	case request.Quantity <= 0:
+		return resp, ErrInvalidQuantity
+	case reason == "":
+		return resp, fmt.Errorf("%w: reason is required", ErrInvalidReturn)
+	case len(photoUrls) > maxReturnPhotos:
+		return resp, fmt.Errorf("%w: at most %d photos can be attached", ErrInvalidReturn, maxReturnPhotos)
+	}
+
+	var returnRequest entity.ReturnRequest
+	var currency string
+	err := o.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		// the order row is locked so concurrent requests can't return more than was bought
+		order, err := o.orderRepo.GetOrderByIDForUpdate(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		if order.UserID != request.UserID {
+			return fmt.Errorf("%w: order %d is not of user %d", ErrInvalidReturn, orderID, request.UserID)
+		}
+
+		if order.Status != entity.OrderStatusDelivered {
+			return fmt.Errorf("%w: order %d is %s, only delivered orders can be returned", ErrInvalidReturn, orderID, order.Status)
+		}
+
+		orderItem, err := o.orderItem(ctx, orderID, request.OrderItemID)
+		if err != nil {
+			return err
+		}
+
+		returned, err := o.returnRequestRepo.SumReturnedQuantity(ctx, orderItem.ID)
+		if err != nil {
+			return err
+		}
+
+		if returned+request.Quantity > orderItem.Quantity {
+			return fmt.Errorf("%w: %d of %s can still be returned", ErrInvalidReturn, orderItem.Quantity-returned, orderItem.Sku)
+		}
+
+		returnRequest = entity.ReturnRequest{
+			OrderID:     orderID,
+			OrderItemID: orderItem.ID,
+			ProductID:   orderItem.ProductID,
+			VariantID:   orderItem.VariantID,
+			SellerID:    orderItem.SellerID,
+			UserID:      order.UserID,
+			Quantity:    request.Quantity,
+			Reason:      reason,
+			PhotoUrls:   photoUrls,
+			Status:      entity.ReturnStatusRequested,
+		}
+		returnRequest.ID, err = o.returnRequestRepo.CreateReturnRequest(ctx, returnRequest)
+		currency = order.Currency
+		return err
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Data = toReturnRequestResponse(returnRequest, currency)
+
+	return resp, nil
+}
+
+func (o *orderService) GetOrderReturnRequests(ctx context.Context, orderID int64) (response.GetReturnRequestListResponse, error) {
+	var resp response.GetReturnRequestListResponse
+
+	order, err := o.orderRepo.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return resp, err
+	}
+
+	returnRequests, err := o.returnRequestRepo.GetReturnRequestsByOrderID(ctx, orderID)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Data = make([]response.ReturnRequest, 0, len(returnRequests))
+	for _, v := range returnRequests {
+		resp.Data = append(resp.Data, toReturnRequestResponse(v, order.Currency))
+	}
+
+	return resp, nil
+}
+
+// GetSellerReturnRequests returns the return requests for the products of a seller, the latest first.
+func (o *orderService) GetSellerReturnRequests(ctx context.Context, sellerID int64, request request.Pagination, path string) (response.GetSellerReturnRequestListResponse, error) {
+	var resp response.GetSellerReturnRequestListResponse
+
+	total, err := o.returnRequestRepo.CountReturnRequestsBySellerID(ctx, sellerID)
+	if err != nil {
+		return resp, err
+	}
+
+	meta, limit, offset := paginate(request, total, path)
+	returnRequests, err := o.returnRequestRepo.GetReturnRequestsBySellerID(ctx, sellerID, limit, offset)
+	if err != nil {
+		return resp, err
+	}
+
+	currencies := map[int64]string{}
+	resp.Data = make([]response.ReturnRequest, 0, len(returnRequests))
+	for _, v := range returnRequests {
+		currency, ok := currencies[v.OrderID]
+		if !ok {
+			order, err := o.orderRepo.GetOrderByID(ctx, v.OrderID)
+			if err != nil {
+				return resp, err
+			}
+
+			currency = order.Currency
+			currencies[v.OrderID] = currency
+		}
+
+		resp.Data = append(resp.Data, toReturnRequestResponse(v, currency))
+	}
+	resp.Pagination = meta
+
+	return resp, nil
+}
+
+// ApproveReturnRequest accepts a return and sets what will be refunded once the goods are received. The refund
+// defaults to what was paid for the returned units and can't be more than that.
+func (o *orderService) ApproveReturnRequest(ctx context.Context, sellerID int64, id int64, request request.ReviewReturnRequest) (err error) {
+	return o.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		returnRequest, err := o.sellerReturnRequestForUpdate(ctx, sellerID, id, entity.ReturnStatusRequested)
+		if err != nil {
+			return err
+		}
+
+		// the order row is locked so returns of the order approved at the same time share what was paid in turn
+		_, err = o.orderRepo.GetOrderByIDForUpdate(ctx, returnRequest.OrderID)
+		if err != nil {
+			return err
+		}
+
+		refundable, err := o.refundableAmount(ctx, returnRequest)
+		if err != nil {
+			return err
+		}
+
+		refundAmount := refundable
+		if request.RefundAmount != nil {
+			refundAmount = *request.RefundAmount
+		}
+
+		if refundAmount < 0 || refundAmount > refundable {
+			return fmt.Errorf("%w: refund_amount should be between 0 and %d", ErrInvalidReturn, refundable)
+		}
+
+		returnRequest.Status = entity.ReturnStatusApproved
+		returnRequest.SellerNote = strings.TrimSpace(request.Note)
+		returnRequest.RefundAmount = refundAmount
+		return o.returnRequestRepo.UpdateReturnRequest(ctx, returnRequest)
+	})
+}
+
+func (o *orderService) RejectReturnRequest(ctx context.Context, sellerID int64, id int64, request request.ReviewReturnRequest) (err error) {
+	note := strings.TrimSpace(request.Note)
+	if note == "" {
+		return fmt.Errorf("%w: note is required to reject a return", ErrInvalidReturn)
+	}
+
+	return o.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		returnRequest, err := o.sellerReturnRequestForUpdate(ctx, sellerID, id, entity.ReturnStatusRequested)
+		if err != nil {
+			return err
+		}
+
+		returnRequest.Status = entity.ReturnStatusRejected
+		returnRequest.SellerNote = note
+		return o.returnRequestRepo.UpdateReturnRequest(ctx, returnRequest)
+	})
+}
+
+// ReceiveReturnRequest records that the seller got the goods of an approved return back. The goods are restocked and
+// the approved amount is refunded from the captured payment of the order, the order is refunded once its whole
+// payment has been given back. The refund is asked from the gateway once the return is committed, receiving a
+// refunded return again retries a refund the gateway failed.
+func (o *orderService) ReceiveReturnRequest(ctx context.Context, sellerID int64, id int64) (err error) {
+	var orderID int64
+	err = o.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		returnRequest, err := o.sellerReturnRequestForUpdate(ctx, sellerID, id, entity.ReturnStatusApproved, entity.ReturnStatusRefunded)
+		if err != nil {
+			return err
+		}
+
+		orderID = returnRequest.OrderID
+		if returnRequest.Status == entity.ReturnStatusRefunded {
+			return nil
+		}
+
+		_, err = o.orderRepo.GetOrderByIDForUpdate(ctx, returnRequest.OrderID)
+		if err != nil {
+			return err
+		}
+
+		err = restock(ctx, o.inventoryRepo, returnRequest.ProductID, returnRequest.VariantID, returnRequest.Quantity,
+			entity.InventoryReasonReturn, returnReference(returnRequest.ID))
+		if err != nil {
+			return err
+		}
+
+		err = o.refundReturn(ctx, returnRequest)
+		if err != nil {
+			return err
+		}
+
+		returnRequest.Status = entity.ReturnStatusRefunded
+		return o.returnRequestRepo.UpdateReturnRequest(ctx, returnRequest)
+	})
+	if err != nil {
+		return err
+	}
+
+	return o.settleRefunds(ctx, orderID)
+}
+
+// refundReturn records the refund of a return from the captured payment of the order.
+func (o *orderService) refundReturn(ctx context.Context, returnRequest entity.ReturnRequest) error {
+	if returnRequest.RefundAmount == 0 {
+		return nil
+	}
+
+	payment, err := o.capturedPaymentForUpdate(ctx, returnRequest.OrderID)
+	if err != nil {
+		return err
+	}
+
+	payment, err = o.refundPayment(ctx, payment, entity.Refund{
+		OrderID:         returnRequest.OrderID,
+		ReturnRequestID: returnRequest.ID,
+		Amount:          returnRequest.RefundAmount,
+	})
+	if err != nil {
+		return err
+	}
+
+	if payment.Status != entity.PaymentStatusRefunded {
+		return nil
+	}
+
+	_, err = o.transitionOrder(ctx, returnRequest.OrderID, entity.OrderStatusRefunded)
+	return err
+}
+
+// refundableAmount returns what was paid for the units of a return. What was paid for an order line is shared among
+// its units in the order they are approved, so the refunds of all of them add up to exactly what was paid.
+func (o *orderService) refundableAmount(ctx context.Context, returnRequest entity.ReturnRequest) (int64, error) {
+	orderItem, err := o.orderItem(ctx, returnRequest.OrderID, returnRequest.OrderItemID)
+	if err != nil {
+		return 0, err
+	}
+
+	returnRequests, err := o.returnRequestRepo.GetReturnRequestsByOrderID(ctx, returnRequest.OrderID)
+	if err != nil {
+		return 0, err
+	}
+
+	var approved int64
+	for _, v := range returnRequests {
+		if v.OrderItemID != orderItem.ID || v.ID == returnRequest.ID {
+			continue
+		}
+
+		if v.Status == entity.ReturnStatusApproved || v.Status == entity.ReturnStatusRefunded {
+			approved += v.Quantity
+		}
+	}
+
+	paid := orderItem.Price*orderItem.Quantity - orderItem.Discount
+	return paidShare(paid, approved+returnRequest.Quantity, orderItem.Quantity) - paidShare(paid, approved, orderItem.Quantity), nil
+}
+
+// paidShare returns what was paid for the first units of the quantity of an order line, rounded down.
+func paidShare(paid int64, units int64, quantity int64) int64 {
+	hi, lo := bits.Mul64(uint64(paid), uint64(units))
+	share, _ := bits.Div64(hi, lo, uint64(quantity))
+	return int64(share)
+}
+
+func (o *orderService) orderItem(ctx context.Context, orderID int64, orderItemID int64) (entity.OrderItem, error) {
+	orderItems, err := o.orderRepo.GetOrderItemsByOrderID(ctx, orderID)
+	if err != nil {
+		return entity.OrderItem{}, err
+	}
+
+	for _, v := range orderItems {
+		if v.ID == orderItemID {
+			return v, nil
+		}
+	}
+
+	return entity.OrderItem{}, fmt.Errorf("%w: order %d has no item %d", ErrInvalidReturn, orderID, orderItemID)
+}
+
+// sellerReturnRequestForUpdate locks a return request of the products of the seller which is in one of statuses.
+func (o *orderService) sellerReturnRequestForUpdate(ctx context.Context, sellerID int64, id int64, statuses ...string) (entity.ReturnRequest, error) {
+	returnRequest, err := o.returnRequestRepo.GetReturnRequestByIDForUpdate(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && returnRequest.SellerID != sellerID) {
+		return entity.ReturnRequest{}, ErrReturnNotFound
+	}
+	if err != nil {
+		return entity.ReturnRequest{}, err
+	}
+
+	for _, v := range statuses {
+		if returnRequest.Status == v {
+			return returnRequest, nil
+		}
+	}
+
+	return entity.ReturnRequest{}, fmt.Errorf("%w: return %d is %s", ErrInvalidReturn, id, returnRequest.Status)
+}
+
+func toReturnRequestResponse(returnRequest entity.ReturnRequest, currency string) response.ReturnRequest {
+	photoUrls := []string(returnRequest.PhotoUrls)
+	if photoUrls == nil {
+		photoUrls = []string{}
+	}
+
+	return response.ReturnRequest{
+		ID:           returnRequest.ID,
+		OrderID:      returnRequest.OrderID,
+		OrderItemID:  returnRequest.OrderItemID,
+		ProductID:    returnRequest.ProductID,
+		SellerID:     returnRequest.SellerID,
+		UserID:       returnRequest.UserID,
+		Quantity:     returnRequest.Quantity,
+		Reason:       returnRequest.Reason,
+		PhotoUrls:    photoUrls,
+		Status:       returnRequest.Status,
+		SellerNote:   returnRequest.SellerNote,
+		RefundAmount: money.New(returnRequest.RefundAmount, currency),
+		CreatedAt:    returnRequest.CreatedAt,
+		UpdatedAt:    returnRequest.UpdatedAt,
+	}
+}
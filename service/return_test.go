@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"ecommerce/model/entity"
+	"ecommerce/model/request"
+	"ecommerce/repository"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type returnOrderRepo struct {
+	repository.OrderProvider
+	orderItems []entity.OrderItem
+}
+
+func (r returnOrderRepo) GetOrderItemsByOrderID(ctx context.Context, orderID int64) ([]entity.OrderItem, error) {
+	return r.orderItems, nil
+}
+
+type memoryReturnRequestRepo struct {
+	repository.ReturnRequestProvider
+	returnRequests []entity.ReturnRequest
+}
+
+func (m *memoryReturnRequestRepo) GetReturnRequestsByOrderID(ctx context.Context, orderID int64) ([]entity.ReturnRequest, error) {
+	return m.returnRequests, nil
+}
+
+func TestPaidShare(t *testing.T) {
+	tests := []struct {
+		name     string
+		paid     int64
+		quantity int64
+		want     []int64
+	}{
+		{name: "even", paid: 3000, quantity: 3, want: []int64{0, 1000, 2000, 3000}},
+		{name: "uneven", paid: 1000, quantity: 3, want: []int64{0, 333, 666, 1000}},
+		{name: "less than a cent per unit", paid: 2, quantity: 3, want: []int64{0, 0, 1, 2}},
+		{name: "nothing paid", paid: 0, quantity: 2, want: []int64{0, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for units, want := range tt.want {
+				assert.Equal(t, want, paidShare(tt.paid, int64(units), tt.quantity), "%d units", units)
+			}
+		})
+	}
+}
+
+func TestRefundableAmountAddsUpToPaid(t *testing.T) {
+	tests := []struct {
+		name       string
+		orderItem  entity.OrderItem
+		quantities []int64
+		want       []int64
+	}{
+		{
+			name:       "one unit at a time",
+			orderItem:  entity.OrderItem{ID: 1, Price: 1000, Quantity: 3, Discount: 1},
+			quantities: []int64{1, 1, 1},
+			want:       []int64{999, 1000, 1000},
+		},
+		{
+			name:       "two units then one",
+			orderItem:  entity.OrderItem{ID: 1, Price: 1000, Quantity: 3, Discount: 1},
+			quantities: []int64{2, 1},
+			want:       []int64{1999, 1000},
+		},
+		{
+			name:       "every unit at once",
+			orderItem:  entity.OrderItem{ID: 1, Price: 999, Quantity: 7, Discount: 500},
+			quantities: []int64{7},
+			want:       []int64{6493},
+		},
+		{
+			name:       "a discount larger than some units",
+			orderItem:  entity.OrderItem{ID: 1, Price: 10, Quantity: 4, Discount: 33},
+			quantities: []int64{1, 1, 1, 1},
+			want:       []int64{1, 2, 2, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			returnRequestRepo := &memoryReturnRequestRepo{
+				// a rejected return of the line and an approved return of another line don't count
+				returnRequests: []entity.ReturnRequest{
+					{ID: 100, OrderItemID: tt.orderItem.ID, Quantity: 1, Status: entity.ReturnStatusRejected},
+					{ID: 101, OrderItemID: tt.orderItem.ID + 1, Quantity: 1, Status: entity.ReturnStatusApproved},
+				},
+			}
+			o := &orderService{
+				orderRepo:         returnOrderRepo{orderItems: []entity.OrderItem{tt.orderItem}},
+				returnRequestRepo: returnRequestRepo,
+			}
+
+			var refunds []int64
+			var total int64
+			for i, quantity := range tt.quantities {
+				returnRequest := entity.ReturnRequest{
+					ID:          int64(i + 1),
+					OrderItemID: tt.orderItem.ID,
+					Quantity:    quantity,
+					Status:      entity.ReturnStatusRequested,
+				}
+				returnRequestRepo.returnRequests = append(returnRequestRepo.returnRequests, returnRequest)
+
+				amount, err := o.refundableAmount(context.Background(), returnRequest)
+				require.NoError(t, err)
+
+				refunds = append(refunds, amount)
+				total += amount
+				returnRequestRepo.returnRequests[len(returnRequestRepo.returnRequests)-1].Status = entity.ReturnStatusApproved
+			}
+
+			assert.Equal(t, tt.want, refunds)
+			assert.Equal(t, tt.orderItem.Price*tt.orderItem.Quantity-tt.orderItem.Discount, total)
+		})
+	}
+}
+
+// lockingOrderRepo logs the locks of the orders in calls.
+type lockingOrderRepo struct {
+	returnOrderRepo
+	calls *[]string
+}
+
+func (r lockingOrderRepo) GetOrderByIDForUpdate(ctx context.Context, id int64) (entity.Order, error) {
+	*r.calls = append(*r.calls, "lock order")
+
+	return entity.Order{ID: id}, nil
+}
+
+// loggingReturnRequestRepo logs the reads and writes of the return requests in calls.
+type loggingReturnRequestRepo struct {
+	memoryReturnRequestRepo
+	calls *[]string
+}
+
+func (r *loggingReturnRequestRepo) GetReturnRequestByIDForUpdate(ctx context.Context, id int64) (entity.ReturnRequest, error) {
+	*r.calls = append(*r.calls, "lock return")
+
+	return r.returnRequests[len(r.returnRequests)-1], nil
+}
+
+func (r *loggingReturnRequestRepo) GetReturnRequestsByOrderID(ctx context.Context, orderID int64) ([]entity.ReturnRequest, error) {
+	*r.calls = append(*r.calls, "read returns")
+
+	return r.returnRequests, nil
+}
+
+func (r *loggingReturnRequestRepo) UpdateReturnRequest(ctx context.Context, payload entity.ReturnRequest) error {
+	*r.calls = append(*r.calls, "update return")
+	r.returnRequests[len(r.returnRequests)-1] = payload
+
+	return nil
+}
+
+func TestApproveReturnRequestLocksOrder(t *testing.T) {
+	var calls []string
+	orderItem := entity.OrderItem{ID: 1, OrderID: 5, SellerID: 3, Price: 1000, Quantity: 3, Discount: 1}
+	returnRequestRepo := &loggingReturnRequestRepo{
+		memoryReturnRequestRepo: memoryReturnRequestRepo{returnRequests: []entity.ReturnRequest{
+			{ID: 1, OrderID: 5, OrderItemID: 1, SellerID: 3, Quantity: 1, Status: entity.ReturnStatusApproved},
+			{ID: 2, OrderID: 5, OrderItemID: 1, SellerID: 3, Quantity: 2, Status: entity.ReturnStatusRequested},
+		}},
+		calls: &calls,
+	}
+	o := &orderService{
+		orderRepo:         lockingOrderRepo{returnOrderRepo: returnOrderRepo{orderItems: []entity.OrderItem{orderItem}}, calls: &calls},
+		returnRequestRepo: returnRequestRepo,
+		transactionRepo:   inlineTransaction{},
+	}
+
+	err := o.ApproveReturnRequest(context.Background(), 3, 2, request.ReviewReturnRequest{})
+	require.NoError(t, err)
+
+	// the returns approved before are read once the order is locked, so they can't change until this one is saved
+	assert.Equal(t, []string{"lock return", "lock order", "read returns", "update return"}, calls)
+	assert.Equal(t, entity.ReturnStatusApproved, returnRequestRepo.returnRequests[1].Status)
+	assert.Equal(t, int64(2000), returnRequestRepo.returnRequests[1].RefundAmount)
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ecommerce/model/entity"
+	"ecommerce/model/money"
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"ecommerce/repository"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+type sellerService struct {
+	sellerRepo    repository.SellerProvider
+	ecommerceRepo repository.EcommerceProvider
+	exchangeRates money.Rates
+	currency      string
+}
+
+type SellerConfig struct {
+	SellerRepo    repository.SellerProvider
+	EcommerceRepo repository.EcommerceProvider
+	// ExchangeRates converts the prices of the storefront to the display currency requested by the client
+	ExchangeRates money.Rates
+	// Currency is the default currency of the store, the currency of the price filters when none is requested
+	Currency string
+}
+
+func NewSellerService(config SellerConfig) sellerService {
+	sellerProvider := sellerService{
+		sellerRepo:    config.SellerRepo,
+		ecommerceRepo: config.EcommerceRepo,
+		exchangeRates: config.ExchangeRates,
+		currency:      config.Currency,
+	}
+
+	return sellerProvider
+}
+
+func (s *sellerService) GetSellerStorefront(ctx context.Context, userID int64, payload request.FilterProduct) (response.GetSellerStorefrontResponse, error) {
+	var resp response.GetSellerStorefrontResponse
+
+	seller, err := s.sellerRepo.GetSellerByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return resp, err
+	}
+
+	stats, err := s.sellerRepo.GetSellerProductStats(ctx, userID)
+	if err != nil {
+		return resp, err
+	}
+
+	// a seller without a profile and without products doesn't exist
+	if seller.UserID == 0 && stats.ProductCount == 0 {
+		return resp, sql.ErrNoRows
+	}
+	seller.UserID = userID
+
+	if payload.Currency != "" && !money.IsSupported(payload.Currency) {
+		return resp, fmt.Errorf("%w: %q is not a supported currency", ErrInvalidCurrency, payload.Currency)
+	}
+
+	payload.UserID = userID
+	products, err := s.ecommerceRepo.GetProductList(ctx, normalizeProductFilter(payload, s.currency))
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Data.Products, err = toProductResponses(products, s.exchangeRates, payload.Currency)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Data.Seller = seller
+	resp.Data.ProductCount = stats.ProductCount
+	resp.Data.AverageRating = math.Round(stats.AverageRating*10) / 10
+
+	return resp, nil
+}
+
+func (s *sellerService) UpsertSeller(ctx context.Context, userID int64, request request.UpsertSeller) (err error) {
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidSeller)
+	}
+
+	return s.sellerRepo.UpsertSeller(ctx, entity.Seller{
+		UserID:      userID,
+		Name:        name,
+		Description: request.Description,
+		City:        request.City,
+		AvatarUrl:   request.AvatarUrl,
+	})
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ecommerce/model/entity"
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"ecommerce/model/weight"
+	"ecommerce/repository"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// defaultVolumetricDivisor is the volume in cubic centimeters most couriers charge as one kilogram.
+const defaultVolumetricDivisor = 6000
+
+type shippingService struct {
+	cartRepo          repository.CartProvider
+	shippingRateRepo  repository.ShippingRateProvider
+	volumetricDivisor float64
+}
+
+type ShippingConfig struct {
+	CartRepo         repository.CartProvider
+	ShippingRateRepo repository.ShippingRateProvider
+	// VolumetricDivisor is the volume in cubic centimeters charged as one kilogram
+	VolumetricDivisor float64
+}
+
+func NewShippingService(config ShippingConfig) shippingService {
+	shippingProvider := shippingService{
+		cartRepo:          config.CartRepo,
+		shippingRateRepo:  config.ShippingRateRepo,
+		volumetricDivisor: config.VolumetricDivisor,
+	}
+
+	if shippingProvider.volumetricDivisor <= 0 {
+		shippingProvider.volumetricDivisor = defaultVolumetricDivisor
+	}
+
+	return shippingProvider
+}
+
+// QuoteShipping returns the courier services able to ship the cart of the user to the postal code. The items of
+// every seller are shipped in their own package, so a service is only offered when it has a rate for each package.
+func (s *shippingService) QuoteShipping(ctx context.Context, request request.ShippingQuote) (response.GetShippingQuoteResponse, error) {
+	var resp response.GetShippingQuoteResponse
+
+	cart, err := s.cartRepo.GetCartByUserID(ctx, request.UserID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return resp, ErrEmptyCart
+	}
+	if err != nil {
+		return resp, err
+	}
+
+	cartItems, err := s.cartRepo.GetCartItemsByCartID(ctx, cart.ID)
+	if err != nil {
+		return resp, err
+	}
+
+	if len(cartItems) == 0 {
+		return resp, ErrEmptyCart
+	}
+
+	zone, err := s.resolveShippingZone(ctx, request.PostalCode)
+	if err != nil {
+		return resp, err
+	}
+
+	rates, err := s.shippingRateRepo.GetShippingRates(ctx)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Data.Zone = zone
+	resp.Data.Currency = summarizeCart(cartItems).Currency
+	resp.Data.Packages = s.shippingPackages(cartItems)
+	resp.Data.Options = shippingOptions(rates, zone, resp.Data.Currency, resp.Data.Packages)
+
+	return resp, nil
+}
+
+// resolveShippingZone returns the zone with the longest postal code prefix matching postalCode.
+func (s *shippingService) resolveShippingZone(ctx context.Context, postalCode string) (string, error) {
+	postalCode = strings.TrimSpace(postalCode)
+	if postalCode == "" {
+		return "", fmt.Errorf("%w: postal_code is required", ErrInvalidDestination)
+	}
+
+	zones, err := s.shippingRateRepo.GetShippingZones(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var zone entity.ShippingZone
+	for _, v := range zones {
+		if strings.HasPrefix(postalCode, v.PostalCodePrefix) && len(v.PostalCodePrefix) > len(zone.PostalCodePrefix) {
+			zone = v
+		}
+	}
+
+	if zone.Zone == "" {
+		return "", fmt.Errorf("%w: no shipping zone covers postal code %s", ErrInvalidDestination, postalCode)
+	}
+
+	return zone.Zone, nil
+}
+
+// shippingPackages groups the cart items in a package per seller, in the order of the seller ids.
+func (s *shippingService) shippingPackages(cartItems []entity.CartItemDetail) []response.ShippingPackage {
+	packages := map[int64]*response.ShippingPackage{}
+	var sellerIDs []int64
+	for _, v := range cartItems {
+		pkg, ok := packages[v.SellerID]
+		if !ok {
+			pkg = &response.ShippingPackage{SellerID: v.SellerID}
+			packages[v.SellerID] = pkg
+			sellerIDs = append(sellerIDs, v.SellerID)
+		}
+
+		pkg.ActualWeight += v.Weight * weight.Grams(v.Quantity)
+		pkg.VolumetricWeight += s.volumetricWeight(v) * weight.Grams(v.Quantity)
+	}
+
+	sort.Slice(sellerIDs, func(i, j int) bool {
+		return sellerIDs[i] < sellerIDs[j]
+	})
+
+	resp := make([]response.ShippingPackage, 0, len(sellerIDs))
+	for _, id := range sellerIDs {
+		pkg := packages[id]
+		pkg.ChargeableWeight = weight.Grams(math.Max(float64(pkg.ActualWeight), float64(pkg.VolumetricWeight)))
+		resp = append(resp, *pkg)
+	}
+
+	return resp
+}
+
+// volumetricWeight returns the weight charged for the volume of a cart item, 0 when its dimensions are not known.
+func (s *shippingService) volumetricWeight(cartItem entity.CartItemDetail) weight.Grams {
+	return weight.Grams(cartItem.Length * cartItem.Width * cartItem.Height / s.volumetricDivisor * 1000)
+}
+
+// shippingOptions prices every courier service of zone in currency for the packages, a service without a rate for
+// one of the packages is left out.
+func shippingOptions(rates []entity.ShippingRate, zone string, currency string, packages []response.ShippingPackage) []response.ShippingOption {
+	type courierService struct {
+		courier string
+		service string
+	}
+
+	var services []courierService
+	ratesByService := map[courierService][]entity.ShippingRate{}
+	for _, v := range rates {
+		if v.Zone != zone || v.Currency != currency {
+			continue
+		}
+
+		key := courierService{courier: v.Courier, service: v.Service}
+		if _, ok := ratesByService[key]; !ok {
+			services = append(services, key)
+		}
+		ratesByService[key] = append(ratesByService[key], v)
+	}
+
+	options := []response.ShippingOption{}
+	for _, key := range services {
+		option := response.ShippingOption{Courier: key.courier, Service: key.service}
+		available := true
+		for _, pkg := range packages {
+			rate, ok := shippingRateFor(ratesByService[key], pkg.ChargeableWeight)
+			if !ok {
+				available = false
+				break
+			}
+
+			option.Price += rate.Price
+			if rate.EstimatedDays > option.EstimatedDays {
+				option.EstimatedDays = rate.EstimatedDays
+			}
+		}
+
+		if available {
+			options = append(options, option)
+		}
+	}
+
+	sort.SliceStable(options, func(i, j int) bool {
+		return options[i].Price < options[j].Price
+	})
+
+	return options
+}
+
+// shippingRateFor returns the rate of the bracket packageWeight falls in, a bracket runs from above its min weight up
+// to its max weight and the bracket starting at 0 includes 0.
+func shippingRateFor(rates []entity.ShippingRate, packageWeight weight.Grams) (entity.ShippingRate, bool) {
+	for _, v := range rates {
+		if (packageWeight > v.MinWeight || v.MinWeight == 0) && (v.MaxWeight == 0 || packageWeight <= v.MaxWeight) {
+			return v, true
+		}
+	}
+
+	return entity.ShippingRate{}, false
+}
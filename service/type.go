@@ -4,6 +4,7 @@ import (
 	"context"
 	"ecommerce/model/request"
 	"ecommerce/model/response"
+	"io"
 )
 
 type EcommerceProvider interface {
@@ -12,4 +13,106 @@ type EcommerceProvider interface {
 	UpdateProduct(ctx context.Context, id int64, request request.UpsertProduct) (err error)
 	GetProductByID(ctx context.Context, id int64) (response response.GetProductDetailResponse, err error)
 	CreateProductReview(ctx context.Context, request request.UpsertProductReview) (err error)
+	ReorderProductImages(ctx context.Context, productID int64, request request.ReorderProductImages) (err error)
+	SetPrimaryProductImage(ctx context.Context, productID int64, imageID int64) (err error)
+	ImportProducts(ctx context.Context, request request.ImportProducts, file io.Reader) (response response.GetProductImportResponse, err error)
+	GetProductImport(ctx context.Context, id int64) (response response.GetProductImportResponse, err error)
+	GetProductImportReport(ctx context.Context, id int64) (report io.ReadCloser, err error)
+	ExportProducts(ctx context.Context, payload request.FilterProduct, format string) (response response.ProductExport, err error)
+	GetProductPrices(ctx context.Context, productID int64) (response response.GetProductPricesResponse, err error)
+	ScheduleProductPrice(ctx context.Context, productID int64, request request.ScheduleProductPrice) (err error)
+	CancelProductPrice(ctx context.Context, productID int64, priceID int64) (err error)
+	GetProductHistory(ctx context.Context, productID int64, request request.Pagination, path string) (response response.GetProductHistoryResponse, err error)
+}
+
+type CartProvider interface {
+	GetCart(ctx context.Context, userID int64) (response response.GetCartResponse, err error)
+	AddCartItem(ctx context.Context, userID int64, request request.UpsertCartItem) (err error)
+	UpdateCartItem(ctx context.Context, userID int64, productID int64, request request.UpsertCartItem) (err error)
+	RemoveCartItem(ctx context.Context, userID int64, productID int64, variantID int64) (err error)
+}
+
+type WishlistProvider interface {
+	GetWishlist(ctx context.Context, userID int64, request request.Pagination, path string) (response response.GetWishlistResponse, err error)
+	AddWishlistItem(ctx context.Context, userID int64, request request.AddWishlistItem) (err error)
+	RemoveWishlistItem(ctx context.Context, userID int64, productID int64) (err error)
+}
+
+type WebhookProvider interface {
+	CreateWebhookSubscription(ctx context.Context, sellerID int64, request request.UpsertWebhookSubscription) (response response.GetWebhookSubscriptionResponse, err error)
+	GetWebhookSubscriptions(ctx context.Context, sellerID int64) (response response.GetWebhookSubscriptionListResponse, err error)
+	UpdateWebhookSubscription(ctx context.Context, sellerID int64, id int64, request request.UpsertWebhookSubscription) (err error)
+	DeleteWebhookSubscription(ctx context.Context, sellerID int64, id int64) (err error)
+	GetWebhookDeliveries(ctx context.Context, sellerID int64, id int64, request request.Pagination, path string) (response response.GetWebhookDeliveryListResponse, err error)
+	RedeliverWebhook(ctx context.Context, sellerID int64, id int64, deliveryID int64) (response response.GetWebhookDeliveryResponse, err error)
+	DeliverWebhooks(ctx context.Context) (err error)
+}
+
+type OrderProvider interface {
+	CreateOrder(ctx context.Context, request request.CreateOrder) (response response.GetOrderDetailResponse, err error)
+	GetOrderByID(ctx context.Context, id int64) (response response.GetOrderDetailResponse, err error)
+	UpdateOrderStatus(ctx context.Context, id int64, request request.UpdateOrderStatus) (err error)
+	ExpireStockReservations(ctx context.Context) (err error)
+	CreatePayment(ctx context.Context, orderID int64) (response response.GetPaymentResponse, err error)
+	GetOrderPayments(ctx context.Context, orderID int64) (response response.GetPaymentListResponse, err error)
+	HandlePaymentWebhook(ctx context.Context, payload []byte, signature string) (err error)
+	RefundOrder(ctx context.Context, orderID int64) (err error)
+	CreateReturnRequest(ctx context.Context, orderID int64, request request.CreateReturnRequest) (response response.GetReturnRequestResponse, err error)
+	GetOrderReturnRequests(ctx context.Context, orderID int64) (response response.GetReturnRequestListResponse, err error)
+	GetSellerReturnRequests(ctx context.Context, sellerID int64, request request.Pagination, path string) (response response.GetSellerReturnRequestListResponse, err error)
+	ApproveReturnRequest(ctx context.Context, sellerID int64, id int64, request request.ReviewReturnRequest) (err error)
+	RejectReturnRequest(ctx context.Context, sellerID int64, id int64, request request.ReviewReturnRequest) (err error)
+	ReceiveReturnRequest(ctx context.Context, sellerID int64, id int64) (err error)
+	GetOrderInvoice(ctx context.Context, orderID int64, request request.GetOrderInvoice) (response response.InvoiceDocument, err error)
+}
+
+type InventoryProvider interface {
+	AdjustStock(ctx context.Context, productID int64, request request.AdjustStock) (err error)
+	GetInventoryLedger(ctx context.Context, productID int64) (response response.GetInventoryLedgerResponse, err error)
+}
+
+type CategoryProvider interface {
+	GetCategoryTree(ctx context.Context) (response response.GetCategoryListResponse, err error)
+	GetCategoryByID(ctx context.Context, id int64) (response response.GetCategoryDetailResponse, err error)
+	CreateCategory(ctx context.Context, request request.UpsertCategory) (err error)
+	UpdateCategory(ctx context.Context, id int64, request request.UpsertCategory) (err error)
+	DeleteCategory(ctx context.Context, id int64) (err error)
+}
+
+type EtalaseProvider interface {
+	GetEtalaseList(ctx context.Context, userID int64) (response response.GetEtalaseListResponse, err error)
+	CreateEtalase(ctx context.Context, userID int64, request request.UpsertEtalase) (err error)
+	UpdateEtalase(ctx context.Context, userID int64, id int64, request request.UpsertEtalase) (err error)
+	DeleteEtalase(ctx context.Context, userID int64, id int64) (err error)
+	AssignEtalaseProducts(ctx context.Context, userID int64, id int64, request request.AssignEtalaseProducts) (err error)
+	RemoveEtalaseProduct(ctx context.Context, userID int64, id int64, productID int64) (err error)
+	GetEtalaseProductList(ctx context.Context, userID int64, id int64, request request.Pagination, path string) (response response.GetEtalaseProductListResponse, err error)
+}
+
+type ImageProvider interface {
+	UploadImage(ctx context.Context, file io.Reader) (response response.UploadImageResponse, err error)
+	ProcessPendingImages(ctx context.Context) (err error)
+}
+
+type FeedProvider interface {
+	GenerateProductFeeds(ctx context.Context) (err error)
+	GetProductFeed(ctx context.Context, format string) (feed io.ReadCloser, contentType string, err error)
+}
+
+type SellerProvider interface {
+	GetSellerStorefront(ctx context.Context, userID int64, request request.FilterProduct) (response response.GetSellerStorefrontResponse, err error)
+	UpsertSeller(ctx context.Context, userID int64, request request.UpsertSeller) (err error)
+}
+
+type PromotionProvider interface {
+	GetPromotionList(ctx context.Context, request request.Pagination, path string) (response response.GetPromotionListResponse, err error)
+	GetPromotionByID(ctx context.Context, id int64) (response response.GetPromotionDetailResponse, err error)
+	CreatePromotion(ctx context.Context, request request.UpsertPromotion) (err error)
+	UpdatePromotion(ctx context.Context, id int64, request request.UpsertPromotion) (err error)
+	DeletePromotion(ctx context.Context, id int64) (err error)
+	EvaluateCartPromotions(ctx context.Context, userID int64, request request.ApplyPromotions) (response response.GetCartPromotionsResponse, err error)
+}
+
+type ShippingProvider interface {
+	QuoteShipping(ctx context.Context, request request.ShippingQuote) (response response.GetShippingQuoteResponse, err error)
 }
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ecommerce/model/entity"
+	"ecommerce/model/money"
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"ecommerce/model/weight"
+	"fmt"
+)
+
+// validateProductVariants makes sure every variant has a unique sku and exactly one declared value per option.
+func validateProductVariants(options []request.UpsertProductOption, variants []request.UpsertProductVariant) error {
+	optionValues := make(map[string]map[string]bool, len(options))
+	for _, v := range options {
+		if v.Name == "" || len(v.Values) == 0 {
+			return fmt.Errorf("%w: option should have a name and at least one value", ErrInvalidVariant)
+		}
+
+		if _, ok := optionValues[v.Name]; ok {
+			return fmt.Errorf("%w: duplicate option %s", ErrInvalidVariant, v.Name)
+		}
+
+		optionValues[v.Name] = make(map[string]bool, len(v.Values))
+		for _, value := range v.Values {
+			if optionValues[v.Name][value] {
+				return fmt.Errorf("%w: duplicate value %s of option %s", ErrInvalidVariant, value, v.Name)
+			}
+			optionValues[v.Name][value] = true
+		}
+	}
+
+	skus := make(map[string]bool, len(variants))
+	combinations := make(map[string]bool, len(variants))
+	for _, v := range variants {
+		if v.Sku == "" || skus[v.Sku] {
+			return fmt.Errorf("%w: sku %q should be filled and unique", ErrInvalidVariant, v.Sku)
+		}
+		skus[v.Sku] = true
+
+		if v.Stock < 0 {
+			return fmt.Errorf("%w: %s", ErrInvalidStock, v.Sku)
+		}
+
+		if len(v.Options) != len(options) {
+			return fmt.Errorf("%w: %s should have a value for every option", ErrInvalidVariant, v.Sku)
+		}
+
+		combination := ""
+		for _, option := range options {
+			value, ok := v.Options[option.Name]
+			if !ok || !optionValues[option.Name][value] {
+				return fmt.Errorf("%w: %s has no valid value for option %s", ErrInvalidVariant, v.Sku, option.Name)
+			}
+			combination += option.Name + "=" + value + ";"
+		}
+
+		if combinations[combination] {
+			return fmt.Errorf("%w: %s duplicates the options of another variant", ErrInvalidVariant, v.Sku)
+		}
+		combinations[combination] = true
+	}
+
+	return nil
+}
+
+// saveProductVariants replaces the options of the product and upserts its variants by sku. The stock of a new
+// variant is recorded in the inventory ledger, the stock of an existing one is left to stock adjustments.
+func (e *ecommerceService) saveProductVariants(ctx context.Context, productID int64, options []request.UpsertProductOption, variants []request.UpsertProductVariant) error {
+	existing, err := e.variantRepo.GetProductVariantsByProductID(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	existingSkus := make(map[string]bool, len(existing))
+	for _, v := range existing {
+		existingSkus[v.Sku] = true
+	}
+
+	err = e.variantRepo.DeleteProductOptionsByProductID(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	optionValueIDs := make(map[string]map[string]int64, len(options))
+	for i, v := range options {
+		optionID, err := e.variantRepo.CreateProductOption(ctx, entity.ProductOption{
+			ProductID: productID,
+			Name:      v.Name,
+			Position:  i,
+		})
+		if err != nil {
+			return err
+		}
+
+		optionValueIDs[v.Name] = make(map[string]int64, len(v.Values))
+		for j, value := range v.Values {
+			optionValueID, err := e.variantRepo.CreateProductOptionValue(ctx, entity.ProductOptionValue{
+				OptionID: optionID,
+				Value:    value,
+				Position: j,
+			})
+			if err != nil {
+				return err
+			}
+
+			optionValueIDs[v.Name][value] = optionValueID
+		}
+	}
+
+	skus := make([]string, 0, len(variants))
+	for _, v := range variants {
+		variant := entity.ProductVariant{
+			ProductID: productID,
+			Sku:       v.Sku,
+			Stock:     v.Stock,
+		}
+
+		if v.Price != nil {
+			variant.Price = sql.NullInt64{Valid: true, Int64: *v.Price}
+		}
+
+		if v.Weight != nil {
+			variant.Weight = sql.NullFloat64{Valid: true, Float64: float64(*v.Weight)}
+		}
+
+		variantID, err := e.variantRepo.UpsertProductVariant(ctx, variant)
+		if err != nil {
+			return err
+		}
+
+		if !existingSkus[v.Sku] && v.Stock > 0 {
+			err = e.inventoryRepo.CreateInventoryLedger(ctx, entity.InventoryLedger{
+				ProductID:      productID,
+				VariantID:      variantID,
+				QuantityChange: v.Stock,
+				StockAfter:     v.Stock,
+				Reason:         entity.InventoryReasonInitialStock,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		for name, value := range v.Options {
+			err = e.variantRepo.CreateProductVariantOptionValue(ctx, variantID, optionValueIDs[name][value])
+			if err != nil {
+				return err
+			}
+		}
+
+		skus = append(skus, v.Sku)
+	}
+
+	return e.variantRepo.DeleteProductVariantsExceptSkus(ctx, productID, skus)
+}
+
+// getProductVariants returns the options and variants of the product with price and weight resolved against it.
+func (e *ecommerceService) getProductVariants(ctx context.Context, product entity.Product) ([]response.ProductOption, []response.ProductVariant, error) {
+	options, err := e.variantRepo.GetProductOptionsByProductID(ctx, product.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	optionValues, err := e.variantRepo.GetProductOptionValuesByProductID(ctx, product.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	variants, err := e.variantRepo.GetProductVariantsByProductID(ctx, product.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	variantOptionValues, err := e.variantRepo.GetProductVariantOptionValuesByProductID(ctx, product.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	valuesByOption := make(map[int64][]string, len(options))
+	for _, v := range optionValues {
+		valuesByOption[v.OptionID] = append(valuesByOption[v.OptionID], v.Value)
+	}
+
+	productOptions := make([]response.ProductOption, 0, len(options))
+	for _, v := range options {
+		productOptions = append(productOptions, response.ProductOption{
+			Name:   v.Name,
+			Values: valuesByOption[v.ID],
+		})
+	}
+
+	optionsByVariant := make(map[int64]map[string]string, len(variants))
+	for _, v := range variantOptionValues {
+		if optionsByVariant[v.VariantID] == nil {
+			optionsByVariant[v.VariantID] = make(map[string]string)
+		}
+		optionsByVariant[v.VariantID][v.OptionName] = v.Value
+	}
+
+	productVariants := make([]response.ProductVariant, 0, len(variants))
+	for _, v := range variants {
+		variant := response.ProductVariant{
+			ID:      v.ID,
+			Sku:     v.Sku,
+			Price:   product.Price,
+			Weight:  product.Weight,
+			Stock:   v.Stock,
+			Options: optionsByVariant[v.ID],
+		}
+
+		if v.Price.Valid {
+			variant.Price = money.New(v.Price.Int64, product.Price.Currency)
+		}
+
+		if v.Weight.Valid {
+			variant.Weight = weight.Grams(v.Weight.Float64)
+		}
+
+		productVariants = append(productVariants, variant)
+	}
+
+	return productOptions, productVariants, nil
+}
@@ -0,0 +1,497 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"ecommerce/model/entity"
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"ecommerce/repository"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// webhookDeliveryBatchSize is the number of deliveries attempted on each DeliverWebhooks run.
+	webhookDeliveryBatchSize = 20
+	// defaultMaxWebhookAttempts is used when no maximum number of attempts is configured.
+	defaultMaxWebhookAttempts = 8
+	// defaultWebhookRetryBackoff is the wait before the first retry when none is configured, it doubles on every retry.
+	defaultWebhookRetryBackoff = time.Minute
+	// maxWebhookRetryBackoff caps the wait between two attempts.
+	maxWebhookRetryBackoff = 6 * time.Hour
+	// webhookDeliveryLease is how long a claimed delivery is left to its worker before it is claimed again, it has to
+	// outlast the timeout of the client.
+	webhookDeliveryLease = 5 * time.Minute
+	// webhookSecretSize is the number of random bytes of a generated secret.
+	webhookSecretSize = 32
+)
+
+// webhookEventTypes are the events sellers can subscribe their webhooks to.
+var webhookEventTypes = map[string]bool{
+	entity.EventReviewCreated: true,
+	entity.EventOrderCreated:  true,
+	entity.EventOrderPaid:     true,
+}
+
+// webhookMessage is the body posted to the subscribers, ID is the id of the event so a subscriber can tell a retry
+// from a new event.
+type webhookMessage struct {
+	ID        int64           `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+type webhookService struct {
+	webhookRepo     repository.WebhookProvider
+	webhookClient   repository.WebhookClient
+	transactionRepo repository.TransactionProvider
+	maxAttempts     int
+	retryBackoff    time.Duration
+}
+
+type WebhookConfig struct {
+	WebhookRepo     repository.WebhookProvider
+	WebhookClient   repository.WebhookClient
+	TransactionRepo repository.TransactionProvider
+	// MaxAttempts is the number of times a delivery is attempted before it is marked as failed
+	MaxAttempts int
+	// RetryBackoff is the wait before the first retry of a delivery, it doubles on every retry
+	RetryBackoff time.Duration
+}
+
+func NewWebhookService(config WebhookConfig) webhookService {
+	webhookProvider := webhookService{
+		webhookRepo:     config.WebhookRepo,
+		webhookClient:   config.WebhookClient,
+		transactionRepo: config.TransactionRepo,
+		maxAttempts:     config.MaxAttempts,
+		retryBackoff:    config.RetryBackoff,
+	}
+
+	if webhookProvider.maxAttempts <= 0 {
+		webhookProvider.maxAttempts = defaultMaxWebhookAttempts
+	}
+
+	if webhookProvider.retryBackoff <= 0 {
+		webhookProvider.retryBackoff = defaultWebhookRetryBackoff
+	}
+
+	return webhookProvider
+}
+
+// recordSellerEvent writes an event concerning a seller to the outbox and queues its delivery to the webhooks the
+// seller subscribed to its type. It has to run in the transaction of the change the event describes.
+func recordSellerEvent(ctx context.Context, outboxRepo repository.OutboxProvider, webhookRepo repository.WebhookProvider, sellerID int64, aggregateType string, aggregateID int64, eventType string, payload interface{}) error {
+	event, err := writeEvent(ctx, outboxRepo, aggregateType, aggregateID, eventType, payload)
+	if err != nil {
+		return err
+	}
+
+	return webhookRepo.CreateWebhookDeliveries(ctx, sellerID, event)
+}
+
+func (w *webhookService) CreateWebhookSubscription(ctx context.Context, sellerID int64, request request.UpsertWebhookSubscription) (response.GetWebhookSubscriptionResponse, error) {
+	var resp response.GetWebhookSubscriptionResponse
+
+	subscription := entity.WebhookSubscription{
+		SellerID: sellerID,
+		Secret:   request.Secret,
+		Active:   true,
+	}
+	if request.Active != nil {
+		subscription.Active = *request.Active
+	}
+
+	err := w.applyWebhookSubscription(ctx, &subscription, request)
+	if err != nil {
+		return resp, err
+	}
+
+	if subscription.Secret == "" {
+		subscription.Secret, err = newWebhookSecret()
+		if err != nil {
+			return resp, err
+		}
+	}
+
+	subscription.ID, err = w.webhookRepo.CreateWebhookSubscription(ctx, subscription)
+	if err != nil {
+		return resp, err
+	}
+
+	subscription, err = w.webhookRepo.GetWebhookSubscriptionByID(ctx, subscription.ID)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Data = toWebhookSubscriptionResponse(subscription)
+	// the secret is only shown once, the seller needs it to verify the deliveries
+	resp.Data.Secret = subscription.Secret
+
+	return resp, nil
+}
+
+func (w *webhookService) GetWebhookSubscriptions(ctx context.Context, sellerID int64) (response.GetWebhookSubscriptionListResponse, error) {
+	var resp response.GetWebhookSubscriptionListResponse
+
+	subscriptions, err := w.webhookRepo.GetWebhookSubscriptionsBySellerID(ctx, sellerID)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Data = make([]response.WebhookSubscription, 0, len(subscriptions))
+	for _, v := range subscriptions {
+		resp.Data = append(resp.Data, toWebhookSubscriptionResponse(v))
+	}
+
+	return resp, nil
+}
+
+func (w *webhookService) UpdateWebhookSubscription(ctx context.Context, sellerID int64, id int64, request request.UpsertWebhookSubscription) (err error) {
+	subscription, err := w.sellerWebhookSubscription(ctx, sellerID, id)
+	if err != nil {
+		return err
+	}
+
+	if request.Secret != "" {
+		subscription.Secret = request.Secret
+	}
+	if request.Active != nil {
+		subscription.Active = *request.Active
+	}
+
+	err = w.applyWebhookSubscription(ctx, &subscription, request)
+	if err != nil {
+		return err
+	}
+
+	return w.webhookRepo.UpdateWebhookSubscription(ctx, subscription)
+}
+
+// DeleteWebhookSubscription removes a subscription along with its delivery log.
+func (w *webhookService) DeleteWebhookSubscription(ctx context.Context, sellerID int64, id int64) (err error) {
+	_, err = w.sellerWebhookSubscription(ctx, sellerID, id)
+	if err != nil {
+		return err
+	}
+
+	return w.webhookRepo.DeleteWebhookSubscription(ctx, id)
+}
+
+// GetWebhookDeliveries returns the delivery log of a subscription with the response code of every attempt, the
+// latest delivery first.
+func (w *webhookService) GetWebhookDeliveries(ctx context.Context, sellerID int64, id int64, request request.Pagination, path string) (response.GetWebhookDeliveryListResponse, error) {
+	var resp response.GetWebhookDeliveryListResponse
+
+	_, err := w.sellerWebhookSubscription(ctx, sellerID, id)
+	if err != nil {
+		return resp, err
+	}
+
+	total, err := w.webhookRepo.CountWebhookDeliveriesBySubscriptionID(ctx, id)
+	if err != nil {
+		return resp, err
+	}
+
+	meta, limit, offset := paginate(request, total, path)
+	deliveries, err := w.webhookRepo.GetWebhookDeliveriesBySubscriptionID(ctx, id, limit, offset)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Data = make([]response.WebhookDelivery, 0, len(deliveries))
+	for _, v := range deliveries {
+		delivery, err := w.toWebhookDeliveryResponse(ctx, v)
+		if err != nil {
+			return resp, err
+		}
+
+		resp.Data = append(resp.Data, delivery)
+	}
+	resp.Pagination = meta
+
+	return resp, nil
+}
+
+// RedeliverWebhook posts a delivery again right away, whatever its status. A successful redelivery marks the delivery
+// as succeeded, a failing one is logged and leaves the delivery as it was.
+func (w *webhookService) RedeliverWebhook(ctx context.Context, sellerID int64, id int64, deliveryID int64) (response.GetWebhookDeliveryResponse, error) {
+	var resp response.GetWebhookDeliveryResponse
+
+	_, err := w.sellerWebhookSubscription(ctx, sellerID, id)
+	if err != nil {
+		return resp, err
+	}
+
+	delivery, err := w.webhookRepo.GetWebhookDeliveryByID(ctx, deliveryID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return resp, ErrDeliveryNotFound
+	}
+	if err != nil {
+		return resp, err
+	}
+
+	if delivery.SubscriptionID != id {
+		return resp, ErrDeliveryNotFound
+	}
+
+	attempt := w.postWebhook(ctx, delivery)
+	attempt.Manual = true
+
+	if webhookSucceeded(attempt) {
+		delivery.Status = entity.WebhookDeliveryStatusSucceeded
+		delivery.ResponseCode = attempt.ResponseCode
+		delivery.LastError = ""
+		delivery.DeliveredAt = sql.NullTime{Valid: true, Time: attempt.CreatedAt}
+	}
+
+	err = w.recordWebhookAttempt(ctx, delivery.WebhookDelivery, attempt)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Data, err = w.toWebhookDeliveryResponse(ctx, delivery)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// DeliverWebhooks posts the deliveries which are due. A failing delivery is retried with a backoff doubling on every
+// attempt until maxAttempts is reached, then it is marked as failed.
+func (w *webhookService) DeliverWebhooks(ctx context.Context) (err error) {
+	now := time.Now()
+	deliveries, err := w.webhookRepo.ClaimDueWebhookDeliveries(ctx, now, now.Add(webhookDeliveryLease), webhookDeliveryBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range deliveries {
+		attempt := w.postWebhook(ctx, v)
+
+		delivery := v.WebhookDelivery
+		delivery.Attempts++
+		delivery.ResponseCode = attempt.ResponseCode
+		delivery.LastError = attempt.Error
+
+		switch {
+		case webhookSucceeded(attempt):
+			delivery.Status = entity.WebhookDeliveryStatusSucceeded
+			delivery.DeliveredAt = sql.NullTime{Valid: true, Time: attempt.CreatedAt}
+		case delivery.Attempts >= w.maxAttempts:
+			delivery.Status = entity.WebhookDeliveryStatusFailed
+		default:
+			delivery.NextAttemptAt = time.Now().Add(w.retryDelay(delivery.Attempts))
+		}
+
+		err = w.recordWebhookAttempt(ctx, delivery, attempt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// retryDelay returns the wait after the attempts-th failed attempt of a delivery.
+func (w *webhookService) retryDelay(attempts int) time.Duration {
+	delay := w.retryBackoff
+	for i := 1; i < attempts && delay < maxWebhookRetryBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > maxWebhookRetryBackoff {
+		delay = maxWebhookRetryBackoff
+	}
+
+	return delay
+}
+
+// postWebhook posts a delivery signed with the secret of its subscription and returns how the attempt went.
+func (w *webhookService) postWebhook(ctx context.Context, delivery entity.WebhookDeliveryDetail) entity.WebhookDeliveryAttempt {
+	attempt := entity.WebhookDeliveryAttempt{DeliveryID: delivery.ID, CreatedAt: time.Now()}
+
+	body, err := json.Marshal(webhookMessage{
+		ID:        delivery.EventID,
+		Type:      delivery.EventType,
+		CreatedAt: delivery.EventCreatedAt,
+		Data:      delivery.Payload,
+	})
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+
+	timestamp := strconv.FormatInt(attempt.CreatedAt.Unix(), 10)
+	header := map[string]string{
+		"Content-Type":        "application/json",
+		"X-Webhook-Event":     delivery.EventType,
+		"X-Webhook-Delivery":  strconv.FormatInt(delivery.ID, 10),
+		"X-Webhook-Timestamp": timestamp,
+		"X-Webhook-Signature": signWebhook(delivery.Secret, timestamp, body),
+	}
+
+	attempt.ResponseCode, err = w.webhookClient.Post(ctx, delivery.Url, header, body)
+	attempt.DurationMs = time.Since(attempt.CreatedAt).Milliseconds()
+
+	switch {
+	case err != nil:
+		attempt.Error = err.Error()
+	case !webhookSucceeded(attempt):
+		attempt.Error = fmt.Sprintf("subscriber responded with status %d", attempt.ResponseCode)
+	}
+
+	return attempt
+}
+
+// recordWebhookAttempt logs an attempt of a delivery along with its new state.
+func (w *webhookService) recordWebhookAttempt(ctx context.Context, delivery entity.WebhookDelivery, attempt entity.WebhookDeliveryAttempt) error {
+	return w.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		err := w.webhookRepo.UpdateWebhookDelivery(ctx, delivery)
+		if err != nil {
+			return err
+		}
+
+		return w.webhookRepo.CreateWebhookDeliveryAttempt(ctx, attempt)
+	})
+}
+
+// sellerWebhookSubscription returns a subscription of the seller, the subscriptions of other sellers are not found.
+func (w *webhookService) sellerWebhookSubscription(ctx context.Context, sellerID int64, id int64) (entity.WebhookSubscription, error) {
+	subscription, err := w.webhookRepo.GetWebhookSubscriptionByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.WebhookSubscription{}, ErrSubscriptionNotFound
+	}
+	if err != nil {
+		return entity.WebhookSubscription{}, err
+	}
+
+	if subscription.SellerID != sellerID {
+		return entity.WebhookSubscription{}, ErrSubscriptionNotFound
+	}
+
+	return subscription, nil
+}
+
+func (w *webhookService) toWebhookDeliveryResponse(ctx context.Context, delivery entity.WebhookDeliveryDetail) (response.WebhookDelivery, error) {
+	resp := response.WebhookDelivery{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseCode:   delivery.ResponseCode,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+	}
+
+	if delivery.Status == entity.WebhookDeliveryStatusPending {
+		resp.NextAttemptAt = &delivery.NextAttemptAt
+	}
+	if delivery.DeliveredAt.Valid {
+		resp.DeliveredAt = &delivery.DeliveredAt.Time
+	}
+
+	attempts, err := w.webhookRepo.GetWebhookDeliveryAttemptsByDeliveryID(ctx, delivery.ID)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.History = make([]response.WebhookDeliveryAttempt, 0, len(attempts))
+	for _, v := range attempts {
+		resp.History = append(resp.History, response.WebhookDeliveryAttempt{
+			ResponseCode: v.ResponseCode,
+			Error:        v.Error,
+			DurationMs:   v.DurationMs,
+			Manual:       v.Manual,
+			CreatedAt:    v.CreatedAt,
+		})
+	}
+
+	return resp, nil
+}
+
+// applyWebhookSubscription validates the url and event types of the request and sets them on subscription. The url
+// should resolve to a public address, the address is checked again on every delivery since it may change.
+func (w *webhookService) applyWebhookSubscription(ctx context.Context, subscription *entity.WebhookSubscription, request request.UpsertWebhookSubscription) error {
+	target, err := url.Parse(strings.TrimSpace(request.Url))
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return fmt.Errorf("%w: url should be an absolute http or https url", ErrInvalidSubscription)
+	}
+
+	err = w.webhookClient.CheckUrl(ctx, target.String())
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSubscription, err)
+	}
+
+	if len(request.EventTypes) == 0 {
+		return fmt.Errorf("%w: at least one event type is required", ErrInvalidSubscription)
+	}
+
+	eventTypes := make([]string, 0, len(request.EventTypes))
+	seen := map[string]bool{}
+	for _, v := range request.EventTypes {
+		if !webhookEventTypes[v] {
+			return fmt.Errorf("%w: %q is not an event type webhooks can subscribe to", ErrInvalidSubscription, v)
+		}
+
+		if !seen[v] {
+			seen[v] = true
+			eventTypes = append(eventTypes, v)
+		}
+	}
+
+	subscription.Url = target.String()
+	subscription.EventTypes = eventTypes
+
+	return nil
+}
+
+func toWebhookSubscriptionResponse(subscription entity.WebhookSubscription) response.WebhookSubscription {
+	return response.WebhookSubscription{
+		ID:         subscription.ID,
+		SellerID:   subscription.SellerID,
+		Url:        subscription.Url,
+		EventTypes: subscription.EventTypes,
+		Active:     subscription.Active,
+		CreatedAt:  subscription.CreatedAt,
+		UpdatedAt:  subscription.UpdatedAt,
+	}
+}
+
+func webhookSucceeded(attempt entity.WebhookDeliveryAttempt) bool {
+	return attempt.ResponseCode >= 200 && attempt.ResponseCode < 300
+}
+
+// signWebhook returns the hex encoded HMAC-SHA256 of the timestamp, a dot and the body keyed by secret. The timestamp
+// is signed along with the body so a subscriber can reject a delivery replayed later.
+func signWebhook(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, webhookSecretSize)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
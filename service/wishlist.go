@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"ecommerce/model/entity"
+	"ecommerce/model/request"
+	"ecommerce/model/response"
+	"ecommerce/repository"
+)
+
+type wishlistService struct {
+	wishlistRepo    repository.WishlistProvider
+	ecommerceRepo   repository.EcommerceProvider
+	transactionRepo repository.TransactionProvider
+}
+
+type WishlistConfig struct {
+	WishlistRepo    repository.WishlistProvider
+	EcommerceRepo   repository.EcommerceProvider
+	TransactionRepo repository.TransactionProvider
+}
+
+func NewWishlistService(config WishlistConfig) wishlistService {
+	wishlistProvider := wishlistService{
+		wishlistRepo:    config.WishlistRepo,
+		ecommerceRepo:   config.EcommerceRepo,
+		transactionRepo: config.TransactionRepo,
+	}
+
+	return wishlistProvider
+}
+
+// GetWishlist returns the products on the wishlist of a user with their current price and stock, the latest added
+// first.
+func (w *wishlistService) GetWishlist(ctx context.Context, userID int64, request request.Pagination, path string) (response.GetWishlistResponse, error) {
+	var resp response.GetWishlistResponse
+
+	total, err := w.wishlistRepo.CountWishlistItemsByUserID(ctx, userID)
+	if err != nil {
+		return resp, err
+	}
+
+	meta, limit, offset := paginate(request, total, path)
+	wishlistItems, err := w.wishlistRepo.GetWishlistItemsByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return resp, err
+	}
+
+	if wishlistItems == nil {
+		wishlistItems = []entity.WishlistItemDetail{}
+	}
+
+	resp.Data = wishlistItems
+	resp.Pagination = meta
+
+	return resp, nil
+}
+
+// AddWishlistItem favourites a product for a user, adding a product already on the wishlist changes nothing.
+func (w *wishlistService) AddWishlistItem(ctx context.Context, userID int64, request request.AddWishlistItem) (err error) {
+	product, err := w.ecommerceRepo.GetProductByID(ctx, request.ProductID)
+	if err != nil {
+		return err
+	}
+
+	return w.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		created, err := w.wishlistRepo.CreateWishlistItem(ctx, entity.WishlistItem{
+			UserID:    userID,
+			ProductID: product.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !created {
+			return nil
+		}
+
+		return w.wishlistRepo.UpdateProductFavouritedCount(ctx, product.ID, 1)
+	})
+}
+
+func (w *wishlistService) RemoveWishlistItem(ctx context.Context, userID int64, productID int64) (err error) {
+	return w.transactionRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		deleted, err := w.wishlistRepo.DeleteWishlistItem(ctx, userID, productID)
+		if err != nil {
+			return err
+		}
+
+		if !deleted {
+			return ErrWishlistItemNotFound
+		}
+
+		return w.wishlistRepo.UpdateProductFavouritedCount(ctx, productID, -1)
+	})
+}
@@ -0,0 +1,133 @@
+// Package pdf writes plain text documents as pdf. The text is laid out line by line in a monospaced font on A4 pages,
+// so text aligned in columns with spaces stays aligned. The font is embedded in the document: DejaVu Sans Mono, which
+// covers the latin, greek and cyrillic scripts, unless a font covering other scripts is given.
+package pdf
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/go-pdf/fpdf"
+	"golang.org/x/image/font/sfnt"
+)
+
+const (
+	pageWidth  = 595.28
+	pageHeight = 841.89
+	margin     = 50
+	fontSize   = 10
+	lineHeight = 14
+	// linesPerPage is how many lines fit between the top and bottom margins
+	linesPerPage = 52
+
+	fontFamily = "text"
+)
+
+//go:embed fonts/DejaVuSansMono.ttf
+var defaultFont []byte
+
+// ErrInvalidFont is returned for a font which isn't a TrueType font.
+var ErrInvalidFont = errors.New("pdf: the font isn't a TrueType font")
+
+// Write writes text as a pdf document titled title to w. The text is set in font, a TrueType font, or in DejaVu Sans
+// Mono when font is nil. Lines longer than the page are wrapped.
+func Write(w io.Writer, font []byte, title string, text string) error {
+	if font == nil {
+		font = defaultFont
+	}
+
+	err := checkFont(font)
+	if err != nil {
+		return err
+	}
+
+	doc := fpdf.NewCustom(&fpdf.InitType{
+		OrientationStr: "P",
+		UnitStr:        "pt",
+		Size:           fpdf.SizeType{Wd: pageWidth, Ht: pageHeight},
+	})
+	doc.SetMargins(margin, margin, margin)
+	doc.SetAutoPageBreak(false, margin)
+	doc.SetTitle(title, true)
+	doc.SetProducer("ecommerce", true)
+	err = addFont(doc, font)
+	if err != nil {
+		return err
+	}
+
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSuffix(strings.ReplaceAll(text, "\r\n", "\n"), "\n"), "\n") {
+		lines = append(lines, wrap(doc, clean(line), pageWidth-2*margin)...)
+	}
+
+	for i, line := range lines {
+		if i%linesPerPage == 0 {
+			doc.AddPage()
+		}
+
+		doc.Text(margin, margin+fontSize+float64(i%linesPerPage*lineHeight), line)
+	}
+
+	return doc.Output(w)
+}
+
+// checkFont returns ErrInvalidFont unless font is a TrueType font, fpdf can't set text in fonts with other outlines.
+func checkFont(font []byte) error {
+	if len(font) < 4 || string(font[:4]) != "\x00\x01\x00\x00" && string(font[:4]) != "true" {
+		return ErrInvalidFont
+	}
+
+	_, err := sfnt.Parse(font)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidFont, err)
+	}
+
+	return nil
+}
+
+// addFont adds font to doc and sets the text in it. The font parser of fpdf panics on some broken fonts, the panic is
+// returned as an error.
+func addFont(doc *fpdf.Fpdf, font []byte) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrInvalidFont, r)
+		}
+	}()
+
+	doc.AddUTF8FontFromBytes(fontFamily, "", font)
+	doc.SetFont(fontFamily, "", fontSize)
+
+	return doc.Error()
+}
+
+// clean expands the tabs of line and leaves out the other control characters.
+func clean(line string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+
+		return r
+	}, strings.ReplaceAll(line, "\t", "    "))
+}
+
+// wrap splits line into lines no wider than width, wide characters such as the ideographs take more room than latin
+// ones.
+func wrap(doc *fpdf.Fpdf, line string, width float64) []string {
+	var lines []string
+	start, lineWidth := 0, 0.0
+	for i, r := range line {
+		runeWidth := doc.GetStringWidth(string(r))
+		if lineWidth+runeWidth > width && i > start {
+			lines = append(lines, line[start:i])
+			start, lineWidth = i, 0
+		}
+		lineWidth += runeWidth
+	}
+
+	return append(lines, line[start:])
+}
@@ -0,0 +1,117 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var streamPattern = regexp.MustCompile(`(?s)/Filter /FlateDecode[^>]*>>\nstream\n(.*?)\nendstream`)
+
+// contents returns the inflated streams of document.
+func contents(t *testing.T, document []byte) [][]byte {
+	var streams [][]byte
+	for _, v := range streamPattern.FindAllSubmatch(document, -1) {
+		r, err := zlib.NewReader(bytes.NewReader(v[1]))
+		require.NoError(t, err)
+
+		stream, err := io.ReadAll(r)
+		require.NoError(t, err)
+
+		streams = append(streams, stream)
+	}
+
+	return streams
+}
+
+// shown returns s as it is written in a content stream, in utf-16 with the characters ending a string escaped.
+func shown(s string) []byte {
+	var b []byte
+	for _, v := range utf16.Encode([]rune(s)) {
+		for _, c := range []byte{byte(v >> 8), byte(v)} {
+			if c == '(' || c == ')' || c == '\\' {
+				b = append(b, '\\')
+			}
+			b = append(b, c)
+		}
+	}
+
+	return b
+}
+
+func TestWriteShowsUnicodeText(t *testing.T) {
+	lines := []string{
+		"Seller  Café Ñandú — Łódź",
+		"Item    Чайник электрический",
+		"Item    Ηλεκτρικός βραστήρας",
+		"Total   25,000 ₩  1,200 ¥  €9.99",
+	}
+
+	var buf bytes.Buffer
+	err := Write(&buf, nil, "Invoice INV/2024/0001", strings.Join(lines, "\n"))
+	require.NoError(t, err)
+
+	document := buf.Bytes()
+	assert.True(t, bytes.HasPrefix(document, []byte("%PDF-")))
+	assert.Contains(t, string(document), "/FontFile2")
+
+	var pages []byte
+	for _, v := range contents(t, document) {
+		pages = append(pages, v...)
+	}
+
+	for _, v := range lines {
+		assert.True(t, bytes.Contains(pages, shown(v)), "%q isn't shown", v)
+	}
+}
+
+func TestWriteWrapsLongLinesAndPages(t *testing.T) {
+	long := strings.Repeat("Ж", 100)
+	var text []string
+	for i := 0; i < linesPerPage; i++ {
+		text = append(text, "line")
+	}
+	text = append(text, long)
+
+	var buf bytes.Buffer
+	err := Write(&buf, nil, "Long", strings.Join(text, "\n"))
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "/Count 2")
+
+	var pages []byte
+	for _, v := range contents(t, buf.Bytes()) {
+		pages = append(pages, v...)
+	}
+
+	// a line of the page holds 82 characters of the font
+	assert.True(t, bytes.Contains(pages, shown(long[:82*len("Ж")])))
+	assert.False(t, bytes.Contains(pages, shown(long[:83*len("Ж")])))
+	assert.True(t, bytes.Contains(pages, shown(long[82*len("Ж"):])))
+}
+
+func TestWriteRejectsInvalidFont(t *testing.T) {
+	tests := []struct {
+		name string
+		font []byte
+	}{
+		{name: "empty", font: []byte{}},
+		{name: "text", font: []byte("not a font at all, just some text")},
+		{name: "truncated", font: defaultFont[:64]},
+		{name: "opentype with cff outlines", font: append([]byte("OTTO"), defaultFont[4:]...)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Write(io.Discard, tt.font, "Invoice", "text")
+			assert.ErrorIs(t, err, ErrInvalidFont)
+		})
+	}
+}
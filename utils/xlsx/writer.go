@@ -0,0 +1,177 @@
+// Package xlsx writes single sheet xlsx workbooks row by row, so big sheets can be streamed without building them
+// in memory.
+package xlsx
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+const (
+	contentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+</Types>`
+	rootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+	workbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+</Relationships>`
+	workbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+	sheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	sheetEnd = `</sheetData></worksheet>`
+)
+
+// Writer writes the rows of a sheet, Close has to be called to complete the workbook.
+type Writer struct {
+	zip   *zip.Writer
+	sheet *bufio.Writer
+	row   int
+	err   error
+}
+
+// NewWriter starts a workbook with a single sheet named sheetName on w.
+func NewWriter(w io.Writer, sheetName string) (*Writer, error) {
+	zw := zip.NewWriter(w)
+
+	var escaped bytes.Buffer
+	err := xml.EscapeText(&escaped, []byte(sheetName))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range []struct {
+		name    string
+		content string
+	}{
+		{name: "[Content_Types].xml", content: contentTypes},
+		{name: "_rels/.rels", content: rootRels},
+		{name: "xl/_rels/workbook.xml.rels", content: workbookRels},
+		{name: "xl/workbook.xml", content: fmt.Sprintf(workbook, escaped.String())},
+	} {
+		f, err := zw.Create(v.name)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = io.WriteString(f, v.content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the sheet is the last file of the archive so it can be written until Close
+	sheet, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	writer := &Writer{
+		zip:   zw,
+		sheet: bufio.NewWriter(sheet),
+	}
+
+	_, err = writer.sheet.WriteString(sheetStart)
+	if err != nil {
+		return nil, err
+	}
+
+	return writer, nil
+}
+
+// Write appends a row to the sheet. Integers and floats are written as numbers, times as text in RFC 3339 and any
+// other value as text.
+func (w *Writer) Write(record []interface{}) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	w.row++
+	row := strconv.Itoa(w.row)
+	w.writeString(`<row r="` + row + `">`)
+	for i, v := range record {
+		ref := columnName(i) + row
+		switch value := v.(type) {
+		case int:
+			w.writeNumber(ref, strconv.Itoa(value))
+		case int64:
+			w.writeNumber(ref, strconv.FormatInt(value, 10))
+		case float64:
+			w.writeNumber(ref, strconv.FormatFloat(value, 'f', -1, 64))
+		case time.Time:
+			w.writeText(ref, value.Format(time.RFC3339))
+		case string:
+			w.writeText(ref, value)
+		default:
+			w.writeText(ref, fmt.Sprint(value))
+		}
+	}
+	w.writeString(`</row>`)
+
+	return w.err
+}
+
+// Close completes the sheet and the workbook, it doesn't close the underlying writer.
+func (w *Writer) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+
+	w.writeString(sheetEnd)
+	if w.err != nil {
+		return w.err
+	}
+
+	err := w.sheet.Flush()
+	if err != nil {
+		return err
+	}
+
+	return w.zip.Close()
+}
+
+func (w *Writer) writeNumber(ref string, value string) {
+	w.writeString(`<c r="` + ref + `"><v>` + value + `</v></c>`)
+}
+
+func (w *Writer) writeText(ref string, value string) {
+	w.writeString(`<c r="` + ref + `" t="inlineStr"><is><t xml:space="preserve">`)
+	if w.err == nil {
+		w.err = xml.EscapeText(w.sheet, []byte(value))
+	}
+	w.writeString(`</t></is></c>`)
+}
+
+func (w *Writer) writeString(s string) {
+	if w.err != nil {
+		return
+	}
+
+	_, w.err = w.sheet.WriteString(s)
+}
+
+// columnName returns the letters of the zero based column i, e.g. A, Z, AA.
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+
+	return name
+}